@@ -175,6 +175,99 @@ func GenerateModels(g *gen.Generator) {
 			return tag
 		}),
 	)
+	g.GenerateModelAs(
+		"api_key",
+		"APIKeyM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("keyID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_api_key_keyID")
+			return tag
+		}),
+		gen.FieldGORMTag("secretHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_api_key_secretHash")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"user_totp",
+		"UserTOTPM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_totp_userID")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"user_identity",
+		"UserIdentityM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_identity_userID_provider,priority:1")
+			return tag
+		}),
+		gen.FieldGORMTag("provider", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_identity_userID_provider,priority:2")
+			tag.Append("uniqueIndex", "idx_user_identity_provider_subject,priority:1")
+			return tag
+		}),
+		gen.FieldGORMTag("subject", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_identity_provider_subject,priority:2")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"one_time_token",
+		"OneTimeTokenM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tokenHash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_one_time_token_tokenHash")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"invitation",
+		"InvitationM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("code", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_invitation_code")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"organization",
+		"OrganizationM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("orgID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_organization_orgID")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"user_quota",
+		"UserQuotaM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_quota_userID")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"audit_event",
+		"AuditEventM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("index", "idx_audit_event_userID")
+			return tag
+		}),
+		gen.FieldGORMTag("requestID", func(tag field.GormTag) field.GormTag {
+			tag.Set("index", "idx_audit_event_requestID")
+			return tag
+		}),
+		gen.FieldGORMTag("createdAt", func(tag field.GormTag) field.GormTag {
+			tag.Set("index", "idx_audit_event_createdAt")
+			return tag
+		}),
+	)
 	g.GenerateModelAs(
 		"casbin_rule",
 		"CasbinRuleM",
@@ -8,6 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"miniblog/internal/apiserver"
+	"miniblog/internal/pkg/known"
+	"net"
+	"net/url"
 	"time"
 
 	genericoptions "miniblog/pkg/options"
@@ -26,6 +29,13 @@ var availableServerModes = sets.New(
 	apiserver.GRPCGatewayServerMode,
 )
 
+// 定义支持的注册模式集合
+var availableRegistrationModes = sets.New(
+	known.RegistrationModeOpen,
+	known.RegistrationModeInviteOnly,
+	known.RegistrationModeClosed,
+)
+
 // ServerOptions 包含服务器配置选项.
 type ServerOptions struct {
 	// ServerMode 定义服务器模式：gRPC、Gin HTTP、HTTP Reverse Proxy.
@@ -34,6 +44,8 @@ type ServerOptions struct {
 	JWTKey string `json:"jwt-key" mapstructure:"jwt-key"`
 	// Expiration 定义 JWT Token 的过期时间.
 	Expiration time.Duration `json:"expiration" mapstructure:"expiration"`
+	// EncryptionKey 定义敏感数据（例如 TOTP 密钥）落库时使用的加密密钥.
+	EncryptionKey string `json:"encryption-key" mapstructure:"encryption-key"`
 	// GRPCOptions 包含 gRPC 配置选项.
 	GRPCOptions *genericoptions.GRPCOptions `json:"grpc" mapstructure:"grpc"`
 	// HTTPOptions 包含 HTTP 配置选项.
@@ -42,18 +54,74 @@ type ServerOptions struct {
 	MySQLOptions *genericoptions.MySQLOptions `json:"mysql" mapstructure:"mysql"`
 	// TLSOptions 包含 TLS 配置选项.
 	TLSOptions *genericoptions.TLSOptions `json:"tls" mapstructure:"tls"`
+	// RedisOptions 包含 Redis 配置选项.
+	RedisOptions *genericoptions.RedisOptions `json:"redis" mapstructure:"redis"`
+	// LockoutOptions 包含登录失败退避和锁定配置选项.
+	LockoutOptions *genericoptions.LockoutOptions `json:"lockout" mapstructure:"lockout"`
+	// AuthzOptions 包含授权策略同步配置选项.
+	AuthzOptions *genericoptions.AuthzOptions `json:"authz" mapstructure:"authz"`
+	// RateLimitOptions 包含请求限流配置选项.
+	RateLimitOptions *genericoptions.RateLimitOptions `json:"ratelimit" mapstructure:"ratelimit"`
+	// IdempotencyOptions 包含幂等键配置选项.
+	IdempotencyOptions *genericoptions.IdempotencyOptions `json:"idempotency" mapstructure:"idempotency"`
+	// QuotaOptions 包含内容配额配置选项.
+	QuotaOptions *genericoptions.QuotaOptions `json:"quota" mapstructure:"quota"`
+	// AuditOptions 包含审计日志配置选项.
+	AuditOptions *genericoptions.AuditOptions `json:"audit" mapstructure:"audit"`
+	// LDAPOptions 包含 LDAP 认证配置选项.
+	LDAPOptions *genericoptions.LDAPOptions `json:"ldap" mapstructure:"ldap"`
+	// OIDCOptions 包含 OIDC 认证配置选项.
+	OIDCOptions *genericoptions.OIDCOptions `json:"oidc" mapstructure:"oidc"`
+	// MailOptions 包含邮件发送配置选项.
+	MailOptions *genericoptions.MailOptions `json:"mail" mapstructure:"mail"`
+	// SMSOptions 包含短信发送配置选项.
+	SMSOptions *genericoptions.SMSOptions `json:"sms" mapstructure:"sms"`
+	// PublicURL 定义 miniblog 对外访问的地址，用于生成邮件中的链接.
+	PublicURL string `json:"public-url" mapstructure:"public-url"`
+	// AllowUnverifiedLogin 定义是否允许邮箱未验证的用户登录.
+	AllowUnverifiedLogin bool `json:"allow-unverified-login" mapstructure:"allow-unverified-login"`
+	// AllowUnverifiedPost 定义是否允许邮箱未验证的用户创建博客.
+	AllowUnverifiedPost bool `json:"allow-unverified-post" mapstructure:"allow-unverified-post"`
+	// RegistrationMode 定义用户注册模式，可选值：open、invite-only、closed.
+	RegistrationMode string `json:"registration-mode" mapstructure:"registration-mode"`
+	// PasswordOptions 包含密码哈希和密码策略配置选项.
+	PasswordOptions *genericoptions.PasswordOptions `json:"password" mapstructure:"password"`
+	// JITProvisioning 定义外部身份首次登录时是否自动创建用户.
+	JITProvisioning bool `json:"jit-provisioning" mapstructure:"jit-provisioning"`
+	// LinkByEmail 定义外部身份首次登录时是否根据已验证的邮箱关联已有用户.
+	LinkByEmail bool `json:"link-by-email" mapstructure:"link-by-email"`
+	// TrustedProxies 定义 Gin 服务器信任的反向代理地址（IP 或 CIDR），只有来自这些地址的请求才使用 X-Forwarded-For 确定客户端 IP.
+	// 默认为空，即不信任任何代理.
+	TrustedProxies []string `json:"trusted-proxies" mapstructure:"trusted-proxies"`
 }
 
 // NewServerOptions 创建带有默认值的 ServerOptions 实例.
 func NewServerOptions() *ServerOptions {
 	opts := &ServerOptions{
-		ServerMode:   apiserver.GRPCGatewayServerMode,
-		JWTKey:       "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
-		Expiration:   2 * time.Hour,
-		GRPCOptions:  genericoptions.NewGRPCOptions(),
-		HTTPOptions:  genericoptions.NewHTTPOptions(),
-		MySQLOptions: genericoptions.NewMySQLOptions(),
-		TLSOptions:   genericoptions.NewTLSOptions(),
+		ServerMode:           apiserver.GRPCGatewayServerMode,
+		JWTKey:               "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
+		Expiration:           2 * time.Hour,
+		EncryptionKey:        "q3Xn7VYc2LmJ8sKdT5pWbE9gRzA4hFuN",
+		GRPCOptions:          genericoptions.NewGRPCOptions(),
+		HTTPOptions:          genericoptions.NewHTTPOptions(),
+		MySQLOptions:         genericoptions.NewMySQLOptions(),
+		TLSOptions:           genericoptions.NewTLSOptions(),
+		RedisOptions:         genericoptions.NewRedisOptions(),
+		LockoutOptions:       genericoptions.NewLockoutOptions(),
+		AuthzOptions:         genericoptions.NewAuthzOptions(),
+		RateLimitOptions:     genericoptions.NewRateLimitOptions(),
+		IdempotencyOptions:   genericoptions.NewIdempotencyOptions(),
+		QuotaOptions:         genericoptions.NewQuotaOptions(),
+		AuditOptions:         genericoptions.NewAuditOptions(),
+		LDAPOptions:          genericoptions.NewLDAPOptions(),
+		OIDCOptions:          genericoptions.NewOIDCOptions(),
+		PasswordOptions:      genericoptions.NewPasswordOptions(),
+		MailOptions:          genericoptions.NewMailOptions(),
+		SMSOptions:           genericoptions.NewSMSOptions(),
+		PublicURL:            "http://127.0.0.1:5555",
+		AllowUnverifiedLogin: true,
+		RegistrationMode:     known.RegistrationModeOpen,
+		JITProvisioning:      true,
 	}
 	opts.GRPCOptions.Addr = ":6666"
 	opts.HTTPOptions.Addr = ":5555"
@@ -71,11 +139,31 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	// 命令行 --expiration 将绑定到 o.Expiration, 若命令行不包含 --server-mode, 则用默认值 o.Expiration
 	// 例如 --expiration=4h
 	fs.DurationVar(&o.Expiration, "expiration", o.Expiration, "The expiration duration of JWT tokens.")
+	fs.StringVar(&o.EncryptionKey, "encryption-key", o.EncryptionKey, "Key used to encrypt sensitive data at rest. Must be at least 16 characters long.")
 
 	o.GRPCOptions.AddFlags(fs, "grpc")
 	o.HTTPOptions.AddFlags(fs, "http")
 	o.MySQLOptions.AddFlags(fs, "mysql")
 	o.TLSOptions.AddFlags(fs, "tls")
+	o.RedisOptions.AddFlags(fs, "redis")
+	o.LockoutOptions.AddFlags(fs, "lockout")
+	o.AuthzOptions.AddFlags(fs, "authz")
+	o.RateLimitOptions.AddFlags(fs, "ratelimit")
+	o.IdempotencyOptions.AddFlags(fs, "idempotency")
+	o.QuotaOptions.AddFlags(fs, "quota")
+	o.AuditOptions.AddFlags(fs, "audit")
+	o.LDAPOptions.AddFlags(fs, "ldap")
+	o.OIDCOptions.AddFlags(fs, "oidc")
+	o.PasswordOptions.AddFlags(fs, "password")
+	o.MailOptions.AddFlags(fs, "mail")
+	o.SMSOptions.AddFlags(fs, "sms")
+	fs.StringVar(&o.PublicURL, "public-url", o.PublicURL, "Public base URL of miniblog, used to build links in emails.")
+	fs.BoolVar(&o.AllowUnverifiedLogin, "allow-unverified-login", o.AllowUnverifiedLogin, "Allow users whose email address is not verified to log in.")
+	fs.BoolVar(&o.AllowUnverifiedPost, "allow-unverified-post", o.AllowUnverifiedPost, "Allow users whose email address is not verified to create posts.")
+	fs.StringVar(&o.RegistrationMode, "registration-mode", o.RegistrationMode, fmt.Sprintf("User registration mode, available options: %v", availableRegistrationModes.UnsortedList()))
+	fs.BoolVar(&o.JITProvisioning, "jit-provisioning", o.JITProvisioning, "Create a user automatically when an external identity signs in for the first time.")
+	fs.BoolVar(&o.LinkByEmail, "link-by-email", o.LinkByEmail, "Link an external identity to the existing user with the same verified email when it signs in for the first time.")
+	fs.StringSliceVar(&o.TrustedProxies, "trusted-proxies", o.TrustedProxies, "IPs or CIDRs of reverse proxies whose X-Forwarded-For header is trusted by the gin server. Empty means trust no proxy.")
 }
 
 // Validate 校验 ServerOptions 中的选项是否合法.
@@ -92,11 +180,64 @@ func (o *ServerOptions) Validate() error {
 		errs = append(errs, errors.New("JWTKey must be at least 6 characters long"))
 	}
 
+	// 校验 EncryptionKey 长度
+	if len(o.EncryptionKey) < 16 {
+		errs = append(errs, errors.New("EncryptionKey must be at least 16 characters long"))
+	}
+
 	// 如果是 gRPC 或 gRPC-Gateway 模式, 校验 gRPC 配置
 	if stringsutil.StringIn(o.ServerMode, []string{apiserver.GRPCServerMode, apiserver.GRPCGatewayServerMode}) {
 		errs = append(errs, o.GRPCOptions.Validate()...)
 	}
 
+	// 校验登录锁定配置
+	errs = append(errs, o.LockoutOptions.Validate()...)
+
+	// 校验授权策略同步配置
+	errs = append(errs, o.AuthzOptions.Validate()...)
+
+	// 校验请求限流配置
+	errs = append(errs, o.RateLimitOptions.Validate()...)
+
+	// 校验幂等键配置
+	errs = append(errs, o.IdempotencyOptions.Validate()...)
+
+	// 校验内容配额配置
+	errs = append(errs, o.QuotaOptions.Validate()...)
+
+	// 校验审计日志配置
+	errs = append(errs, o.AuditOptions.Validate()...)
+
+	// 校验外部认证配置
+	errs = append(errs, o.LDAPOptions.Validate()...)
+	errs = append(errs, o.OIDCOptions.Validate()...)
+
+	// 校验密码哈希和密码策略配置
+	errs = append(errs, o.PasswordOptions.Validate()...)
+
+	// 校验短信配置
+	errs = append(errs, o.SMSOptions.Validate()...)
+
+	// 校验邮件配置
+	errs = append(errs, o.MailOptions.Validate()...)
+	if _, err := url.ParseRequestURI(o.PublicURL); err != nil {
+		errs = append(errs, fmt.Errorf("invalid public url: %w", err))
+	}
+
+	// 校验注册模式是否有效
+	if !availableRegistrationModes.Has(o.RegistrationMode) {
+		errs = append(errs, fmt.Errorf("invalid registration mode: must be one of %v", availableRegistrationModes.UnsortedList()))
+	}
+
+	// 校验信任的代理地址
+	for _, proxy := range o.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("invalid trusted proxy %q: must be an IP or CIDR", proxy))
+			}
+		}
+	}
+
 	// 合并所有错误并返回
 	return utilerrors.NewAggregate(errs)
 }
@@ -105,12 +246,32 @@ func (o *ServerOptions) Validate() error {
 // ----------- 在运行时配置可用 -----------
 func (o *ServerOptions) Config() (*apiserver.Config, error) {
 	return &apiserver.Config{
-		ServerMode:   o.ServerMode,
-		JWTKey:       o.JWTKey,
-		Expiration:   o.Expiration,
-		GRPCOptions:  o.GRPCOptions,
-		HTTPOptions:  o.HTTPOptions,
-		MySQLOptions: o.MySQLOptions,
-		TLSOptions:   o.TLSOptions,
+		ServerMode:           o.ServerMode,
+		JWTKey:               o.JWTKey,
+		Expiration:           o.Expiration,
+		EncryptionKey:        o.EncryptionKey,
+		GRPCOptions:          o.GRPCOptions,
+		HTTPOptions:          o.HTTPOptions,
+		MySQLOptions:         o.MySQLOptions,
+		TLSOptions:           o.TLSOptions,
+		RedisOptions:         o.RedisOptions,
+		LockoutOptions:       o.LockoutOptions,
+		AuthzOptions:         o.AuthzOptions,
+		RateLimitOptions:     o.RateLimitOptions,
+		IdempotencyOptions:   o.IdempotencyOptions,
+		QuotaOptions:         o.QuotaOptions,
+		AuditOptions:         o.AuditOptions,
+		LDAPOptions:          o.LDAPOptions,
+		OIDCOptions:          o.OIDCOptions,
+		PasswordOptions:      o.PasswordOptions,
+		MailOptions:          o.MailOptions,
+		SMSOptions:           o.SMSOptions,
+		PublicURL:            o.PublicURL,
+		AllowUnverifiedLogin: o.AllowUnverifiedLogin,
+		AllowUnverifiedPost:  o.AllowUnverifiedPost,
+		RegistrationMode:     o.RegistrationMode,
+		JITProvisioning:      o.JITProvisioning,
+		LinkByEmail:          o.LinkByEmail,
+		TrustedProxies:       o.TrustedProxies,
 	}, nil
 }
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"miniblog/cmd/mb-apiserver/app/options"
+	"miniblog/internal/apiserver"
+	"miniblog/pkg/authz"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newPolicyCommand 创建授权策略管理相关的子命令.
+func newPolicyCommand(opts *options.ServerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Manage the casbin authorization policies",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newPolicyMigrateCommand(opts))
+	cmd.AddCommand(newPolicyExplainCommand())
+	cmd.AddCommand(newPolicySyncCommand(opts))
+
+	return cmd
+}
+
+// newPolicyMigrateCommand 创建 policy migrate 子命令，用于将旧格式的授权策略迁移为基于逻辑权限的策略.
+func newPolicyMigrateCommand(opts *options.ServerOptions) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Rewrite policies keyed by gRPC methods or HTTP routes into logical permissions",
+		Long: `Rewrite casbin policies whose object is a gRPC full method name (action CALL) or an
+HTTP route (action is an HTTP method) into policies on logical permissions such as user:delete.
+All rules are rewritten in a single transaction. Rules that can not be mapped are reported and left unchanged.`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := newConfig(opts)
+			if err != nil {
+				return err
+			}
+
+			migrations, err := cfg.MigratePolicies(cmd.Context(), dryRun)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, m := range migrations {
+				if len(m.New) == 0 {
+					fmt.Fprintf(out, "skip    %s, %s, %s, %s: no matching permission\n", m.Old.V0, m.Old.V1, m.Old.V2, m.Old.V3)
+					continue
+				}
+				for _, rule := range m.New {
+					fmt.Fprintf(out, "migrate %s, %s, %s, %s => %s, %s, %s, %s\n",
+						m.Old.V0, m.Old.V1, m.Old.V2, m.Old.V3, rule.V0, rule.V1, rule.V2, rule.V3)
+				}
+			}
+			if dryRun {
+				fmt.Fprintln(out, "dry run, no changes were made")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", dryRun, "Only print the migration result without modifying the database.")
+
+	return cmd
+}
+
+// newPolicyExplainCommand 创建 policy explain 子命令，用于离线评估策略文件中的授权策略.
+func newPolicyExplainCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "explain SUBJECT OBJECT ACTION",
+		Short: "Explain an authorization decision against a policy file offline",
+		Long: `Evaluate SUBJECT OBJECT ACTION against a casbin CSV policy file without connecting to the database,
+and print the decision, the matched policies, the role chain that led to each of them and the deny rule that won.`,
+		Example:      `  mb-apiserver policy explain --file policy.csv user-000001 user delete`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := authz.NewAuthzFromFile(file)
+			if err != nil {
+				return err
+			}
+
+			explanation, err := a.Explain(args[0], args[1], args[2])
+			if err != nil {
+				return err
+			}
+
+			printExplanation(cmd.OutOrStdout(), explanation)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", file, "Path to the casbin CSV policy file.")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// newPolicySyncCommand 创建 policy sync 子命令，用于将内置策略和策略包同步到数据库.
+func newPolicySyncCommand(opts *options.ServerOptions) *cobra.Command {
+	var (
+		file string
+		mode = authz.SyncModeCheck
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync the built-in policies and a YAML/CSV policy bundle into the database",
+		Long: `Diff the built-in role::admin/role::user/organization role policies and the rules in a YAML or CSV policy bundle against
+the casbin_rule table, and apply the difference in a single transaction.
+
+Modes:
+  check          only print the difference, exit with an error if the database is out of sync
+  additive       insert the rules that are missing from the database
+  authoritative  also delete p and p2 rules that are not in the bundle; g and g2 rules (role assignments) are never deleted`,
+		Example: `  mb-apiserver policy sync --file configs/policy.yaml --mode check
+  mb-apiserver policy sync --file configs/policy.yaml --mode authoritative`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := newConfig(opts)
+			if err != nil {
+				return err
+			}
+
+			// 命令行指定的策略包优先于配置文件中的策略包
+			if file == "" {
+				file = cfg.AuthzOptions.PolicyFile
+			}
+			db, err := cfg.NewDB()
+			if err != nil {
+				return err
+			}
+
+			result, err := apiserver.SyncPolicies(cmd.Context(), db, file, mode)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, rule := range result.Missing {
+				fmt.Fprintf(out, "+ %s\n", rule)
+			}
+			for _, rule := range result.Extra {
+				fmt.Fprintf(out, "- %s\n", rule)
+			}
+
+			switch {
+			case result.InSync():
+				fmt.Fprintln(out, "policies are in sync")
+			case mode == authz.SyncModeCheck:
+				return fmt.Errorf("policies are out of sync: %d missing, %d extra", len(result.Missing), len(result.Extra))
+			case mode == authz.SyncModeAdditive && len(result.Extra) > 0:
+				fmt.Fprintln(out, "extra rules were kept, use --mode authoritative to delete them")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", file, "Path to the YAML or CSV policy bundle, defaults to --authz.policy-file.")
+	cmd.Flags().StringVar(&mode, "mode", mode, "Sync mode, available options: [check additive authoritative].")
+
+	return cmd
+}
+
+// printExplanation 打印授权判断的解释.
+func printExplanation(out io.Writer, explanation *authz.Explanation) {
+	decision := "deny"
+	if explanation.Allowed {
+		decision = "allow"
+	}
+	fmt.Fprintf(out, "decision: %s\n", decision)
+	fmt.Fprintf(out, "roles: %s\n", strings.Join(explanation.Roles, ", "))
+
+	fmt.Fprintln(out, "matched policies:")
+	if len(explanation.Matches) == 0 {
+		fmt.Fprintln(out, "  (none)")
+	}
+	for _, match := range explanation.Matches {
+		fmt.Fprintf(out, "  %s (via %s)\n", strings.Join(match.Policy, ", "), strings.Join(match.RoleChain, " -> "))
+	}
+
+	if len(explanation.DecidingPolicy) > 0 {
+		fmt.Fprintf(out, "deciding policy: %s\n", strings.Join(explanation.DecidingPolicy, ", "))
+	} else {
+		fmt.Fprintln(out, "deciding policy: (none, no deny policy matched)")
+	}
+}
+
+// newConfig 解析并校验命令行选项，返回应用配置.
+func newConfig(opts *options.ServerOptions) (*apiserver.Config, error) {
+	// 将 viper 中的配置解析到 opts
+	if err := viper.Unmarshal(opts); err != nil {
+		return nil, err
+	}
+
+	// 校验命令行选项
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	return opts.Config()
+}
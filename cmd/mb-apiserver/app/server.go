@@ -73,6 +73,9 @@ func NewMiniBlogCommand() *cobra.Command {
 	// 添加 --version 标志
 	version.AddFlags(cmd.PersistentFlags())
 
+	// 添加授权策略管理子命令
+	cmd.AddCommand(newPolicyCommand(opts))
+
 	return cmd
 }
 
@@ -84,19 +87,9 @@ func run(opts *options.ServerOptions) error {
 	log.Init(logOptions())
 	defer log.Sync()
 
-	// 将 viper 中的配置解析到 opts
-	if err := viper.Unmarshal(opts); err != nil {
-		return err
-	}
-
-	// 校验命令行选项
-	if err := opts.Validate(); err != nil {
-		return err
-	}
-
 	// 获取应用配置.
 	// 将命令行选项和应用配置分开，可以更加灵活的处理 2 种不同类型的配置.
-	cfg, err := opts.Config()
+	cfg, err := newConfig(opts)
 	if err != nil {
 		return err
 	}
@@ -26,6 +26,32 @@ CREATE DATABASE /*!32312 IF NOT EXISTS*/ `miniblog` /*!40100 DEFAULT CHARACTER S
 
 USE `miniblog`;
 
+--
+-- Table structure for table `api_key`
+--
+
+DROP TABLE IF EXISTS `api_key`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `api_key` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `keyID` varchar(36) NOT NULL DEFAULT '' COMMENT 'API Key 唯一 ID',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `name` varchar(64) NOT NULL DEFAULT '' COMMENT 'API Key 名称',
+  `prefix` varchar(16) NOT NULL DEFAULT '' COMMENT 'API Key 明文前缀',
+  `secretHash` varchar(64) NOT NULL DEFAULT '' COMMENT 'API Key 哈希值',
+  `scopes` varchar(512) NOT NULL DEFAULT '' COMMENT 'API Key 权限范围，逗号分隔',
+  `expiresAt` datetime DEFAULT NULL COMMENT 'API Key 过期时间',
+  `lastUsedAt` datetime DEFAULT NULL COMMENT 'API Key 最后使用时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT 'API Key 创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT 'API Key 最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `api_key.keyID` (`keyID`),
+  UNIQUE KEY `api_key.secretHash` (`secretHash`),
+  KEY `idx.api_key.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='用户 API Key 表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `casbin_rule`
 --
@@ -56,13 +82,52 @@ LOCK TABLES `casbin_rule` WRITE;
 INSERT INTO `casbin_rule` VALUES
 (18,'g','user-000000','role::admin',NULL,NULL,'',''),
 (21,'p','role::admin','*','*','allow','',''),
-(7,'p','role::user','/v1.MiniBlog/DeleteUser','CALL','deny','',''),
-(8,'p','role::user','/v1.MiniBlog/ListUser','CALL','deny','',''),
-(9,'p','role::user','/v1/users','GET','deny','',''),
-(10,'p','role::user','/v1/users/*','DELETE','deny','','');
+(7,'p','role::user','user','delete','deny','',''),
+(8,'p','role::user','user','list','deny','',''),
+(9,'p','role::user','user','get-roles','deny','',''),
+(22,'p','role::user','invitation','create','deny','',''),
+(23,'p','role::user','invitation','delete','deny','',''),
+(24,'p','role::user','invitation','list','deny','',''),
+(28,'p','role::user','policy','*','deny','',''),
+(29,'p','role::user','role-assignment','*','deny','',''),
+(30,'p2','role::admin','*','*','true','allow',''),
+(31,'p2','role::user','post','update','r2.res.OwnerID == r2.sub','allow',''),
+(32,'p2','role::user','post','delete','r2.res.OwnerID == r2.sub','allow',''),
+(33,'p2','role::org-admin','post','*','true','allow',''),
+(34,'p2','role::org-admin','organization','*','true','allow',''),
+(35,'p2','role::org-admin','organization-member','*','true','allow',''),
+(36,'p2','role::org-member','organization','get','true','allow',''),
+(37,'p2','role::org-member','organization-member','list','true','allow',''),
+(38,'p','role::user','audit-event','*','deny','','');
 /*!40000 ALTER TABLE `casbin_rule` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `casbin_version`
+--
+
+DROP TABLE IF EXISTS `casbin_version`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `casbin_version` (
+  `id` bigint(20) unsigned NOT NULL COMMENT '版本行 ID，固定为 1',
+  `version` bigint(20) NOT NULL DEFAULT 0 COMMENT '策略版本号，每次策略变更时加 1',
+  `message` text COMMENT '最近一次策略变更的消息',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '策略最后变更时间',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='授权策略版本表，用于在实例之间同步策略变更';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `casbin_version`
+--
+
+LOCK TABLES `casbin_version` WRITE;
+/*!40000 ALTER TABLE `casbin_version` DISABLE KEYS */;
+INSERT INTO `casbin_version` VALUES (1,0,NULL,'2024-12-12 03:55:25');
+/*!40000 ALTER TABLE `casbin_version` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `post`
 --
@@ -78,9 +143,12 @@ CREATE TABLE `post` (
   `content` longtext NOT NULL DEFAULT '' COMMENT '博文内容',
   `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '博文创建时间',
   `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '博文最后修改时间',
+  `orgID` varchar(36) NOT NULL DEFAULT '' COMMENT '博文所属的组织 ID，为空表示不属于任何组织',
+  `version` bigint(20) NOT NULL DEFAULT 1 COMMENT '博文版本号，每次修改加 1，用于乐观并发控制',
   PRIMARY KEY (`id`),
   UNIQUE KEY `post.postID` (`postID`),
-  KEY `idx.post.userID` (`userID`)
+  KEY `idx.post.userID` (`userID`),
+  KEY `idx.post.orgID` (`orgID`)
 ) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='博文表';
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -107,9 +175,12 @@ CREATE TABLE `user` (
   `password` varchar(255) NOT NULL DEFAULT '' COMMENT '用户密码（加密后）',
   `nickname` varchar(30) NOT NULL DEFAULT '' COMMENT '用户昵称',
   `email` varchar(256) NOT NULL DEFAULT '' COMMENT '用户电子邮箱地址',
-  `phone` varchar(16) NOT NULL DEFAULT '' COMMENT '用户手机号',
+  `emailVerified` tinyint(1) NOT NULL DEFAULT 0 COMMENT '电子邮箱地址是否已验证',
+  `phone` varchar(16) DEFAULT NULL COMMENT '用户手机号',
+  `sessionsRevokedAt` datetime(3) DEFAULT NULL COMMENT '会话吊销时间，在此之前签发的令牌失效',
   `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '用户创建时间',
   `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '用户最后修改时间',
+  `version` bigint(20) NOT NULL DEFAULT 1 COMMENT '用户版本号，每次修改加 1，用于乐观并发控制',
   PRIMARY KEY (`id`),
   UNIQUE KEY `user.userID` (`userID`),
   UNIQUE KEY `user.username` (`username`),
@@ -124,9 +195,159 @@ CREATE TABLE `user` (
 LOCK TABLES `user` WRITE;
 /*!40000 ALTER TABLE `user` DISABLE KEYS */;
 INSERT INTO `user` VALUES
-(96,'user-000000','root','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','colin404','colin404@foxmail.com','18110000000','2024-12-12 03:55:25','2024-12-12 03:55:25');
+(96,'user-000000','root','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','colin404','colin404@foxmail.com',1,'18110000000',NULL,'2024-12-12 03:55:25','2024-12-12 03:55:25',1);
 /*!40000 ALTER TABLE `user` ENABLE KEYS */;
 UNLOCK TABLES;
+
+--
+-- Table structure for table `user_totp`
+--
+
+DROP TABLE IF EXISTS `user_totp`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `user_totp` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `secret` varchar(255) NOT NULL DEFAULT '' COMMENT 'TOTP 密钥（加密后）',
+  `enabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否已开启两步验证',
+  `lastUsedStep` bigint(20) NOT NULL DEFAULT 0 COMMENT '最后一次使用的时间步，用于防止验证码重放',
+  `recoveryCodes` text NOT NULL DEFAULT '' COMMENT '一次性恢复码（哈希后），逗号分隔',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `user_totp.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='用户 TOTP 两步验证表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `user_identity`
+--
+
+DROP TABLE IF EXISTS `user_identity`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `user_identity` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `provider` varchar(32) NOT NULL DEFAULT '' COMMENT '认证方式，例如 ldap、oidc',
+  `subject` varchar(255) NOT NULL DEFAULT '' COMMENT '用户在认证方式中的唯一标识',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `user_identity.userID_provider` (`userID`,`provider`),
+  UNIQUE KEY `user_identity.provider_subject` (`provider`,`subject`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='用户外部身份关联表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `one_time_token`
+--
+
+DROP TABLE IF EXISTS `one_time_token`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `one_time_token` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `purpose` varchar(32) NOT NULL DEFAULT '' COMMENT '令牌用途，例如 password-reset',
+  `tokenHash` varchar(64) NOT NULL DEFAULT '' COMMENT '令牌哈希值',
+  `expiresAt` datetime NOT NULL COMMENT '令牌过期时间',
+  `usedAt` datetime DEFAULT NULL COMMENT '令牌使用时间，为空表示未使用',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `one_time_token.tokenHash` (`tokenHash`),
+  KEY `idx.one_time_token.userID_purpose` (`userID`,`purpose`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='一次性令牌表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `invitation`
+--
+
+DROP TABLE IF EXISTS `invitation`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `invitation` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `code` varchar(32) NOT NULL DEFAULT '' COMMENT '邀请码',
+  `createdBy` varchar(36) NOT NULL DEFAULT '' COMMENT '创建邀请码的用户 ID',
+  `maxUses` bigint(20) NOT NULL DEFAULT 1 COMMENT '最多可以使用的次数',
+  `usedCount` bigint(20) NOT NULL DEFAULT 0 COMMENT '已经使用的次数',
+  `expiresAt` datetime NOT NULL COMMENT '过期时间',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `invitation.code` (`code`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='邀请码表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `organization`
+--
+
+DROP TABLE IF EXISTS `organization`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `organization` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `orgID` varchar(36) NOT NULL DEFAULT '' COMMENT '组织唯一 ID',
+  `name` varchar(255) NOT NULL DEFAULT '' COMMENT '组织名称',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '组织描述',
+  `ownerID` varchar(36) NOT NULL DEFAULT '' COMMENT '创建组织的用户 ID',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `organization.orgID` (`orgID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='组织表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+--
+-- Table structure for table `audit_event`
+--
+
+DROP TABLE IF EXISTS `audit_event`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `audit_event` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `requestID` varchar(64) NOT NULL DEFAULT '' COMMENT '请求 ID',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '操作者的用户 ID',
+  `impersonatorID` varchar(36) NOT NULL DEFAULT '' COMMENT '代理身份的真实操作者的用户 ID',
+  `method` varchar(255) NOT NULL DEFAULT '' COMMENT '调用的 gRPC 方法或 HTTP 路由',
+  `resourceIDs` varchar(1024) NOT NULL DEFAULT '' COMMENT '目标资源 ID，多个 ID 以逗号分隔',
+  `code` int(11) NOT NULL DEFAULT 0 COMMENT '请求结果的 HTTP 状态码',
+  `reason` varchar(255) NOT NULL DEFAULT '' COMMENT '失败请求的错误原因',
+  `request` text NOT NULL COMMENT '脱敏后的请求摘要',
+  `clientIP` varchar(64) NOT NULL DEFAULT '' COMMENT '客户端 IP',
+  `latency` bigint(20) NOT NULL DEFAULT 0 COMMENT '请求耗时，单位为毫秒',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '请求时间',
+  PRIMARY KEY (`id`),
+  KEY `idx.audit_event.userID` (`userID`),
+  KEY `idx.audit_event.requestID` (`requestID`),
+  KEY `idx.audit_event.createdAt` (`createdAt`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='审计日志表';
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `user_quota`
+--
+
+DROP TABLE IF EXISTS `user_quota`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `user_quota` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `postsDay` varchar(10) NOT NULL DEFAULT '' COMMENT 'postsToday 计数所属的 UTC 日期',
+  `postsToday` bigint(20) NOT NULL DEFAULT 0 COMMENT '当天已创建的博客数',
+  `storageBytes` bigint(20) NOT NULL DEFAULT 0 COMMENT '博客标题和内容占用的字节数',
+  `createdAt` datetime NOT NULL DEFAULT current_timestamp() COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp() COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `user_quota.userID` (`userID`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci COMMENT='用户内容配额用量表';
+/*!40101 SET character_set_client = @saved_cs_client */;
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
 
 /*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
@@ -0,0 +1,61 @@
+package apiserver
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/audit"
+	genericoptions "miniblog/pkg/options"
+	"strings"
+)
+
+// NewAuditRecorder 根据配置创建审计事件记录器，未开启审计日志时返回 nil.
+func (cfg *Config) NewAuditRecorder(store store.IStore) (*audit.Recorder, error) {
+	var sink audit.Sink
+	switch cfg.AuditOptions.Backend {
+	case genericoptions.AuditBackendNone:
+		return nil, nil
+	case genericoptions.AuditBackendFile:
+		fileSink, err := audit.NewFileSink(cfg.AuditOptions.File)
+		if err != nil {
+			return nil, err
+		}
+		sink = fileSink
+	default:
+		sink = &auditEventSink{store: store}
+	}
+
+	return audit.NewRecorder(sink,
+		audit.WithBufferSize(cfg.AuditOptions.BufferSize),
+		audit.WithErrorHandler(func(err error, events []*audit.Event) {
+			log.Errorw("Failed to write audit events", "err", err, "count", len(events))
+		}),
+	), nil
+}
+
+// auditEventSink 将审计事件写入数据库的 audit_event 表.
+type auditEventSink struct {
+	store store.IStore
+}
+
+// Write 实现 audit.Sink 接口.
+func (s *auditEventSink) Write(ctx context.Context, events []*audit.Event) error {
+	objs := make([]*model.AuditEventM, 0, len(events))
+	for _, event := range events {
+		objs = append(objs, &model.AuditEventM{
+			RequestID:      event.RequestID,
+			UserID:         event.UserID,
+			ImpersonatorID: event.ImpersonatorID,
+			Method:         event.Method,
+			ResourceIDs:    strings.Join(event.ResourceIDs, ","),
+			Code:           int32(event.Code),
+			Reason:         event.Reason,
+			Request:        event.Request,
+			ClientIP:       event.ClientIP,
+			Latency:        event.Latency.Milliseconds(),
+			CreatedAt:      event.Time,
+		})
+	}
+	return s.store.AuditEvent().Create(ctx, objs...)
+}
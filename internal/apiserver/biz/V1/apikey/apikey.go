@@ -0,0 +1,109 @@
+package apikey
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/conversion"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/store/where"
+	"miniblog/pkg/token"
+	"strings"
+)
+
+// prefixLength 定义保存到数据库中的 API Key 明文前缀长度.
+const prefixLength = 12
+
+type APIKeyBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreateAPIKeyRequest) (*apiv1.CreateAPIKeyResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeleteAPIKeyRequest) (*apiv1.DeleteAPIKeyResponse, error)
+	List(ctx context.Context, rq *apiv1.ListAPIKeyRequest) (*apiv1.ListAPIKeyResponse, error)
+
+	APIKeyExpansion
+}
+
+type APIKeyExpansion interface {
+}
+
+type apiKeyBiz struct {
+	store store.IStore
+}
+
+// 确保 apiKeyBiz 实现了 APIKeyBiz 接口.
+var _ APIKeyBiz = (*apiKeyBiz)(nil)
+
+func New(store store.IStore) *apiKeyBiz {
+	return &apiKeyBiz{
+		store: store,
+	}
+}
+
+// Create 实现 APIKeyBiz 接口中的 Create 方法.
+// API Key 的明文只在创建时返回一次，数据库中只保存其哈希值.
+func (b *apiKeyBiz) Create(ctx context.Context, rq *apiv1.CreateAPIKeyRequest) (*apiv1.CreateAPIKeyResponse, error) {
+	secret, hash, err := token.NewAPIKey()
+	if err != nil {
+		log.W(ctx).Errorw("Failed to generate api key", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	apiKeyM := model.APIKeyM{
+		UserID:     contextx.UserID(ctx),
+		Name:       rq.GetName(),
+		Prefix:     secret[:prefixLength],
+		SecretHash: hash,
+		Scopes:     strings.Join(rq.GetScopes(), ","),
+	}
+	if rq.ExpiresAt != nil {
+		expiresAt := rq.GetExpiresAt().AsTime()
+		apiKeyM.ExpiresAt = &expiresAt
+	}
+
+	if err := b.store.APIKey().Create(ctx, &apiKeyM); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.CreateAPIKeyResponse{
+		KeyID:  apiKeyM.KeyID,
+		Secret: secret,
+		ApiKey: conversion.APIKeyModelToAPIKeyV1(&apiKeyM),
+	}, nil
+}
+
+// Delete 实现 APIKeyBiz 接口中的 Delete 方法.
+// 用户只能删除自己的 API Key.
+func (b *apiKeyBiz) Delete(ctx context.Context, rq *apiv1.DeleteAPIKeyRequest) (*apiv1.DeleteAPIKeyResponse, error) {
+	whr := where.T(ctx).F("keyID", rq.GetKeyID())
+	if _, err := b.store.APIKey().Get(ctx, whr); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.APIKey().Delete(ctx, where.T(ctx).F("keyID", rq.GetKeyID())); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.DeleteAPIKeyResponse{}, nil
+}
+
+// List 实现 APIKeyBiz 接口中的 List 方法.
+// 用户只能查看自己的 API Key.
+func (b *apiKeyBiz) List(ctx context.Context, rq *apiv1.ListAPIKeyRequest) (*apiv1.ListAPIKeyResponse, error) {
+	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit())).T(ctx)
+	count, apiKeyList, err := b.store.APIKey().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]*apiv1.APIKey, 0, len(apiKeyList))
+	for _, apiKeyM := range apiKeyList {
+		apiKeys = append(apiKeys, conversion.APIKeyModelToAPIKeyV1(apiKeyM))
+	}
+
+	return &apiv1.ListAPIKeyResponse{
+		TotalCount: count,
+		ApiKeys:    apiKeys,
+	}, nil
+}
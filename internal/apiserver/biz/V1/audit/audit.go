@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"context"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/conversion"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authz"
+	"miniblog/pkg/store/where"
+	"time"
+)
+
+type AuditBiz interface {
+	List(ctx context.Context, rq *apiv1.ListAuditEventsRequest) (*apiv1.ListAuditEventsResponse, error)
+
+	AuditExpansion
+}
+
+type AuditExpansion interface {
+}
+
+type auditBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 auditBiz 实现了 AuditBiz 接口.
+var _ AuditBiz = (*auditBiz)(nil)
+
+func New(store store.IStore, authz *authz.Authz) *auditBiz {
+	return &auditBiz{
+		store: store,
+		authz: authz,
+	}
+}
+
+// List 实现 AuditBiz 接口中的 List 方法.
+// 仅允许被显式授予 audit-event:list 能力的用户（例如管理员）查询审计事件.
+func (b *auditBiz) List(ctx context.Context, rq *apiv1.ListAuditEventsRequest) (*apiv1.ListAuditEventsResponse, error) {
+	resource, action := known.SplitPermission(known.PermissionAuditEventList)
+	ok, err := b.authz.Can(contextx.UserID(ctx), resource, action)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check capability", "permission", known.PermissionAuditEventList, "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !ok {
+		return nil, errno.ErrPermissionDenied
+	}
+
+	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit()))
+	for key, value := range map[string]string{
+		"userID":         rq.GetUserID(),
+		"impersonatorID": rq.GetImpersonatorID(),
+		"method":         rq.GetMethod(),
+		"requestID":      rq.GetRequestID(),
+		"reason":         rq.GetReason(),
+	} {
+		if value != "" {
+			whr.F(key, value)
+		}
+	}
+	if rq.GetResourceID() != "" {
+		// resourceIDs 字段以逗号分隔保存多个资源 ID
+		whr.Q("FIND_IN_SET(?, resourceIDs) > 0", rq.GetResourceID())
+	}
+	if rq.GetStartTime() > 0 {
+		whr.Q("createdAt >= ?", time.Unix(rq.GetStartTime(), 0))
+	}
+	if rq.GetEndTime() > 0 {
+		whr.Q("createdAt < ?", time.Unix(rq.GetEndTime(), 0))
+	}
+
+	count, eventList, err := b.store.AuditEvent().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*apiv1.AuditEvent, 0, len(eventList))
+	for _, eventM := range eventList {
+		events = append(events, conversion.AuditEventModelToAuditEventV1(eventM))
+	}
+
+	return &apiv1.ListAuditEventsResponse{
+		TotalCount: count,
+		Events:     events,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package invitation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/conversion"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authz"
+	"miniblog/pkg/id"
+	"miniblog/pkg/store/where"
+	"time"
+)
+
+const (
+	// codeLength 定义邀请码的长度.
+	codeLength = 12
+	// maxCodeAttempts 定义生成不重复邀请码的最大尝试次数.
+	maxCodeAttempts = 3
+)
+
+type InvitationBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreateInvitationRequest) (*apiv1.CreateInvitationResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeleteInvitationRequest) (*apiv1.DeleteInvitationResponse, error)
+	List(ctx context.Context, rq *apiv1.ListInvitationRequest) (*apiv1.ListInvitationResponse, error)
+
+	InvitationExpansion
+}
+
+type InvitationExpansion interface {
+}
+
+type invitationBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 invitationBiz 实现了 InvitationBiz 接口.
+var _ InvitationBiz = (*invitationBiz)(nil)
+
+func New(store store.IStore, authz *authz.Authz) *invitationBiz {
+	return &invitationBiz{
+		store: store,
+		authz: authz,
+	}
+}
+
+// Create 实现 InvitationBiz 接口中的 Create 方法.
+// 仅允许被显式授予 invitation:create 能力的用户（例如管理员）创建邀请码，未指定过期时间时使用默认有效期.
+func (b *invitationBiz) Create(ctx context.Context, rq *apiv1.CreateInvitationRequest) (*apiv1.CreateInvitationResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionInvitationCreate); err != nil {
+		return nil, err
+	}
+
+	code, err := b.newCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invitationM := model.InvitationM{
+		Code:      code,
+		CreatedBy: contextx.UserID(ctx),
+		MaxUses:   rq.GetMaxUses(),
+		ExpiresAt: time.Now().Add(known.InvitationExpiration),
+	}
+	if rq.ExpiresAt != nil {
+		invitationM.ExpiresAt = rq.GetExpiresAt().AsTime()
+	}
+
+	if err := b.store.Invitation().Create(ctx, &invitationM); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.CreateInvitationResponse{Invitation: conversion.InvitationModelToInvitationV1(&invitationM)}, nil
+}
+
+// Delete 实现 InvitationBiz 接口中的 Delete 方法.
+func (b *invitationBiz) Delete(ctx context.Context, rq *apiv1.DeleteInvitationRequest) (*apiv1.DeleteInvitationResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionInvitationDelete); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.Invitation().Delete(ctx, where.F("code", rq.GetCode())); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.DeleteInvitationResponse{}, nil
+}
+
+// List 实现 InvitationBiz 接口中的 List 方法.
+func (b *invitationBiz) List(ctx context.Context, rq *apiv1.ListInvitationRequest) (*apiv1.ListInvitationResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionInvitationList); err != nil {
+		return nil, err
+	}
+
+	count, invitationList, err := b.store.Invitation().List(ctx, where.P(int(rq.GetOffset()), int(rq.GetLimit())))
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*apiv1.Invitation, 0, len(invitationList))
+	for _, invitationM := range invitationList {
+		invitations = append(invitations, conversion.InvitationModelToInvitationV1(invitationM))
+	}
+
+	return &apiv1.ListInvitationResponse{
+		TotalCount:  count,
+		Invitations: invitations,
+	}, nil
+}
+
+// newCode 使用随机数生成一个数据库中不存在的邀请码.
+func (b *invitationBiz) newCode(ctx context.Context) (string, error) {
+	var buf [8]byte
+	for range maxCodeAttempts {
+		if _, err := rand.Read(buf[:]); err != nil {
+			log.W(ctx).Errorw("Failed to generate invitation code", "err", err)
+			return "", errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+
+		code := id.NewCode(binary.BigEndian.Uint64(buf[:]), id.WithCodeL(codeLength))
+		_, err := b.store.Invitation().Get(ctx, where.F("code", code))
+		if errors.Is(err, errno.ErrInvitationInvalid) {
+			return code, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", errno.ErrInternal.WithMessage("failed to generate a unique invitation code")
+}
@@ -0,0 +1,222 @@
+package organization
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/conversion"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authz"
+	"miniblog/pkg/store/where"
+	"slices"
+	"strings"
+	"time"
+)
+
+type OrganizationBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreateOrganizationRequest) (*apiv1.CreateOrganizationResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) (*apiv1.DeleteOrganizationResponse, error)
+	Get(ctx context.Context, rq *apiv1.GetOrganizationRequest) (*apiv1.GetOrganizationResponse, error)
+	List(ctx context.Context, rq *apiv1.ListOrganizationRequest) (*apiv1.ListOrganizationResponse, error)
+
+	OrganizationExpansion
+}
+
+type OrganizationExpansion interface {
+	AddMember(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) (*apiv1.AddOrganizationMemberResponse, error)
+	RemoveMember(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) (*apiv1.RemoveOrganizationMemberResponse, error)
+	ListMember(ctx context.Context, rq *apiv1.ListOrganizationMemberRequest) (*apiv1.ListOrganizationMemberResponse, error)
+}
+
+type organizationBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 organizationBiz 实现了 OrganizationBiz 接口.
+var _ OrganizationBiz = (*organizationBiz)(nil)
+
+func New(store store.IStore, authz *authz.Authz) *organizationBiz {
+	return &organizationBiz{
+		store: store,
+		authz: authz,
+	}
+}
+
+// Create 实现 OrganizationBiz 接口中的 Create 方法.
+// 组织成员保存在 casbin 的 g2 规则中，创建者成为组织管理员.
+func (b *organizationBiz) Create(ctx context.Context, rq *apiv1.CreateOrganizationRequest) (*apiv1.CreateOrganizationResponse, error) {
+	orgM := model.OrganizationM{
+		Name:        rq.GetName(),
+		Description: rq.GetDescription(),
+		OwnerID:     contextx.UserID(ctx),
+	}
+	if err := b.store.Organization().Create(ctx, &orgM); err != nil {
+		return nil, err
+	}
+
+	if err := b.authz.SetDomainRole(orgM.OwnerID, known.RoleOrgAdmin, orgM.OrgID); err != nil {
+		log.W(ctx).Errorw("Failed to add organization admin", "orgID", orgM.OrgID, "err", err)
+		// 没有管理员的组织无法管理，删除刚创建的组织
+		_ = b.store.Organization().Delete(ctx, where.F("orgID", orgM.OrgID))
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	return &apiv1.CreateOrganizationResponse{OrgID: orgM.OrgID}, nil
+}
+
+// Delete 实现 OrganizationBiz 接口中的 Delete 方法.
+// 组织内的博客和组织成员一并删除.
+func (b *organizationBiz) Delete(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) (*apiv1.DeleteOrganizationResponse, error) {
+	orgM, err := b.get(ctx, rq.GetOrgID(), known.PermissionOrganizationDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		// 博客按组织隔离，需要在组织的上下文中删除组织内的博客
+		orgCtx := contextx.WithOrgID(ctx, orgM.OrgID)
+		if err := b.store.Post().Delete(orgCtx, where.F("orgID", orgM.OrgID)); err != nil {
+			return err
+		}
+		return b.store.Organization().Delete(ctx, where.F("orgID", orgM.OrgID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.authz.RemoveDomain(orgM.OrgID); err != nil {
+		log.W(ctx).Errorw("Failed to remove organization members", "orgID", orgM.OrgID, "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	return &apiv1.DeleteOrganizationResponse{}, nil
+}
+
+// Get 实现 OrganizationBiz 接口中的 Get 方法.
+func (b *organizationBiz) Get(ctx context.Context, rq *apiv1.GetOrganizationRequest) (*apiv1.GetOrganizationResponse, error) {
+	orgM, err := b.get(ctx, rq.GetOrgID(), known.PermissionOrganizationGet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.GetOrganizationResponse{Organization: conversion.OrganizationModelToOrganizationV1(orgM)}, nil
+}
+
+// List 实现 OrganizationBiz 接口中的 List 方法，返回当前用户所属的组织.
+func (b *organizationBiz) List(ctx context.Context, rq *apiv1.ListOrganizationRequest) (*apiv1.ListOrganizationResponse, error) {
+	orgIDs, err := b.authz.Domains(contextx.UserID(ctx))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get organizations of user", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if len(orgIDs) == 0 {
+		return &apiv1.ListOrganizationResponse{Organizations: []*apiv1.Organization{}}, nil
+	}
+
+	count, orgList, err := b.store.Organization().List(ctx, where.P(int(rq.GetOffset()), int(rq.GetLimit())).F("orgID", orgIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]*apiv1.Organization, 0, len(orgList))
+	for _, orgM := range orgList {
+		orgs = append(orgs, conversion.OrganizationModelToOrganizationV1(orgM))
+	}
+
+	return &apiv1.ListOrganizationResponse{
+		TotalCount:    count,
+		Organizations: orgs,
+	}, nil
+}
+
+// AddMember 实现 OrganizationBiz 接口中的 AddMember 方法. 用户已经是组织成员时修改其角色.
+func (b *organizationBiz) AddMember(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) (*apiv1.AddOrganizationMemberResponse, error) {
+	orgM, err := b.get(ctx, rq.GetOrgID(), known.PermissionOrganizationMemberAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, err
+	}
+
+	if err := b.authz.SetDomainRole(rq.GetUserID(), rq.GetRole(), orgM.OrgID); err != nil {
+		log.W(ctx).Errorw("Failed to add organization member", "orgID", orgM.OrgID, "userID", rq.GetUserID(), "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	return &apiv1.AddOrganizationMemberResponse{}, nil
+}
+
+// RemoveMember 实现 OrganizationBiz 接口中的 RemoveMember 方法.
+func (b *organizationBiz) RemoveMember(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) (*apiv1.RemoveOrganizationMemberResponse, error) {
+	orgM, err := b.get(ctx, rq.GetOrgID(), known.PermissionOrganizationMemberRemove)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := b.authz.RemoveDomainMember(rq.GetUserID(), orgM.OrgID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to remove organization member", "orgID", orgM.OrgID, "userID", rq.GetUserID(), "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !removed {
+		return nil, errno.ErrOrganizationMemberNotFound
+	}
+
+	return &apiv1.RemoveOrganizationMemberResponse{}, nil
+}
+
+// ListMember 实现 OrganizationBiz 接口中的 ListMember 方法.
+func (b *organizationBiz) ListMember(ctx context.Context, rq *apiv1.ListOrganizationMemberRequest) (*apiv1.ListOrganizationMemberResponse, error) {
+	orgM, err := b.get(ctx, rq.GetOrgID(), known.PermissionOrganizationMemberList)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := b.authz.DomainMembers(orgM.OrgID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list organization members", "orgID", orgM.OrgID, "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	ret := make([]*apiv1.OrganizationMember, 0, len(members))
+	for userID, roles := range members {
+		for _, role := range roles {
+			ret = append(ret, &apiv1.OrganizationMember{UserID: userID, Role: role})
+		}
+	}
+	slices.SortFunc(ret, func(a, b *apiv1.OrganizationMember) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return &apiv1.ListOrganizationMemberResponse{Members: ret}, nil
+}
+
+// get 查询组织，并使用 ABAC 策略校验当前用户能否对组织执行 permission 对应的操作，
+// 例如组织成员可以查看组织，组织管理员可以管理组织成员.
+func (b *organizationBiz) get(ctx context.Context, orgID string, permission string) (*model.OrganizationM, error) {
+	orgM, err := b.store.Organization().Get(ctx, where.F("orgID", orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	resource, action := known.SplitPermission(permission)
+	res := authz.Resource{ID: orgM.OrgID, OwnerID: orgM.OwnerID, OrgID: orgM.OrgID}
+	env := authz.NewEnvironment(time.Now(), contextx.ClientIP(ctx))
+
+	allowed, err := b.authz.AuthorizeResource(contextx.UserID(ctx), resource, action, res, env)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to authorize organization", "orgID", orgID, "permission", permission, "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		return nil, errno.ErrPermissionDenied
+	}
+	return orgM, nil
+}
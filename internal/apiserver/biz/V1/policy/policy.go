@@ -0,0 +1,236 @@
+package policy
+
+import (
+	"context"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/conversion"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authz"
+	"miniblog/pkg/store/where"
+	"slices"
+	"strings"
+)
+
+type PolicyBiz interface {
+	List(ctx context.Context, rq *apiv1.ListPolicyRequest) (*apiv1.ListPolicyResponse, error)
+	Add(ctx context.Context, rq *apiv1.AddPolicyRequest) (*apiv1.AddPolicyResponse, error)
+	Remove(ctx context.Context, rq *apiv1.RemovePolicyRequest) (*apiv1.RemovePolicyResponse, error)
+	Explain(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) (*apiv1.ExplainAuthorizationResponse, error)
+
+	PolicyExpansion
+}
+
+type PolicyExpansion interface {
+	ListRoleAssignment(ctx context.Context, rq *apiv1.ListRoleAssignmentRequest) (*apiv1.ListRoleAssignmentResponse, error)
+	AddRoleAssignment(ctx context.Context, rq *apiv1.AddRoleAssignmentRequest) (*apiv1.AddRoleAssignmentResponse, error)
+	RemoveRoleAssignment(ctx context.Context, rq *apiv1.RemoveRoleAssignmentRequest) (*apiv1.RemoveRoleAssignmentResponse, error)
+	GetUserRoles(ctx context.Context, rq *apiv1.GetUserRolesRequest) (*apiv1.GetUserRolesResponse, error)
+}
+
+type policyBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 policyBiz 实现了 PolicyBiz 接口.
+var _ PolicyBiz = (*policyBiz)(nil)
+
+func New(store store.IStore, authz *authz.Authz) *policyBiz {
+	return &policyBiz{
+		store: store,
+		authz: authz,
+	}
+}
+
+// List 实现 PolicyBiz 接口中的 List 方法.
+func (b *policyBiz) List(ctx context.Context, rq *apiv1.ListPolicyRequest) (*apiv1.ListPolicyResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionPolicyList); err != nil {
+		return nil, err
+	}
+
+	var (
+		rules [][]string
+		err   error
+	)
+	if rq.GetSubject() != "" {
+		rules, err = b.authz.GetFilteredPolicy(0, rq.GetSubject())
+	} else {
+		rules, err = b.authz.GetPolicy()
+	}
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get policies", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	policies := make([]*apiv1.Policy, 0, len(rules))
+	for _, rule := range paginate(rules, rq.GetOffset(), rq.GetLimit()) {
+		policies = append(policies, conversion.PolicyRuleToPolicyV1(rule))
+	}
+
+	return &apiv1.ListPolicyResponse{TotalCount: int64(len(rules)), Policies: policies}, nil
+}
+
+// Add 实现 PolicyBiz 接口中的 Add 方法. 策略会持久化到数据库，并通过 watcher 通知其它实例立即生效；
+// 未配置 watcher 时，其它实例在下一次自动加载策略时生效.
+func (b *policyBiz) Add(ctx context.Context, rq *apiv1.AddPolicyRequest) (*apiv1.AddPolicyResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionPolicyAdd); err != nil {
+		return nil, err
+	}
+
+	p := rq.GetPolicy()
+	added, err := b.authz.AddPolicy(p.GetSubject(), p.GetObject(), p.GetAction(), p.GetEffect())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to add policy", "policy", p, "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !added {
+		return nil, errno.ErrPolicyAlreadyExists
+	}
+
+	log.W(ctx).Infow("Policy added", "policy", p)
+	return &apiv1.AddPolicyResponse{}, nil
+}
+
+// Remove 实现 PolicyBiz 接口中的 Remove 方法.
+func (b *policyBiz) Remove(ctx context.Context, rq *apiv1.RemovePolicyRequest) (*apiv1.RemovePolicyResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionPolicyRemove); err != nil {
+		return nil, err
+	}
+
+	p := rq.GetPolicy()
+	removed, err := b.authz.RemovePolicy(p.GetSubject(), p.GetObject(), p.GetAction(), p.GetEffect())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to remove policy", "policy", p, "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !removed {
+		return nil, errno.ErrPolicyNotFound
+	}
+
+	log.W(ctx).Infow("Policy removed", "policy", p)
+	return &apiv1.RemovePolicyResponse{}, nil
+}
+
+// Explain 解释主体对资源执行动作的授权判断过程，用于排查请求被拒绝的原因.
+func (b *policyBiz) Explain(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) (*apiv1.ExplainAuthorizationResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionPolicyExplain); err != nil {
+		return nil, err
+	}
+
+	explanation, err := b.authz.Explain(rq.GetSubject(), rq.GetObject(), rq.GetAction())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to explain authorization", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	return conversion.ExplanationToExplainAuthorizationResponseV1(explanation), nil
+}
+
+// ListRoleAssignment 查询角色分配列表，可以按主体和角色过滤.
+func (b *policyBiz) ListRoleAssignment(ctx context.Context, rq *apiv1.ListRoleAssignmentRequest) (*apiv1.ListRoleAssignmentResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionRoleAssignmentList); err != nil {
+		return nil, err
+	}
+
+	rules, err := b.authz.GetFilteredGroupingPolicy(0, rq.GetSubject(), rq.GetRole())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get role assignments", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	assignments := make([]*apiv1.RoleAssignment, 0, len(rules))
+	for _, rule := range paginate(rules, rq.GetOffset(), rq.GetLimit()) {
+		assignments = append(assignments, conversion.GroupingRuleToRoleAssignmentV1(rule))
+	}
+
+	return &apiv1.ListRoleAssignmentResponse{TotalCount: int64(len(rules)), RoleAssignments: assignments}, nil
+}
+
+// AddRoleAssignment 为用户或角色分配角色. 主体是用户 ID 时，用户必须存在.
+func (b *policyBiz) AddRoleAssignment(ctx context.Context, rq *apiv1.AddRoleAssignmentRequest) (*apiv1.AddRoleAssignmentResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionRoleAssignmentAdd); err != nil {
+		return nil, err
+	}
+
+	ra := rq.GetRoleAssignment()
+	if !strings.HasPrefix(ra.GetSubject(), known.RolePrefix) {
+		if _, err := b.store.User().Get(ctx, where.F("userID", ra.GetSubject())); err != nil {
+			return nil, err
+		}
+	}
+
+	added, err := b.authz.AddGroupingPolicy(ra.GetSubject(), ra.GetRole())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to add role assignment", "roleAssignment", ra, "err", err)
+		return nil, errno.ErrAddRole.WithMessage("%s", err.Error())
+	}
+	if !added {
+		return nil, errno.ErrRoleAssignmentAlreadyExists
+	}
+
+	log.W(ctx).Infow("Role assignment added", "roleAssignment", ra)
+	return &apiv1.AddRoleAssignmentResponse{}, nil
+}
+
+// RemoveRoleAssignment 取消角色分配. 管理员不能取消自己的管理员角色.
+func (b *policyBiz) RemoveRoleAssignment(ctx context.Context, rq *apiv1.RemoveRoleAssignmentRequest) (*apiv1.RemoveRoleAssignmentResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionRoleAssignmentRemove); err != nil {
+		return nil, err
+	}
+
+	ra := rq.GetRoleAssignment()
+	if ra.GetSubject() == contextx.UserID(ctx) && ra.GetRole() == known.RoleAdmin {
+		return nil, errno.ErrRemoveOwnAdminRole
+	}
+
+	removed, err := b.authz.RemoveGroupingPolicy(ra.GetSubject(), ra.GetRole())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to remove role assignment", "roleAssignment", ra, "err", err)
+		return nil, errno.ErrRemoveRole.WithMessage("%s", err.Error())
+	}
+	if !removed {
+		return nil, errno.ErrRoleAssignmentNotFound
+	}
+
+	log.W(ctx).Infow("Role assignment removed", "roleAssignment", ra)
+	return &apiv1.RemoveRoleAssignmentResponse{}, nil
+}
+
+// GetUserRoles 查询用户直接分配的角色，以及通过角色继承实际拥有的全部角色.
+func (b *policyBiz) GetUserRoles(ctx context.Context, rq *apiv1.GetUserRolesRequest) (*apiv1.GetUserRolesResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionUserGetRoles); err != nil {
+		return nil, err
+	}
+
+	if _, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, err
+	}
+
+	roles, err := b.authz.GetRolesForUser(rq.GetUserID())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get roles for user", "userID", rq.GetUserID(), "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	effectiveRoles, err := b.authz.GetImplicitRolesForUser(rq.GetUserID())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get implicit roles for user", "userID", rq.GetUserID(), "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	slices.Sort(roles)
+	slices.Sort(effectiveRoles)
+	return &apiv1.GetUserRolesResponse{Roles: roles, EffectiveRoles: effectiveRoles}, nil
+}
+
+// paginate 返回 rules 中从 offset 开始的最多 limit 条规则.
+func paginate(rules [][]string, offset, limit int64) [][]string {
+	total := int64(len(rules))
+	start := min(offset, total)
+	end := min(start+limit, total)
+	return rules[start:end]
+}
@@ -2,13 +2,24 @@ package post
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	quotav1 "miniblog/internal/apiserver/biz/V1/quota"
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/conversion"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authz"
 	"miniblog/pkg/store/where"
+	"time"
 
 	"github.com/jinzhu/copier"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type PostBiz interface {
@@ -26,23 +37,52 @@ type PostExpansion interface {
 
 type postBiz struct {
 	store store.IStore
+	authz *authz.Authz
+	quota quotav1.QuotaExpansion
+	// allowUnverifiedPost 定义是否允许邮箱未验证的用户创建博客
+	allowUnverifiedPost bool
 }
 
 // 确保 postBiz 实现了 PostBiz 接口.
 var _ PostBiz = (*postBiz)(nil)
 
-func New(store store.IStore) *postBiz {
+func New(store store.IStore, authz *authz.Authz, quota quotav1.QuotaExpansion, allowUnverifiedPost bool) *postBiz {
 	return &postBiz{
-		store: store,
+		store:               store,
+		authz:               authz,
+		quota:               quota,
+		allowUnverifiedPost: allowUnverifiedPost,
 	}
 }
 
 // Create 实现 PostBiz 接口中的 Create 方法.
 func (b *postBiz) Create(ctx context.Context, rq *apiv1.CreatePostRequest) (*apiv1.CreatePostResponse, error) {
+	// 不允许邮箱未验证的用户创建博客
+	if !b.allowUnverifiedPost {
+		userM, err := b.store.User().Get(ctx, where.F("userID", contextx.UserID(ctx)))
+		if err != nil {
+			return nil, err
+		}
+		if !userM.EmailVerified {
+			return nil, errno.ErrEmailNotVerified
+		}
+	}
+
 	var postM model.PostM
 	_ = copier.Copy(&postM, rq)
-
-	if err := b.store.Post().Create(ctx, &postM); err != nil {
+	// 博客的作者是当前用户，修改和删除博客时据此判断所有权
+	postM.UserID = contextx.UserID(ctx)
+	// 博客属于请求访问的组织，之后只能在该组织中查询到
+	postM.OrgID = contextx.OrgID(ctx)
+
+	// 在同一个事务中扣减配额和创建博客，创建失败时配额用量一起回滚
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.quota.ConsumePost(ctx, postSize(postM.Title, postM.Content)); err != nil {
+			return err
+		}
+		return b.store.Post().Create(ctx, &postM)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -52,21 +92,42 @@ func (b *postBiz) Create(ctx context.Context, rq *apiv1.CreatePostRequest) (*api
 }
 
 // Update 实现 PostBiz 接口中的 Update 方法.
+// 是否允许修改由 ABAC 策略决定，例如作者可以修改自己的博客.
 func (b *postBiz) Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*apiv1.UpdatePostResponse, error) {
 	postM, err := b.store.Post().Get(ctx, where.F("postID", rq.GetPostID()))
 	if err != nil {
 		return nil, err
 	}
 
-	if rq.Title != nil {
-		postM.Title = rq.GetTitle()
+	if err := b.authorize(ctx, known.PermissionPostUpdate, postM); err != nil {
+		return nil, err
+	}
+
+	// 传入 etag 时，博客必须仍是调用方读取到的版本
+	if rq.GetEtag() != "" && !conversion.MatchETag(rq.GetEtag(), conversion.VersionToETag(postM.Version)) {
+		return nil, errno.ErrPreconditionFailed
 	}
 
-	if rq.Content != nil {
-		postM.Content = rq.GetContent()
+	oldSize := postSize(postM.Title, postM.Content)
+	for _, path := range conversion.UpdatePaths(rq, rq.GetUpdateMask()) {
+		switch path {
+		case "title":
+			postM.Title = rq.GetTitle()
+		case "content":
+			postM.Content = rq.GetContent()
+		default:
+			return nil, errno.ErrInvalidArgument.WithMessage("unknown update mask path: %s", path)
+		}
 	}
 
-	if err := b.store.Post().Update(ctx, postM); err != nil {
+	// 存储空间计入博客作者的配额
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.quota.AdjustStorage(ctx, postM.UserID, postSize(postM.Title, postM.Content)-oldSize); err != nil {
+			return err
+		}
+		return b.store.Post().Update(ctx, postM)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -74,8 +135,28 @@ func (b *postBiz) Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*api
 }
 
 // Delete 实现 PostBiz 接口中的 Delete 方法.
+// 是否允许删除由 ABAC 策略决定，只要有一篇博客不允许删除，就不删除任何博客.
 func (b *postBiz) Delete(ctx context.Context, rq *apiv1.DeletePostRequest) (*apiv1.DeletePostResponse, error) {
-	if err := b.store.Post().Delete(ctx, where.F("postID", rq.GetPostIDs())); err != nil {
+	_, postList, err := b.store.Post().List(ctx, where.F("postID", rq.GetPostIDs()))
+	if err != nil {
+		return nil, err
+	}
+	for _, postM := range postList {
+		if err := b.authorize(ctx, known.PermissionPostDelete, postM); err != nil {
+			return nil, err
+		}
+	}
+
+	// 释放博客作者占用的存储空间
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		for _, postM := range postList {
+			if err := b.quota.AdjustStorage(ctx, postM.UserID, -postSize(postM.Title, postM.Content)); err != nil {
+				return err
+			}
+		}
+		return b.store.Post().Delete(ctx, where.F("postID", rq.GetPostIDs()))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -89,9 +170,18 @@ func (b *postBiz) Get(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.Get
 		return nil, err
 	}
 
-	return &apiv1.GetPostResponse{
-		Post: conversion.PostModelToPostV1(postM),
-	}, nil
+	resp := &apiv1.GetPostResponse{
+		Etag:         conversion.VersionToETag(postM.Version),
+		LastModified: timestamppb.New(postM.UpdatedAt),
+	}
+	// 博客没有修改时不返回博客内容，调用方继续使用缓存
+	if conversion.NotModified(rq.GetIfNoneMatch(), rq.GetIfModifiedSince(), resp.Etag, postM.UpdatedAt) {
+		resp.NotModified = true
+		return resp, nil
+	}
+
+	resp.Post = conversion.PostModelToPostV1(postM)
+	return resp, nil
 }
 
 // List 实现 PostBiz 接口中的 List 方法.
@@ -106,6 +196,13 @@ func (b *postBiz) List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.L
 		return nil, err
 	}
 
+	// 文章列表没有变化时不返回文章，调用方继续使用缓存.
+	// 删除博客不会留下修改时间，因此文章列表只支持通过 etag 判断
+	etag := listETag(count, postList)
+	if conversion.NotModified(rq.GetIfNoneMatch(), nil, etag, time.Time{}) {
+		return &apiv1.ListPostResponse{NotModified: true, Etag: etag}, nil
+	}
+
 	posts := make([]*apiv1.Post, 0, len(postList))
 	for _, postM := range postList {
 		convented := conversion.PostModelToPostV1(postM)
@@ -115,5 +212,40 @@ func (b *postBiz) List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.L
 	return &apiv1.ListPostResponse{
 		TotalCount: count,
 		Posts:      posts,
+		Etag:       etag,
 	}, nil
 }
+
+// listETag 根据文章总数以及每篇博客的 ID 和版本号计算文章列表的 etag，
+// 列表中的博客被修改、增加或删除时 etag 都会变化.
+func listETag(count int64, postList []*model.PostM) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d", count)
+	for _, postM := range postList {
+		fmt.Fprintf(h, ";%s:%d", postM.PostID, postM.Version)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// authorize 使用 ABAC 策略校验当前用户能否对博客执行 permission 对应的操作.
+// 策略可以根据博客的作者、用户在博客所属组织中的角色以及请求的时间、客户端 IP 进行授权.
+func (b *postBiz) authorize(ctx context.Context, permission string, postM *model.PostM) error {
+	resource, action := known.SplitPermission(permission)
+	res := authz.Resource{ID: postM.PostID, OwnerID: postM.UserID, OrgID: postM.OrgID}
+	env := authz.NewEnvironment(time.Now(), contextx.ClientIP(ctx))
+
+	allowed, err := b.authz.AuthorizeResource(contextx.UserID(ctx), resource, action, res, env)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to authorize post", "postID", postM.PostID, "permission", permission, "err", err)
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if !allowed {
+		return errno.ErrPermissionDenied
+	}
+	return nil
+}
+
+// postSize 返回博客占用的存储空间，即标题和内容的字节数.
+func postSize(title, content string) int64 {
+	return int64(len(title) + len(content))
+}
@@ -0,0 +1,167 @@
+package quota
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authz"
+	"miniblog/pkg/quota"
+	"miniblog/pkg/store/where"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type QuotaBiz interface {
+	GetMy(ctx context.Context, rq *apiv1.GetMyQuotaRequest) (*apiv1.GetMyQuotaResponse, error)
+
+	QuotaExpansion
+}
+
+// QuotaExpansion 定义了供其他业务模块调用的配额操作，需要在修改博客的事务中调用，使配额用量和博客保持一致.
+type QuotaExpansion interface {
+	// ConsumePost 为当前用户记录一次博客创建，博客占用 size 字节的存储空间. 超过配额时返回对应的错误.
+	ConsumePost(ctx context.Context, size int64) error
+	// AdjustStorage 调整 userID 占用的存储空间. delta 大于 0 时校验存储配额，小于 0 时表示释放存储空间.
+	AdjustStorage(ctx context.Context, userID string, delta int64) error
+}
+
+type quotaBiz struct {
+	store store.IStore
+	authz *authz.Authz
+	plans quota.Plans
+	now   func() time.Time
+}
+
+// 确保 quotaBiz 实现了 QuotaBiz 接口.
+var _ QuotaBiz = (*quotaBiz)(nil)
+
+func New(store store.IStore, authz *authz.Authz, plans quota.Plans) *quotaBiz {
+	return &quotaBiz{
+		store: store,
+		authz: authz,
+		plans: plans,
+		now:   time.Now,
+	}
+}
+
+// GetMy 实现 QuotaBiz 接口中的 GetMy 方法.
+func (b *quotaBiz) GetMy(ctx context.Context, rq *apiv1.GetMyQuotaRequest) (*apiv1.GetMyQuotaResponse, error) {
+	userID := contextx.UserID(ctx)
+	limits, err := b.limits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaM, err := b.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := b.now()
+	return &apiv1.GetMyQuotaResponse{
+		Quota: &apiv1.Quota{
+			DailyPosts:   max(limits.DailyPosts, 0),
+			PostsToday:   postsOn(quotaM, quota.Day(now)),
+			MaxStorage:   max(limits.MaxStorage, 0),
+			StorageBytes: quotaM.StorageBytes,
+			ResetAt:      timestamppb.New(quota.NextReset(now)),
+		},
+	}, nil
+}
+
+// ConsumePost 实现 QuotaExpansion 接口中的 ConsumePost 方法.
+func (b *quotaBiz) ConsumePost(ctx context.Context, size int64) error {
+	userID := contextx.UserID(ctx)
+	limits, err := b.limits(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := b.store.UserQuota().Init(ctx, userID); err != nil {
+		return err
+	}
+
+	now := b.now()
+	ok, err := b.store.UserQuota().Consume(ctx, userID, quota.Day(now), 1, size, limits.DailyPosts, limits.MaxStorage)
+	if err != nil || ok {
+		return err
+	}
+
+	// 判断超过的是哪一项配额
+	quotaM, err := b.get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if limits.DailyPosts > 0 && postsOn(quotaM, quota.Day(now))+1 > limits.DailyPosts {
+		log.W(ctx).Warnw("Daily post quota exceeded", "limit", limits.DailyPosts)
+		return errno.DailyPostQuotaExceeded(limits.DailyPosts, quota.NextReset(now))
+	}
+	log.W(ctx).Warnw("Storage quota exceeded", "limit", limits.MaxStorage, "used", quotaM.StorageBytes, "size", size)
+	return errno.StorageQuotaExceeded(limits.MaxStorage)
+}
+
+// AdjustStorage 实现 QuotaExpansion 接口中的 AdjustStorage 方法.
+func (b *quotaBiz) AdjustStorage(ctx context.Context, userID string, delta int64) error {
+	if delta == 0 {
+		return nil
+	}
+
+	var limits quota.Limits
+	if delta > 0 {
+		var err error
+		if limits, err = b.limits(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := b.store.UserQuota().Init(ctx, userID); err != nil {
+		return err
+	}
+
+	ok, err := b.store.UserQuota().Consume(ctx, userID, quota.Day(b.now()), 0, delta, 0, limits.MaxStorage)
+	if err != nil {
+		return err
+	}
+	// 释放存储空间不会失败，占用的存储空间已经为 0 时记录不会被修改
+	if !ok && delta > 0 {
+		log.W(ctx).Warnw("Storage quota exceeded", "userID", userID, "limit", limits.MaxStorage, "size", delta)
+		return errno.StorageQuotaExceeded(limits.MaxStorage)
+	}
+	return nil
+}
+
+// limits 返回 userID 适用的配额.
+func (b *quotaBiz) limits(ctx context.Context, userID string) (quota.Limits, error) {
+	// 请求用户的角色已经由认证中间件获取，其他用户的角色需要从授权器中查询
+	if userID == contextx.UserID(ctx) {
+		return b.plans.Resolve(contextx.Roles(ctx)), nil
+	}
+
+	roles, err := b.authz.GetImplicitRolesForUser(userID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get user roles", "userID", userID, "err", err)
+		return quota.Limits{}, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	return b.plans.Resolve(roles), nil
+}
+
+// get 返回 userID 的配额用量，记录不存在时先创建记录.
+func (b *quotaBiz) get(ctx context.Context, userID string) (*model.UserQuotaM, error) {
+	if err := b.store.UserQuota().Init(ctx, userID); err != nil {
+		return nil, err
+	}
+	return b.store.UserQuota().Get(ctx, where.F("userID", userID))
+}
+
+// postsOn 返回 day 当天已创建的博客数.
+func postsOn(quotaM *model.UserQuotaM, day string) int64 {
+	if quotaM.PostsDay != day {
+		return 0
+	}
+	return quotaM.PostsToday
+}
@@ -0,0 +1,353 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authenticator"
+	"miniblog/pkg/errorsx"
+	"miniblog/pkg/store/where"
+	"miniblog/pkg/token"
+	"regexp"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// invalidUsernameChars 匹配用户名中不允许出现的字符.
+	invalidUsernameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+	// phonePattern 匹配合法的手机号.
+	phonePattern = regexp.MustCompile(`^1[3-9]\d{9}$`)
+)
+
+const (
+	// minUsernameLength 和 maxUsernameLength 是用户名的长度限制，与用户名校验规则保持一致.
+	minUsernameLength = 4
+	maxUsernameLength = 20
+	// maxNicknameLength 是昵称的最大长度.
+	maxNicknameLength = 30
+)
+
+// OIDCLogin 发起 OIDC 登录，返回授权地址和 state.
+// state 是一个短期签名令牌，其中保存了用于校验 ID Token 的 nonce，因此服务端不需要保存会话状态.
+func (b *userBiz) OIDCLogin(ctx context.Context, rq *apiv1.OIDCLoginRequest) (*apiv1.OIDCLoginResponse, error) {
+	provider, err := b.authenticators.OIDC()
+	if err != nil {
+		return nil, errno.ErrAuthProviderNotFound
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(buf)
+
+	state, _, err := token.SignWithPurpose(known.PurposeOIDCState, nonce, known.OIDCStateExpiration)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign oidc state", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	return &apiv1.OIDCLoginResponse{AuthURL: provider.AuthCodeURL(state, nonce), State: state}, nil
+}
+
+// OIDCCallback 使用授权码完成 OIDC 登录.
+func (b *userBiz) OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.LoginResponse, error) {
+	provider, err := b.authenticators.OIDC()
+	if err != nil {
+		return nil, errno.ErrAuthProviderNotFound
+	}
+
+	nonce, err := token.ParseWithPurpose(rq.GetState(), known.PurposeOIDCState)
+	if err != nil {
+		return nil, errno.ErrOIDCStateInvalid
+	}
+
+	identity, err := provider.Exchange(ctx, rq.GetCode(), nonce)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to exchange oidc authorization code", "err", err)
+		if errors.Is(err, authenticator.ErrOIDCExchange) {
+			return nil, errno.ErrUnauthenticated
+		}
+		return nil, errno.ErrAuthProviderUnavailable
+	}
+
+	userM, err := b.resolveIdentity(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.issueLoginToken(ctx, userM)
+}
+
+// LinkIdentity 校验外部身份的用户名和密码，并将其关联到当前用户.
+func (b *userBiz) LinkIdentity(ctx context.Context, rq *apiv1.LinkIdentityRequest) (*apiv1.LinkIdentityResponse, error) {
+	if rq.GetProvider() == authenticator.ProviderLocal {
+		return nil, errno.ErrAuthProviderNotFound
+	}
+
+	identity, err := b.authenticate(ctx, rq.GetProvider(), rq.GetUsername(), rq.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	// 外部身份已经关联到其他用户
+	if _, err := b.store.UserIdentity().Get(ctx, where.F("provider", identity.Provider, "subject", identity.Subject)); err == nil {
+		return nil, errno.ErrIdentityAlreadyLinked
+	} else if !errors.Is(err, errno.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// 当前用户已经关联了该认证方式的其他身份
+	if _, err := b.store.UserIdentity().Get(ctx, where.T(ctx).F("provider", identity.Provider)); err == nil {
+		return nil, errno.ErrIdentityAlreadyLinked
+	} else if !errors.Is(err, errno.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	identityM := &model.UserIdentityM{UserID: contextx.UserID(ctx), Provider: identity.Provider, Subject: identity.Subject}
+	if err := b.store.UserIdentity().Create(ctx, identityM); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.LinkIdentityResponse{}, nil
+}
+
+// UnlinkIdentity 解除当前用户与某个认证方式的身份关联.
+func (b *userBiz) UnlinkIdentity(ctx context.Context, rq *apiv1.UnlinkIdentityRequest) (*apiv1.UnlinkIdentityResponse, error) {
+	if err := b.store.UserIdentity().Delete(ctx, where.T(ctx).F("provider", rq.GetProvider())); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.UnlinkIdentityResponse{}, nil
+}
+
+// authenticate 使用指定的认证方式校验用户名和密码，并按用户名和客户端 IP 限制失败次数.
+func (b *userBiz) authenticate(ctx context.Context, provider, username, password string) (*authenticator.Identity, error) {
+	a, err := b.authenticators.Get(provider)
+	if err != nil {
+		return nil, errno.ErrAuthProviderNotFound
+	}
+
+	// 同时按用户名和客户端 IP 跟踪失败次数. 用户名不存在时同样计数，避免通过锁定行为判断用户是否存在
+	keys := loginGuardKeys(ctx, username)
+	wait, err := b.loginGuard.Check(ctx, keys...)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check login attempts", "err", err)
+		return nil, errno.ErrInternal
+	}
+	if wait > 0 {
+		log.W(ctx).Warnw("Login attempt rejected due to too many failures", "username", username, "retry-after", wait.String())
+		return nil, errno.ErrTooManyLoginAttempts
+	}
+
+	identity, err := a.Authenticate(ctx, username, password)
+	if err != nil {
+		if !errors.Is(err, authenticator.ErrInvalidCredentials) {
+			log.W(ctx).Errorw("Failed to authenticate", "provider", a.Name(), "err", err)
+			if errx := new(errorsx.ErrorX); errors.As(err, &errx) {
+				return nil, errx
+			}
+			return nil, errno.ErrAuthProviderUnavailable
+		}
+
+		if err := b.loginGuard.Fail(ctx, keys...); err != nil {
+			log.W(ctx).Errorw("Failed to record login failure", "err", err)
+		}
+		return nil, errno.ErrLoginFailed
+	}
+
+	// 认证成功后清除该用户名的失败记录. 客户端 IP 的失败记录不清除，避免攻击者用自己的账号重置计数
+	if err := b.loginGuard.Reset(ctx, keys[0]); err != nil {
+		log.W(ctx).Errorw("Failed to reset login failures", "err", err)
+	}
+
+	return identity, nil
+}
+
+// resolveIdentity 返回身份对应的 miniblog 用户.
+// 外部身份首次登录时，按配置根据已验证的邮箱关联已有用户，或者在注册模式允许时自动创建新用户.
+func (b *userBiz) resolveIdentity(ctx context.Context, identity *authenticator.Identity) (*model.UserM, error) {
+	if identity.Provider == authenticator.ProviderLocal {
+		return b.store.User().Get(ctx, where.F("userID", identity.Subject))
+	}
+
+	identityM, err := b.store.UserIdentity().Get(ctx, where.F("provider", identity.Provider, "subject", identity.Subject))
+	if err == nil {
+		return b.store.User().Get(ctx, where.F("userID", identityM.UserID))
+	}
+	if !errors.Is(err, errno.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	var userM *model.UserM
+	if b.authenticators.LinkByEmail && identity.EmailVerified && identity.Email != "" {
+		userM, err = b.findUserByEmail(ctx, identity.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if userM == nil {
+		if !b.authenticators.JITProvisioning {
+			return nil, errno.ErrProvisioningDisabled
+		}
+		if err := b.checkProvisioningAllowed(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 自动创建用户和关联外部身份在同一个事务中完成，避免失败时留下无法登录且占用用户名的用户
+	provisioned := userM == nil
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if provisioned {
+			if userM, err = b.provisionUser(ctx, identity); err != nil {
+				return err
+			}
+		}
+
+		identityM = &model.UserIdentityM{UserID: userM.UserID, Provider: identity.Provider, Subject: identity.Subject}
+		if err := b.store.UserIdentity().Create(ctx, identityM); err != nil {
+			return err
+		}
+		if !provisioned {
+			return nil
+		}
+
+		// 最后添加授权角色，失败时回滚已创建的用户和关联
+		if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser); err != nil {
+			log.W(ctx).Errorw("Failed to add grouping policy for user", "user", userM.UserID, "role", known.RoleUser)
+			return errno.ErrAddRole.WithMessage(err.Error(), "")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if provisioned {
+		// 外部认证方式未验证的邮箱需要用户自行验证
+		b.sendVerificationMail(ctx, userM)
+		log.W(ctx).Infow("Provisioned user from external identity", "userID", userM.UserID, "provider", identity.Provider)
+	}
+
+	log.W(ctx).Infow("Linked external identity", "userID", userM.UserID, "provider", identity.Provider, "subject", identity.Subject)
+	return userM, nil
+}
+
+// findUserByEmail 根据邮箱查找唯一的用户. 邮箱没有唯一约束，匹配到多个用户时不进行关联.
+// 只匹配邮箱已验证的用户，避免攻击者预先注册他人邮箱后接管外部身份.
+func (b *userBiz) findUserByEmail(ctx context.Context, email string) (*model.UserM, error) {
+	count, users, err := b.store.User().List(ctx, where.F("email", email, "emailVerified", true).L(2))
+	if err != nil {
+		return nil, err
+	}
+	if count != 1 {
+		return nil, nil
+	}
+	return users[0], nil
+}
+
+// provisionUser 根据外部身份信息自动创建用户，需要在事务中调用.
+// 自动创建的用户使用随机密码，只能通过外部身份登录.
+func (b *userBiz) provisionUser(ctx context.Context, identity *authenticator.Identity) (*model.UserM, error) {
+	username, err := b.availableUsername(ctx, identity.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	userM := &model.UserM{
+		Username: username,
+		Password: base64.RawURLEncoding.EncodeToString(password),
+		Nickname: truncate(identity.Nickname, maxNicknameLength),
+		Email:    identity.Email,
+		// 外部认证方式已经验证过的邮箱无需再次验证
+		EmailVerified: identity.EmailVerified,
+	}
+	if userM.Nickname == "" {
+		userM.Nickname = username
+	}
+	// 手机号有唯一约束，只有格式合法且未被占用时才使用
+	if phonePattern.MatchString(identity.Phone) {
+		if _, err := b.store.User().Get(ctx, where.F("phone", identity.Phone)); errors.Is(err, errno.ErrUserNotFound) {
+			userM.Phone = identity.Phone
+		}
+	}
+
+	if err := b.store.User().Create(ctx, userM); err != nil {
+		return nil, err
+	}
+	return userM, nil
+}
+
+// availableUsername 根据外部身份的用户名生成一个符合规则且未被占用的用户名.
+func (b *userBiz) availableUsername(ctx context.Context, name string) (string, error) {
+	base := truncate(invalidUsernameChars.ReplaceAllString(name, "_"), maxUsernameLength)
+	for len(base) < minUsernameLength {
+		base += "_"
+	}
+
+	candidate := base
+	for range 5 {
+		_, err := b.store.User().Get(ctx, where.F("username", candidate))
+		if errors.Is(err, errno.ErrUserNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+		candidate = fmt.Sprintf("%s_%04d", truncate(base, maxUsernameLength-5), n.Int64())
+	}
+
+	return "", errno.ErrUserAlreadyExists
+}
+
+// issueLoginToken 为认证成功的用户签发访问令牌. 如果用户开启了两步验证，则只返回挑战令牌.
+// 不允许未验证邮箱的用户登录时，邮箱未验证的用户会被拒绝.
+func (b *userBiz) issueLoginToken(ctx context.Context, userM *model.UserM) (*apiv1.LoginResponse, error) {
+	if err := b.checkEmailVerifiedForLogin(userM); err != nil {
+		return nil, err
+	}
+
+	userID := userM.UserID
+
+	// 如果用户开启了两步验证，只返回短期挑战令牌，需要调用 LoginVerify 完成登录
+	if challenge, err := b.loginChallenge(ctx, userID); err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	tk, expiration, err := token.Sign(userID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	return &apiv1.LoginResponse{Token: tk, ExpireAt: timestamppb.New(expiration)}, nil
+}
+
+// truncate 将字符串截断到最多 n 个字符.
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/store/where"
+	"miniblog/pkg/token"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Impersonate 以指定用户的身份签发短期令牌，仅允许被显式授予 user:impersonate 能力的用户（例如管理员）调用.
+// 不能代理管理员. 令牌中同时记录真实操作者，代理期间的请求会在日志和审计日志中记录真实操作者，并且不能执行修改密码、删除账号等敏感操作.
+func (b *userBiz) Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionUserImpersonate); err != nil {
+		return nil, err
+	}
+
+	actor := contextx.UserID(ctx)
+	if rq.GetUserID() == actor {
+		return nil, errno.ErrInvalidArgument.WithMessage("cannot impersonate yourself")
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 不允许代理管理员，避免被授予 user:impersonate 能力的用户借此获得管理员权限
+	roles, err := b.authz.GetImplicitRolesForUser(userM.UserID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get implicit roles for user", "userID", userM.UserID, "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	if slices.Contains(roles, known.RoleAdmin) {
+		return nil, errno.ErrPermissionDenied.WithMessage("cannot impersonate an administrator")
+	}
+
+	tk, expiration, err := token.SignImpersonation(userM.UserID, actor, known.ImpersonationExpiration)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign impersonation token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	log.W(ctx).Infow("Impersonation token issued", "userID", userM.UserID, "impersonator", actor, "expireAt", expiration)
+	return &apiv1.ImpersonateResponse{Token: tk, ExpireAt: timestamppb.New(expiration)}, nil
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/store/where"
+)
+
+// Unlock 解除用户的登录锁定，仅允许被显式授予 user:unlock 能力的用户（例如管理员）调用.
+func (b *userBiz) Unlock(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error) {
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionUserUnlock); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.loginGuard.Reset(ctx, loginGuardKeys(ctx, userM.Username)[0]); err != nil {
+		log.W(ctx).Errorw("Failed to reset login failures", "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	return &apiv1.UnlockUserResponse{}, nil
+}
+
+// loginGuardKeys 返回登录失败计数使用的 key，第一个为用户名维度，第二个为客户端 IP 维度.
+func loginGuardKeys(ctx context.Context, username string) []string {
+	keys := []string{"username:" + username}
+	if ip := contextx.ClientIP(ctx); ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
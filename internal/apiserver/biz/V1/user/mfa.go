@@ -0,0 +1,287 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authn"
+	"miniblog/pkg/store/where"
+	"miniblog/pkg/token"
+	"miniblog/pkg/totp"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recoveryCodeCharset 定义恢复码使用的字符集，去掉了容易混淆的字符.
+const recoveryCodeCharset = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// EnrollTOTP 为当前用户生成新的 TOTP 密钥.
+// 此时两步验证尚未开启，用户需要使用身份验证器生成的验证码调用 VerifyTOTP 确认.
+func (b *userBiz) EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error) {
+	userM, err := b.store.User().Get(ctx, where.T(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	totpM, err := b.store.UserTOTP().Get(ctx, where.T(ctx))
+	if err != nil && !errors.Is(err, errno.ErrTOTPNotEnrolled) {
+		return nil, err
+	}
+	if totpM != nil && totpM.Enabled {
+		return nil, errno.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.W(ctx).Errorw("Failed to generate totp secret", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	// TOTP 密钥需要还原明文才能校验验证码，因此加密保存而不是哈希保存
+	encrypted, err := b.cipher.Encrypt(secret)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to encrypt totp secret", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	if totpM == nil {
+		totpM = &model.UserTOTPM{UserID: userM.UserID, Secret: encrypted}
+		if err := b.store.UserTOTP().Create(ctx, totpM); err != nil {
+			return nil, err
+		}
+	} else {
+		// 重新绑定时覆盖之前未确认的密钥
+		totpM.Secret = encrypted
+		totpM.LastUsedStep = 0
+		totpM.RecoveryCodes = ""
+		if err := b.store.UserTOTP().Update(ctx, totpM); err != nil {
+			return nil, err
+		}
+	}
+
+	return &apiv1.EnrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(known.TOTPIssuer, userM.Username, secret),
+	}, nil
+}
+
+// VerifyTOTP 使用验证码确认绑定，并开启两步验证.
+// 开启成功后返回一次性恢复码，恢复码只会返回这一次.
+func (b *userBiz) VerifyTOTP(ctx context.Context, rq *apiv1.VerifyTOTPRequest) (*apiv1.VerifyTOTPResponse, error) {
+	totpM, err := b.store.UserTOTP().Get(ctx, where.T(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if totpM.Enabled {
+		return nil, errno.ErrTOTPAlreadyEnabled
+	}
+
+	if ok, err := b.checkTOTPCode(totpM, rq.GetCode()); err != nil {
+		log.W(ctx).Errorw("Failed to check totp code", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	} else if !ok {
+		return nil, errno.ErrTOTPCodeInvalid
+	}
+
+	codes, hashes, err := newRecoveryCodes(known.RecoveryCodeCount)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to generate recovery codes", "err", err)
+		return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	totpM.Enabled = true
+	totpM.RecoveryCodes = strings.Join(hashes, ",")
+	if err := b.store.UserTOTP().Update(ctx, totpM); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.VerifyTOTPResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP 关闭两步验证. 需要提供验证码或恢复码.
+func (b *userBiz) DisableTOTP(ctx context.Context, rq *apiv1.DisableTOTPRequest) (*apiv1.DisableTOTPResponse, error) {
+	totpM, err := b.store.UserTOTP().Get(ctx, where.T(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !totpM.Enabled {
+		return nil, errno.ErrTOTPNotEnabled
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.verifySecondFactor(ctx, totpM, rq.GetCode()); err != nil {
+			return err
+		}
+		return b.store.UserTOTP().Delete(ctx, where.T(ctx))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.DisableTOTPResponse{}, nil
+}
+
+// LoginVerify 使用 Login 返回的挑战令牌和两步验证码完成登录.
+// 验证码只有 6 位，因此按用户限制失败次数，防止在挑战令牌有效期内暴力破解.
+func (b *userBiz) LoginVerify(ctx context.Context, rq *apiv1.LoginVerifyRequest) (*apiv1.LoginVerifyResponse, error) {
+	userID, err := token.ParseWithPurpose(rq.GetChallengeToken(), known.PurposeMFAChallenge)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to parse challenge token", "err", err)
+		return nil, errno.ErrChallengeTokenInvalid
+	}
+
+	key := "mfa:" + userID
+	wait, err := b.loginGuard.Check(ctx, key)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check login attempts", "err", err)
+		return nil, errno.ErrInternal
+	}
+	if wait > 0 {
+		log.W(ctx).Warnw("Login verify attempt rejected due to too many failures", "userID", userID, "retry-after", wait.String())
+		return nil, errno.ErrTooManyLoginAttempts
+	}
+
+	totpM, err := b.store.UserTOTP().Get(ctx, where.F("userID", userID))
+	if err != nil || !totpM.Enabled {
+		return nil, errno.ErrChallengeTokenInvalid
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		return b.verifySecondFactor(ctx, totpM, rq.GetCode())
+	})
+	if err != nil {
+		if errors.Is(err, errno.ErrTOTPCodeInvalid) {
+			if err := b.loginGuard.Fail(ctx, key); err != nil {
+				log.W(ctx).Errorw("Failed to record login failure", "err", err)
+			}
+		}
+		return nil, err
+	}
+
+	if err := b.loginGuard.Reset(ctx, key); err != nil {
+		log.W(ctx).Errorw("Failed to reset login failures", "err", err)
+	}
+
+	tk, expiration, err := token.Sign(userID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	return &apiv1.LoginVerifyResponse{Token: tk, ExpireAt: timestamppb.New(expiration)}, nil
+}
+
+// loginChallenge 判断用户是否开启了两步验证，如果开启则签发挑战令牌.
+// 返回 nil 表示用户未开启两步验证，可以直接签发访问令牌.
+func (b *userBiz) loginChallenge(ctx context.Context, userID string) (*apiv1.LoginResponse, error) {
+	totpM, err := b.store.UserTOTP().Get(ctx, where.F("userID", userID))
+	if errors.Is(err, errno.ErrTOTPNotEnrolled) || (err == nil && !totpM.Enabled) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, expiration, err := token.SignWithPurpose(known.PurposeMFAChallenge, userID, known.MFAChallengeExpiration)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign challenge token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	return &apiv1.LoginResponse{
+		MfaRequired:    true,
+		ChallengeToken: challenge,
+		ExpireAt:       timestamppb.New(expiration),
+	}, nil
+}
+
+// verifySecondFactor 校验 TOTP 验证码或一次性恢复码，校验成功后持久化防重放状态或消耗恢复码.
+// 持久化使用带条件的更新，并发请求使用同一个验证码或恢复码时只有一个会成功.
+func (b *userBiz) verifySecondFactor(ctx context.Context, totpM *model.UserTOTPM, code string) error {
+	ok, err := b.checkTOTPCode(totpM, code)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check totp code", "err", err)
+		return errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+
+	var used bool
+	if ok {
+		used, err = b.store.UserTOTP().UseStep(ctx, totpM.UserID, totpM.LastUsedStep)
+	} else {
+		oldCodes := totpM.RecoveryCodes
+		if !consumeRecoveryCode(totpM, code) {
+			return errno.ErrTOTPCodeInvalid
+		}
+		used, err = b.store.UserTOTP().UseRecoveryCodes(ctx, totpM.UserID, oldCodes, totpM.RecoveryCodes)
+	}
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errno.ErrTOTPCodeInvalid
+	}
+	return nil
+}
+
+// checkTOTPCode 校验 TOTP 验证码，并拒绝重复使用已经用过的时间步.
+func (b *userBiz) checkTOTPCode(totpM *model.UserTOTPM, code string) (bool, error) {
+	secret, err := b.cipher.Decrypt(totpM.Secret)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), known.TOTPSkew)
+	if !ok || step <= totpM.LastUsedStep {
+		return false, nil
+	}
+
+	totpM.LastUsedStep = step
+	return true, nil
+}
+
+// consumeRecoveryCode 校验恢复码，匹配成功时将其从列表中移除.
+func consumeRecoveryCode(totpM *model.UserTOTPM, code string) bool {
+	if totpM.RecoveryCodes == "" {
+		return false
+	}
+
+	hashes := strings.Split(totpM.RecoveryCodes, ",")
+	for i, hash := range hashes {
+		if authn.Compare(hash, strings.ToLower(strings.TrimSpace(code))) == nil {
+			totpM.RecoveryCodes = strings.Join(append(hashes[:i], hashes[i+1:]...), ",")
+			return true
+		}
+	}
+	return false
+}
+
+// newRecoveryCodes 生成 n 个一次性恢复码，返回明文及其哈希值.
+func newRecoveryCodes(n int) ([]string, []string, error) {
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+	for range n {
+		buf := make([]byte, 10)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		for i := range buf {
+			buf[i] = recoveryCodeCharset[int(buf[i])%len(recoveryCodeCharset)]
+		}
+
+		code := string(buf[:5]) + "-" + string(buf[5:])
+		hash, err := authn.Encrypt(code)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hash)
+	}
+	return codes, hashes, nil
+}
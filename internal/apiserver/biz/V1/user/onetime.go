@@ -0,0 +1,68 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/pkg/store/where"
+	"time"
+)
+
+// issueOneTimeToken 为用户签发一个指定用途的一次性令牌，返回令牌明文.
+// 数据库中只保存令牌的哈希值，同一用户同一用途之前签发的令牌会失效.
+func (b *userBiz) issueOneTimeToken(ctx context.Context, userID, purpose string, expiration time.Duration) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errno.ErrInternal.WithMessage("%s", err.Error())
+	}
+	plaintext := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := b.saveOneTimeToken(ctx, userID, purpose, plaintext, expiration); err != nil {
+		return "", err
+	}
+
+	return plaintext, nil
+}
+
+// saveOneTimeToken 保存一次性令牌的哈希值，并删除同一用户同一用途之前签发的令牌.
+func (b *userBiz) saveOneTimeToken(ctx context.Context, userID, purpose, plaintext string, expiration time.Duration) error {
+	return b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.OneTimeToken().Delete(ctx, where.F("userID", userID, "purpose", purpose)); err != nil {
+			return err
+		}
+		return b.store.OneTimeToken().Create(ctx, &model.OneTimeTokenM{
+			UserID:    userID,
+			Purpose:   purpose,
+			TokenHash: hashOneTimeToken(plaintext),
+			ExpiresAt: time.Now().Add(expiration),
+		})
+	})
+}
+
+// consumeOneTimeToken 校验并使用一次性令牌. 令牌不存在、用途不匹配、已过期或已使用时返回 errno.ErrOneTimeTokenInvalid.
+func (b *userBiz) consumeOneTimeToken(ctx context.Context, purpose, plaintext string) (*model.OneTimeTokenM, error) {
+	tokenM, err := b.store.OneTimeToken().Get(ctx, where.F("tokenHash", hashOneTimeToken(plaintext), "purpose", purpose))
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenM.UsedAt != nil || time.Now().After(tokenM.ExpiresAt) {
+		return nil, errno.ErrOneTimeTokenInvalid
+	}
+
+	if err := b.store.OneTimeToken().Consume(ctx, tokenM); err != nil {
+		return nil, err
+	}
+
+	return tokenM, nil
+}
+
+// hashOneTimeToken 计算一次性令牌的哈希值. 令牌本身是高熵随机数，使用 SHA-256 即可防止数据库泄露后被直接使用.
+func hashOneTimeToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
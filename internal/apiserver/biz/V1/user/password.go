@@ -0,0 +1,104 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authn"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/store/where"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxAccountsPerEmail 是按邮箱发送邮件时一次最多处理的账号数量.
+const maxAccountsPerEmail = 10
+
+// RequestPasswordReset 向邮箱对应的用户发送重置密码邮件.
+// 无论邮箱是否存在都返回成功，邮件异步发送，避免通过响应内容或响应时间判断邮箱是否已注册.
+func (b *userBiz) RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error) {
+	_, users, err := b.store.User().List(ctx, where.F("email", rq.GetEmail()).L(maxAccountsPerEmail))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) > 0 {
+		go b.sendPasswordResetMails(context.WithoutCancel(ctx), users)
+	}
+
+	return &apiv1.RequestPasswordResetResponse{}, nil
+}
+
+// ResetPassword 使用一次性令牌重置密码，并吊销用户已有的会话.
+func (b *userBiz) ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error) {
+	var userM *model.UserM
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		tokenM, err := b.consumeOneTimeToken(ctx, known.PurposePasswordReset, rq.GetToken())
+		if err != nil {
+			return err
+		}
+
+		userM, err = b.store.User().Get(ctx, where.F("userID", tokenM.UserID))
+		if err != nil {
+			return err
+		}
+
+		if userM.Password, err = authn.Encrypt(rq.GetNewPassword()); err != nil {
+			return errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+
+		// JWT 的签发时间精确到毫秒，这里同样截断到毫秒，重置后签发的令牌不会被误判为已吊销
+		revokedAt := time.Now().Truncate(time.Millisecond)
+		userM.SessionsRevokedAt = &revokedAt
+
+		return b.store.User().Update(ctx, userM)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 重置密码后清除登录失败记录，避免用户被之前的失败尝试锁定
+	if err := b.loginGuard.Reset(ctx, loginGuardKeys(ctx, userM.Username)[0]); err != nil {
+		log.W(ctx).Errorw("Failed to reset login failures", "err", err)
+	}
+
+	return &apiv1.ResetPasswordResponse{}, nil
+}
+
+// sendPasswordResetMails 为每个用户签发重置密码令牌并发送邮件，失败时只记录日志.
+func (b *userBiz) sendPasswordResetMails(ctx context.Context, users []*model.UserM) {
+	ctx, cancel := context.WithTimeout(ctx, known.MailSendTimeout)
+	defer cancel()
+
+	for _, userM := range users {
+		token, err := b.issueOneTimeToken(ctx, userM.UserID, known.PurposePasswordReset, known.PasswordResetExpiration)
+		if err != nil {
+			log.W(ctx).Errorw("Failed to issue password reset token", "userID", userM.UserID, "err", err)
+			continue
+		}
+
+		msg := &mail.Message{
+			To:      []string{userM.Email},
+			Subject: "Reset your miniblog password",
+			Body: fmt.Sprintf("Hi %s,\n\n"+
+				"We received a request to reset the password of your miniblog account. "+
+				"Open the link below within %s to choose a new password:\n\n%s\n\n"+
+				"If you did not request a password reset, you can ignore this email.\n",
+				userM.Username, known.PasswordResetExpiration, b.publicLink("/reset-password", token)),
+		}
+		if err := b.mailer.Send(ctx, msg); err != nil {
+			log.W(ctx).Errorw("Failed to send password reset mail", "userID", userM.UserID, "err", err)
+		}
+	}
+}
+
+// publicLink 返回邮件中使用的外部访问链接.
+func (b *userBiz) publicLink(path string, token string) string {
+	return strings.TrimRight(b.publicURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
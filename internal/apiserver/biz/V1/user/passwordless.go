@@ -0,0 +1,250 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/sms"
+	"miniblog/pkg/store/where"
+)
+
+// RequestMagicLink 向邮箱对应的用户发送一次性登录链接.
+// 无论邮箱是否存在都返回成功，邮件异步发送. 申请次数按邮箱限流，邮箱不存在时同样计数.
+func (b *userBiz) RequestMagicLink(ctx context.Context, rq *apiv1.RequestMagicLinkRequest) (*apiv1.RequestMagicLinkResponse, error) {
+	if err := b.throttleCodeRequest(ctx, known.PurposeMagicLink, rq.GetEmail()); err != nil {
+		return nil, err
+	}
+
+	_, users, err := b.store.User().List(ctx, where.F("email", rq.GetEmail()).L(maxAccountsPerEmail))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) > 0 {
+		go b.sendMagicLinkMails(context.WithoutCancel(ctx), users)
+	}
+
+	return &apiv1.RequestMagicLinkResponse{}, nil
+}
+
+// MagicLinkLogin 使用邮件中的一次性登录链接登录. 登录链接发送到用户邮箱，因此登录成功同时说明邮箱已验证.
+func (b *userBiz) MagicLinkLogin(ctx context.Context, rq *apiv1.MagicLinkLoginRequest) (*apiv1.LoginResponse, error) {
+	var userM *model.UserM
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		tokenM, err := b.consumeOneTimeToken(ctx, known.PurposeMagicLink, rq.GetToken())
+		if err != nil {
+			return err
+		}
+
+		userM, err = b.store.User().Get(ctx, where.F("userID", tokenM.UserID))
+		if err != nil {
+			return err
+		}
+
+		if userM.EmailVerified {
+			return nil
+		}
+		// 只更新邮箱验证状态，避免与并发修改用户资料的请求冲突导致登录失败
+		if err := b.store.User().MarkEmailVerified(ctx, userM.UserID, userM.Email); err != nil {
+			if errors.Is(err, errno.ErrPreconditionFailed) {
+				return errno.ErrOneTimeTokenInvalid
+			}
+			return err
+		}
+		userM.EmailVerified = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b.resetCodeRequests(ctx, known.PurposeMagicLink, userM.Email)
+
+	return b.issueLoginToken(ctx, userM)
+}
+
+// RequestLoginCode 向手机号对应的用户发送一次性登录验证码.
+// 无论手机号是否存在都返回成功，短信异步发送. 申请次数按手机号限流，手机号不存在时同样计数.
+func (b *userBiz) RequestLoginCode(ctx context.Context, rq *apiv1.RequestLoginCodeRequest) (*apiv1.RequestLoginCodeResponse, error) {
+	if err := b.throttleCodeRequest(ctx, known.PurposeLoginCode, rq.GetPhone()); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("phone", rq.GetPhone()))
+	if err != nil {
+		if errors.Is(err, errno.ErrUserNotFound) {
+			return &apiv1.RequestLoginCodeResponse{}, nil
+		}
+		return nil, err
+	}
+
+	go b.sendLoginCode(context.WithoutCancel(ctx), userM)
+
+	return &apiv1.RequestLoginCodeResponse{}, nil
+}
+
+// LoginWithCode 使用短信中的一次性验证码登录.
+// 验证码位数较少，因此按手机号和客户端 IP 限制失败次数，防止暴力破解.
+func (b *userBiz) LoginWithCode(ctx context.Context, rq *apiv1.LoginWithCodeRequest) (*apiv1.LoginResponse, error) {
+	keys := loginCodeGuardKeys(ctx, rq.GetPhone())
+	wait, err := b.loginGuard.Check(ctx, keys...)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check login attempts", "err", err)
+		return nil, errno.ErrInternal
+	}
+	if wait > 0 {
+		log.W(ctx).Warnw("Login code attempt rejected due to too many failures", "phone", rq.GetPhone(), "retry-after", wait.String())
+		return nil, errno.ErrTooManyLoginAttempts
+	}
+
+	var userM *model.UserM
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		var err error
+		if userM, err = b.store.User().Get(ctx, where.F("phone", rq.GetPhone())); err != nil {
+			return err
+		}
+
+		_, err = b.consumeOneTimeToken(ctx, known.PurposeLoginCode, loginCodeSecret(userM.UserID, rq.GetCode()))
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, errno.ErrUserNotFound) && !errors.Is(err, errno.ErrOneTimeTokenInvalid) {
+			return nil, err
+		}
+
+		if err := b.loginGuard.Fail(ctx, keys...); err != nil {
+			log.W(ctx).Errorw("Failed to record login failure", "err", err)
+		}
+		return nil, errno.ErrLoginFailed
+	}
+
+	// 登录成功后清除该手机号的失败记录和申请记录. 客户端 IP 的失败记录不清除
+	if err := b.loginGuard.Reset(ctx, keys[0]); err != nil {
+		log.W(ctx).Errorw("Failed to reset login failures", "err", err)
+	}
+	b.resetCodeRequests(ctx, known.PurposeLoginCode, rq.GetPhone())
+
+	return b.issueLoginToken(ctx, userM)
+}
+
+// sendMagicLinkMails 为每个用户签发一次性登录令牌并发送邮件，失败时只记录日志.
+func (b *userBiz) sendMagicLinkMails(ctx context.Context, users []*model.UserM) {
+	ctx, cancel := context.WithTimeout(ctx, known.MailSendTimeout)
+	defer cancel()
+
+	for _, userM := range users {
+		token, err := b.issueOneTimeToken(ctx, userM.UserID, known.PurposeMagicLink, known.MagicLinkExpiration)
+		if err != nil {
+			log.W(ctx).Errorw("Failed to issue magic link token", "userID", userM.UserID, "err", err)
+			continue
+		}
+
+		msg := &mail.Message{
+			To:      []string{userM.Email},
+			Subject: "Sign in to miniblog",
+			Body: fmt.Sprintf("Hi %s,\n\n"+
+				"Open the link below within %s to sign in to your miniblog account. The link can only be used once:\n\n%s\n\n"+
+				"If you did not request this link, you can ignore this email.\n",
+				userM.Username, known.MagicLinkExpiration, b.publicLink("/login/magic-link", token)),
+		}
+		if err := b.mailer.Send(ctx, msg); err != nil {
+			log.W(ctx).Errorw("Failed to send magic link mail", "userID", userM.UserID, "err", err)
+		}
+	}
+}
+
+// sendLoginCode 为用户生成短信登录验证码并发送短信，失败时只记录日志.
+func (b *userBiz) sendLoginCode(ctx context.Context, userM *model.UserM) {
+	ctx, cancel := context.WithTimeout(ctx, known.SMSSendTimeout)
+	defer cancel()
+
+	code, err := newLoginCode()
+	if err != nil {
+		log.W(ctx).Errorw("Failed to generate login code", "userID", userM.UserID, "err", err)
+		return
+	}
+
+	// 验证码的取值空间较小，不同用户的验证码可能相同，因此与用户 ID 组合后再计算哈希值
+	if err := b.saveOneTimeToken(ctx, userM.UserID, known.PurposeLoginCode, loginCodeSecret(userM.UserID, code), known.LoginCodeExpiration); err != nil {
+		log.W(ctx).Errorw("Failed to save login code", "userID", userM.UserID, "err", err)
+		return
+	}
+
+	msg := &sms.Message{
+		To:   userM.Phone,
+		Body: fmt.Sprintf("Your miniblog login code is %s. It expires in %s. Do not share it with anyone.", code, known.LoginCodeExpiration),
+	}
+	if err := b.smsSender.Send(ctx, msg); err != nil {
+		log.W(ctx).Errorw("Failed to send login code", "userID", userM.UserID, "err", err)
+	}
+}
+
+// throttleCodeRequest 按接收方限制申请登录链接或验证码的频率，超过限制时返回 errno.ErrTooManyCodeRequests.
+func (b *userBiz) throttleCodeRequest(ctx context.Context, purpose, recipient string) error {
+	key := codeRequestKey(purpose, recipient)
+	wait, err := b.loginGuard.Check(ctx, key)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check code requests", "err", err)
+		return errno.ErrInternal
+	}
+	if wait > 0 {
+		log.W(ctx).Warnw("Code request rejected due to rate limit", "purpose", purpose, "retry-after", wait.String())
+		return errno.ErrTooManyCodeRequests
+	}
+
+	// 每次申请都计数，连续申请的等待时间逐渐增加，达到阈值后临时锁定
+	if err := b.loginGuard.Fail(ctx, key); err != nil {
+		log.W(ctx).Errorw("Failed to record code request", "err", err)
+	}
+	return nil
+}
+
+// resetCodeRequests 登录成功后清除接收方的申请记录.
+func (b *userBiz) resetCodeRequests(ctx context.Context, purpose, recipient string) {
+	if err := b.loginGuard.Reset(ctx, codeRequestKey(purpose, recipient)); err != nil {
+		log.W(ctx).Errorw("Failed to reset code requests", "err", err)
+	}
+}
+
+// codeRequestKey 返回申请登录链接或验证码时限流使用的 key.
+func codeRequestKey(purpose, recipient string) string {
+	return "request:" + purpose + ":" + recipient
+}
+
+// loginCodeGuardKeys 返回短信验证码登录失败计数使用的 key，第一个为手机号维度，第二个为客户端 IP 维度.
+func loginCodeGuardKeys(ctx context.Context, phone string) []string {
+	keys := []string{"phone:" + phone}
+	if ip := contextx.ClientIP(ctx); ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+// loginCodeSecret 返回计算短信验证码哈希值时使用的内容.
+func loginCodeSecret(userID, code string) string {
+	return userID + ":" + code
+}
+
+// newLoginCode 生成一个随机的数字验证码.
+func newLoginCode() (string, error) {
+	limit := big.NewInt(1)
+	for range known.LoginCodeLength {
+		limit.Mul(limit, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", known.LoginCodeLength, n.Int64()), nil
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// checkRegistrationAllowed 根据注册模式检查是否允许创建用户.
+// 关闭注册时只有被显式授予 user:create 能力的用户（例如管理员）可以创建用户；邀请注册时必须提供邀请码，邀请码在创建用户的事务中核销.
+func (b *userBiz) checkRegistrationAllowed(ctx context.Context, rq *apiv1.CreateUserRequest) error {
+	switch b.registrationMode {
+	case known.RegistrationModeClosed:
+		if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionUserCreate); err != nil {
+			if errors.Is(err, errno.ErrPermissionDenied) {
+				return errno.ErrRegistrationClosed
+			}
+			return err
+		}
+	case known.RegistrationModeInviteOnly:
+		if rq.GetInviteCode() == "" {
+			return errno.ErrInvitationRequired
+		}
+	}
+	return nil
+}
+
+// checkProvisioningAllowed 根据注册模式检查是否允许通过外部身份自动创建用户.
+// 自动创建用户同样属于注册，关闭注册时不允许；外部身份登录无法提供邀请码，邀请注册时同样不允许.
+func (b *userBiz) checkProvisioningAllowed() error {
+	switch b.registrationMode {
+	case known.RegistrationModeClosed:
+		return errno.ErrRegistrationClosed
+	case known.RegistrationModeInviteOnly:
+		return errno.ErrInvitationRequired
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/apiserver/store"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/pkg/authenticator"
+)
+
+func TestCheckProvisioningAllowed(t *testing.T) {
+	tests := []struct {
+		mode string
+		want error
+	}{
+		{mode: known.RegistrationModeOpen},
+		{mode: known.RegistrationModeClosed, want: errno.ErrRegistrationClosed},
+		{mode: known.RegistrationModeInviteOnly, want: errno.ErrInvitationRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			b := &userBiz{registrationMode: tt.mode}
+			if tt.want == nil {
+				assert.NoError(t, b.checkProvisioningAllowed())
+			} else {
+				assert.ErrorIs(t, b.checkProvisioningAllowed(), tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveIdentityRegistrationMode(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "miniblog.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.UserM{}, &model.UserIdentityM{}))
+
+	authenticators := authenticator.NewRegistry()
+	authenticators.JITProvisioning = true
+	identity := &authenticator.Identity{Provider: "ldap", Subject: "uid=colin", Username: "colin"}
+
+	// 关闭注册或邀请注册时，外部身份首次登录不会自动创建用户
+	for mode, want := range map[string]error{
+		known.RegistrationModeClosed:     errno.ErrRegistrationClosed,
+		known.RegistrationModeInviteOnly: errno.ErrInvitationRequired,
+	} {
+		b := &userBiz{store: store.NewStore(db), authenticators: authenticators, registrationMode: mode}
+		_, err := b.resolveIdentity(context.Background(), identity)
+		assert.ErrorIs(t, err, want, mode)
+	}
+
+	var count int64
+	require.NoError(t, db.Model(&model.UserM{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
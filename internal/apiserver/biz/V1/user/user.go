@@ -10,8 +10,13 @@ import (
 	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authenticator"
 	"miniblog/pkg/authn"
 	"miniblog/pkg/authz"
+	"miniblog/pkg/cipher"
+	"miniblog/pkg/lockout"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/sms"
 	"miniblog/pkg/store/where"
 	"miniblog/pkg/token"
 	"sync"
@@ -35,20 +40,58 @@ type UserExpansion interface {
 	Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error)
 	RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error)
 	ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error)
+	LoginVerify(ctx context.Context, rq *apiv1.LoginVerifyRequest) (*apiv1.LoginVerifyResponse, error)
+	EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error)
+	VerifyTOTP(ctx context.Context, rq *apiv1.VerifyTOTPRequest) (*apiv1.VerifyTOTPResponse, error)
+	DisableTOTP(ctx context.Context, rq *apiv1.DisableTOTPRequest) (*apiv1.DisableTOTPResponse, error)
+	Unlock(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error)
+	Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error)
+	OIDCLogin(ctx context.Context, rq *apiv1.OIDCLoginRequest) (*apiv1.OIDCLoginResponse, error)
+	OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.LoginResponse, error)
+	LinkIdentity(ctx context.Context, rq *apiv1.LinkIdentityRequest) (*apiv1.LinkIdentityResponse, error)
+	UnlinkIdentity(ctx context.Context, rq *apiv1.UnlinkIdentityRequest) (*apiv1.UnlinkIdentityResponse, error)
+	RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error)
+	ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error)
+	VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error)
+	ResendVerification(ctx context.Context, rq *apiv1.ResendVerificationRequest) (*apiv1.ResendVerificationResponse, error)
+	RequestMagicLink(ctx context.Context, rq *apiv1.RequestMagicLinkRequest) (*apiv1.RequestMagicLinkResponse, error)
+	MagicLinkLogin(ctx context.Context, rq *apiv1.MagicLinkLoginRequest) (*apiv1.LoginResponse, error)
+	RequestLoginCode(ctx context.Context, rq *apiv1.RequestLoginCodeRequest) (*apiv1.RequestLoginCodeResponse, error)
+	LoginWithCode(ctx context.Context, rq *apiv1.LoginWithCodeRequest) (*apiv1.LoginResponse, error)
 }
 
 type userBiz struct {
-	store store.IStore
-	authz *authz.Authz
+	store          store.IStore
+	authz          *authz.Authz
+	cipher         *cipher.Cipher
+	loginGuard     *lockout.Guard
+	authenticators *authenticator.Registry
+	mailer         mail.Mailer
+	smsSender      sms.Sender
+	// publicURL 是 miniblog 对外访问的地址，用于生成邮件中的链接
+	publicURL string
+	// allowUnverifiedLogin 定义是否允许邮箱未验证的用户登录
+	allowUnverifiedLogin bool
+	// registrationMode 定义用户注册模式
+	registrationMode string
 }
 
 // 确保 userBiz 实现了 UserBiz 接口.
 var _ UserBiz = (*userBiz)(nil)
 
-func New(store store.IStore, authz *authz.Authz) *userBiz {
+func New(store store.IStore, authz *authz.Authz, cipher *cipher.Cipher, loginGuard *lockout.Guard, authenticators *authenticator.Registry, mailer mail.Mailer, smsSender sms.Sender, publicURL string, allowUnverifiedLogin bool, registrationMode string) *userBiz {
 	return &userBiz{
-		store: store,
-		authz: authz,
+		store:          store,
+		authz:          authz,
+		cipher:         cipher,
+		loginGuard:     loginGuard,
+		authenticators: authenticators,
+		mailer:         mailer,
+		smsSender:      smsSender,
+		publicURL:      publicURL,
+
+		allowUnverifiedLogin: allowUnverifiedLogin,
+		registrationMode:     registrationMode,
 	}
 }
 
@@ -56,10 +99,24 @@ func New(store store.IStore, authz *authz.Authz) *userBiz {
 func (b *userBiz) Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*apiv1.CreateUserResponse, error) {
 	// 注册用户时把明文密码加密操作放在了 hook 中
 
+	if err := b.checkRegistrationAllowed(ctx, rq); err != nil {
+		return nil, err
+	}
+
 	var userM model.UserM
 	_ = copier.Copy(&userM, rq)
 
-	if err := b.store.User().Create(ctx, &userM); err != nil {
+	// 用户创建和邀请码核销在同一个事务中完成，避免邀请码被超额使用
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.User().Create(ctx, &userM); err != nil {
+			return err
+		}
+		if b.registrationMode != known.RegistrationModeInviteOnly {
+			return nil
+		}
+		return b.store.Invitation().Consume(ctx, rq.GetInviteCode())
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -69,6 +126,9 @@ func (b *userBiz) Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*api
 		return nil, errno.ErrAddRole.WithMessage(err.Error(), "")
 	}
 
+	// 发送邮箱验证邮件
+	b.sendVerificationMail(ctx, &userM)
+
 	return &apiv1.CreateUserResponse{UserID: userM.UserID}, nil
 }
 
@@ -79,20 +139,30 @@ func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*api
 		return nil, err
 	}
 
-	if rq.Username != nil {
-		userM.Username = *rq.Username
+	// 传入 etag 时，用户必须仍是调用方读取到的版本
+	if rq.GetEtag() != "" && !conversion.MatchETag(rq.GetEtag(), conversion.VersionToETag(userM.Version)) {
+		return nil, errno.ErrPreconditionFailed
 	}
 
-	if rq.Nickname != nil {
-		userM.Nickname = *rq.Nickname
-	}
-
-	if rq.Email != nil {
-		userM.Email = *rq.Email
-	}
-
-	if rq.Phone != nil {
-		userM.Phone = *rq.Phone
+	var emailChanged bool
+	for _, path := range conversion.UpdatePaths(rq, rq.GetUpdateMask()) {
+		switch path {
+		case "username":
+			userM.Username = rq.GetUsername()
+		case "nickname":
+			userM.Nickname = rq.GetNickname()
+		case "email":
+			// 修改邮箱后需要重新验证
+			if rq.GetEmail() != userM.Email {
+				emailChanged = true
+				userM.Email = rq.GetEmail()
+				userM.EmailVerified = false
+			}
+		case "phone":
+			userM.Phone = rq.GetPhone()
+		default:
+			return nil, errno.ErrInvalidArgument.WithMessage("unknown update mask path: %s", path)
+		}
 	}
 
 	err = b.store.User().Update(ctx, userM)
@@ -100,6 +170,14 @@ func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*api
 		return nil, err
 	}
 
+	if emailChanged {
+		// 发送到旧邮箱的登录链接不能再用于登录，否则会把新邮箱标记为已验证
+		if err := b.store.OneTimeToken().Delete(ctx, where.F("userID", userM.UserID, "purpose", known.PurposeMagicLink)); err != nil {
+			log.W(ctx).Errorw("Failed to delete magic link tokens", "userID", userM.UserID, "err", err)
+		}
+		b.sendVerificationMail(ctx, userM)
+	}
+
 	return &apiv1.UpdateUserResponse{}, nil
 }
 
@@ -137,13 +215,13 @@ func (b *userBiz) Get(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.Get
 // List 实现 UserBiz 接口中的 List 方法.
 func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.ListUserResponse, error) {
 	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit()))
-	// 如果不是 root 用户，只能查看自己的信息
-	if contextx.Username(ctx) != known.AdminUsername {
+	// 如果没有被授予查看所有用户的能力，只能查看自己的信息
+	if err := b.authz.RequireCapability(contextx.UserID(ctx), known.PermissionUserListAll); err != nil {
 		whr.T(ctx)
 	}
 
-	// 如果是 root 用户，userList 将包含所有用户
-	// 如果不是 root 用户，userList 只包含当前用户自己
+	// 如果被授予了查看所有用户的能力（例如管理员），userList 将包含所有用户
+	// 否则 userList 只包含当前用户自己
 	count, userList, err := b.store.User().List(ctx, whr)
 	if err != nil {
 		return nil, err
@@ -164,8 +242,8 @@ func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.L
 			case <-ctx.Done():
 				return nil
 			default:
-				// 查询用户的博客数
-				count, _, err := b.store.Post().List(ctx, where.F("userID", user.UserID))
+				// 查询用户在所有组织中的博客数
+				count, err := b.store.Post().CountByUser(ctx, user.UserID)
 				if err != nil {
 					return err
 				}
@@ -201,26 +279,20 @@ func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.L
 
 // Login 实现 UserBiz 接口中的登陆方法.
 func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error) {
-
-	userM, err := b.store.User().Get(ctx, where.F("username", rq.Username))
+	// 使用请求指定的认证方式校验用户名和密码，默认使用本地密码认证
+	identity, err := b.authenticate(ctx, rq.GetProvider(), rq.GetUsername(), rq.GetPassword())
 	if err != nil {
 		return nil, err
 	}
 
-	// 用户登陆需要对加密后的密码进行对比
-	if err := authn.Compare(userM.Password, rq.Password); err != nil {
-		log.W(ctx).Errorw("Failed to compare password", "err", err)
-		return nil, errno.ErrPasswordInvalid
-	}
-
-	// 如果匹配成功，说明登录成功，签发 token 并返回
-	tk, expiration, err := token.Sign(userM.UserID)
+	// 找到身份对应的用户，外部身份首次登录时会关联或创建用户
+	userM, err := b.resolveIdentity(ctx, identity)
 	if err != nil {
-		log.W(ctx).Errorw("Failed to sign token", "err", err)
-		return nil, errno.ErrSignToken
+		return nil, err
 	}
 
-	return &apiv1.LoginResponse{Token: tk, ExpireAt: timestamppb.New(expiration)}, nil
+	// 认证成功，签发 token 并返回
+	return b.issueLoginToken(ctx, userM)
 }
 
 // RefreshToken 用于刷新用户的身份验证令牌.
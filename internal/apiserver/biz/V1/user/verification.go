@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/store/where"
+	"miniblog/pkg/token"
+	"strings"
+)
+
+// VerifyEmail 使用验证邮件中的签名令牌将用户邮箱标记为已验证.
+// 令牌中包含签发时的邮箱，用户修改邮箱后旧令牌失效.
+func (b *userBiz) VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error) {
+	subject, err := token.ParseWithPurpose(rq.GetToken(), known.PurposeEmailVerification)
+	if err != nil {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	userID, email, ok := strings.Cut(subject, ":")
+	if !ok {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	if userM.Email != email {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	if !userM.EmailVerified {
+		// 邮箱在验证期间被修改时，验证令牌对应的是旧邮箱，不能再使用
+		if err := b.store.User().MarkEmailVerified(ctx, userM.UserID, email); err != nil {
+			if errors.Is(err, errno.ErrPreconditionFailed) {
+				return nil, errno.ErrVerificationTokenInvalid
+			}
+			return nil, err
+		}
+	}
+
+	return &apiv1.VerifyEmailResponse{}, nil
+}
+
+// ResendVerification 向邮箱对应的未验证用户重新发送验证邮件.
+// 无论邮箱是否存在都返回成功，避免通过该接口判断邮箱是否已注册.
+func (b *userBiz) ResendVerification(ctx context.Context, rq *apiv1.ResendVerificationRequest) (*apiv1.ResendVerificationResponse, error) {
+	_, users, err := b.store.User().List(ctx, where.F("email", rq.GetEmail(), "emailVerified", false).L(maxAccountsPerEmail))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userM := range users {
+		b.sendVerificationMail(ctx, userM)
+	}
+
+	return &apiv1.ResendVerificationResponse{}, nil
+}
+
+// sendVerificationMail 异步向用户发送邮箱验证邮件，失败时只记录日志.
+func (b *userBiz) sendVerificationMail(ctx context.Context, userM *model.UserM) {
+	if userM.Email == "" || userM.EmailVerified {
+		return
+	}
+
+	tk, _, err := token.SignWithPurpose(known.PurposeEmailVerification, userM.UserID+":"+userM.Email, known.EmailVerificationExpiration)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign email verification token", "userID", userM.UserID, "err", err)
+		return
+	}
+
+	msg := &mail.Message{
+		To:      []string{userM.Email},
+		Subject: "Verify your miniblog email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please open the link below within %s to verify your email address:\n\n%s\n\n"+
+			"If you did not create a miniblog account, you can ignore this email.\n",
+			userM.Username, known.EmailVerificationExpiration, b.publicLink("/verify-email", tk)),
+	}
+
+	go func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, known.MailSendTimeout)
+		defer cancel()
+
+		if err := b.mailer.Send(ctx, msg); err != nil {
+			log.W(ctx).Errorw("Failed to send email verification mail", "userID", userM.UserID, "err", err)
+		}
+	}(context.WithoutCancel(ctx))
+}
+
+// checkEmailVerifiedForLogin 在不允许未验证邮箱的用户登录时，校验用户邮箱是否已验证.
+func (b *userBiz) checkEmailVerifiedForLogin(userM *model.UserM) error {
+	if !b.allowUnverifiedLogin && !userM.EmailVerified {
+		return errno.ErrEmailNotVerified
+	}
+	return nil
+}
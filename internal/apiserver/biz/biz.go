@@ -1,10 +1,22 @@
 package biz
 
 import (
+	apikeyv1 "miniblog/internal/apiserver/biz/V1/apikey"
+	auditv1 "miniblog/internal/apiserver/biz/V1/audit"
+	invitationv1 "miniblog/internal/apiserver/biz/V1/invitation"
+	organizationv1 "miniblog/internal/apiserver/biz/V1/organization"
+	policyv1 "miniblog/internal/apiserver/biz/V1/policy"
 	postv1 "miniblog/internal/apiserver/biz/V1/post"
+	quotav1 "miniblog/internal/apiserver/biz/V1/quota"
 	userv1 "miniblog/internal/apiserver/biz/V1/user"
 	"miniblog/internal/apiserver/store"
+	"miniblog/pkg/authenticator"
 	"miniblog/pkg/authz"
+	"miniblog/pkg/cipher"
+	"miniblog/pkg/lockout"
+	"miniblog/pkg/mail"
+	"miniblog/pkg/quota"
+	"miniblog/pkg/sms"
 )
 
 // IBiz 定义了业务层需要实现的方法.
@@ -13,33 +25,97 @@ type IBiz interface {
 	UserV1() userv1.UserBiz
 	// 获取帖子业务接口.
 	PostV1() postv1.PostBiz
+	// 获取 API Key 业务接口.
+	APIKeyV1() apikeyv1.APIKeyBiz
+	// 获取邀请码业务接口.
+	InvitationV1() invitationv1.InvitationBiz
+	// 获取授权策略业务接口.
+	PolicyV1() policyv1.PolicyBiz
+	// 获取组织业务接口.
+	OrganizationV1() organizationv1.OrganizationBiz
+	// 获取审计日志业务接口.
+	AuditV1() auditv1.AuditBiz
+	// 获取内容配额业务接口.
+	QuotaV1() quotav1.QuotaBiz
 	// 获取帖子业务接口（V2版本）. 未实现，仅展示用.
 	//PostV2()
 }
 
 // biz 是 IBiz 的一个具体实现.
 type biz struct {
-	store store.IStore
-	authz *authz.Authz
+	store          store.IStore
+	authz          *authz.Authz
+	cipher         *cipher.Cipher
+	loginGuard     *lockout.Guard
+	authenticators *authenticator.Registry
+	mailer         mail.Mailer
+	smsSender      sms.Sender
+	quotaPlans     quota.Plans
+	publicURL      string
+
+	allowUnverifiedLogin bool
+	allowUnverifiedPost  bool
+	registrationMode     string
 }
 
 // 确保 biz 实现了 IBiz 接口.
 var _ IBiz = (*biz)(nil)
 
 // NewBiz 创建一个 IBiz 类型的实例.
-func NewBiz(store store.IStore, authz *authz.Authz) *biz {
+func NewBiz(store store.IStore, authz *authz.Authz, cipher *cipher.Cipher, loginGuard *lockout.Guard, authenticators *authenticator.Registry, mailer mail.Mailer, smsSender sms.Sender, quotaPlans quota.Plans, publicURL string, allowUnverifiedLogin, allowUnverifiedPost bool, registrationMode string) *biz {
 	return &biz{
-		store: store,
-		authz: authz,
+		store:          store,
+		authz:          authz,
+		cipher:         cipher,
+		loginGuard:     loginGuard,
+		authenticators: authenticators,
+		mailer:         mailer,
+		smsSender:      smsSender,
+		quotaPlans:     quotaPlans,
+		publicURL:      publicURL,
+
+		allowUnverifiedLogin: allowUnverifiedLogin,
+		allowUnverifiedPost:  allowUnverifiedPost,
+		registrationMode:     registrationMode,
 	}
 }
 
 // UserV1 返回一个实现了 UserBiz 接口的实例.
 func (b *biz) UserV1() userv1.UserBiz {
-	return userv1.New(b.store,b.authz)
+	return userv1.New(b.store, b.authz, b.cipher, b.loginGuard, b.authenticators, b.mailer, b.smsSender, b.publicURL, b.allowUnverifiedLogin, b.registrationMode)
 }
 
 // PostV1 返回一个实现了 PostBiz 接口的实例.
 func (b *biz) PostV1() postv1.PostBiz {
-	return postv1.New(b.store)
+	return postv1.New(b.store, b.authz, b.QuotaV1(), b.allowUnverifiedPost)
+}
+
+// APIKeyV1 返回一个实现了 APIKeyBiz 接口的实例.
+func (b *biz) APIKeyV1() apikeyv1.APIKeyBiz {
+	return apikeyv1.New(b.store)
+}
+
+// InvitationV1 返回一个实现了 InvitationBiz 接口的实例.
+func (b *biz) InvitationV1() invitationv1.InvitationBiz {
+	return invitationv1.New(b.store, b.authz)
+}
+
+// PolicyV1 返回一个实现了 PolicyBiz 接口的实例.
+func (b *biz) PolicyV1() policyv1.PolicyBiz {
+	return policyv1.New(b.store, b.authz)
+}
+
+// OrganizationV1 返回一个实现了 OrganizationBiz 接口的实例.
+func (b *biz) OrganizationV1() organizationv1.OrganizationBiz {
+	return organizationv1.New(b.store, b.authz)
+}
+
+// AuditV1 返回一个实现了 AuditBiz 接口的实例.
+func (b *biz) AuditV1() auditv1.AuditBiz {
+	return auditv1.New(b.store, b.authz)
+}
+
+// QuotaV1 返回一个实现了 QuotaBiz 接口的实例.
+func (b *biz) QuotaV1() quotav1.QuotaBiz {
+	return quotav1.New(b.store, b.authz, b.quotaPlans)
 }
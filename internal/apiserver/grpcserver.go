@@ -2,12 +2,16 @@ package apiserver
 
 import (
 	"context"
+	"fmt"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/server"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	handler "miniblog/internal/apiserver/handler/grpc"
 	mw "miniblog/internal/pkg/middleware/grpc"
@@ -33,21 +37,43 @@ var _ server.Server = (*grpcServer)(nil)
 //  2. 处理默认值或回退逻辑
 //  3. 表达灵活选项
 func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
+	// 注意拦截器顺序
+	interceptors := []grpc.UnaryServerInterceptor{
+		// 请求 ID 拦截器
+		mw.RequestIDInterceptor(),
+		// 客户端 IP 拦截器
+		mw.ClientIPInterceptor(),
+	}
+	// 按客户端 IP 限流的拦截器，放在认证之前，使暴力尝试令牌或 API Key 的请求同样被限流
+	if c.limiter != nil {
+		interceptors = append(interceptors, mw.RateLimitInterceptor(c.limiter))
+	}
+	// 认证拦截器
+	interceptors = append(interceptors, selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.retriever), NewAuthnWhiteListMatcher(c.cfg.RegistrationMode)))
+	// 按用户限流的拦截器，放在认证之后以便获取用户 ID，放在审计之前避免被限流的请求刷屏审计日志
+	if c.limiter != nil {
+		interceptors = append(interceptors, mw.UserRateLimitInterceptor(c.limiter))
+	}
+	// 审计拦截器，放在认证之后以便记录操作者，放在校验和授权之前以便记录被拒绝的请求
+	if c.audit != nil {
+		interceptors = append(interceptors, selector.UnaryServerInterceptor(mw.AuditInterceptor(c.audit), NewAuditMatcher()))
+	}
+	interceptors = append(interceptors,
+		// 请求参数设置默认值
+		mw.DefaulterInterceptor(),
+		// 数据校验拦截器
+		mw.ValidatorInterceptor(genericvalidation.NewValidator(c.val)),
+		// 授权拦截器
+		selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz, NewGRPCPermissions()), NewAuthzWhiteListMatcher(c.cfg.RegistrationMode)),
+	)
+	// 幂等键拦截器，放在授权之后，使重试的请求同样经过认证和授权
+	if c.idempotency != nil {
+		interceptors = append(interceptors, selector.UnaryServerInterceptor(mw.IdempotencyInterceptor(c.idempotency), NewIdempotencyMatcher()))
+	}
+
 	// 配置 gRPC 服务器选项，包括拦截器链
 	serverOptions := []grpc.ServerOption{
-		// 注意拦截器顺序
-		grpc.ChainUnaryInterceptor(
-			// 请求 ID 拦截器
-			mw.RequestIDInterceptor(),
-			// 认证拦截器
-			selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.retriever), NewAuthnWhiteListMatcher()),
-			// 请求参数设置默认值
-			mw.DefaulterInterceptor(),
-			// 数据校验拦截器
-			mw.ValidatorInterceptor(genericvalidation.NewValidator(c.val)),
-			// 授权拦截器
-			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthzWhiteListMatcher()),
-		),
+		grpc.ChainUnaryInterceptor(interceptors...),
 	}
 
 	// 创建 gRPC 服务器
@@ -107,11 +133,26 @@ func (s *grpcServer) GracefulStop(ctx context.Context) {
 }
 
 // NewAuthnWhiteListMatcher 创建认证白名单匹配器.
-func NewAuthnWhiteListMatcher() selector.Matcher {
+// 关闭注册时只有管理员可以创建用户，因此 CreateUser 不在白名单中.
+func NewAuthnWhiteListMatcher(registrationMode string) selector.Matcher {
 	whitelist := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
+		apiv1.MiniBlog_Healthz_FullMethodName:              {},
+		apiv1.MiniBlog_CreateUser_FullMethodName:           {},
+		apiv1.MiniBlog_Login_FullMethodName:                {},
+		apiv1.MiniBlog_LoginVerify_FullMethodName:          {},
+		apiv1.MiniBlog_OIDCLogin_FullMethodName:            {},
+		apiv1.MiniBlog_OIDCCallback_FullMethodName:         {},
+		apiv1.MiniBlog_RequestMagicLink_FullMethodName:     {},
+		apiv1.MiniBlog_MagicLinkLogin_FullMethodName:       {},
+		apiv1.MiniBlog_RequestLoginCode_FullMethodName:     {},
+		apiv1.MiniBlog_LoginWithCode_FullMethodName:        {},
+		apiv1.MiniBlog_RequestPasswordReset_FullMethodName: {},
+		apiv1.MiniBlog_ResetPassword_FullMethodName:        {},
+		apiv1.MiniBlog_VerifyEmail_FullMethodName:          {},
+		apiv1.MiniBlog_ResendVerification_FullMethodName:   {},
+	}
+	if registrationMode == known.RegistrationModeClosed {
+		delete(whitelist, apiv1.MiniBlog_CreateUser_FullMethodName)
 	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
 		_, ok := whitelist[call.FullMethod()]
@@ -120,14 +161,62 @@ func NewAuthnWhiteListMatcher() selector.Matcher {
 }
 
 // NewAuthzWhiteListMatcher 创建授权白名单匹配器.
-func NewAuthzWhiteListMatcher() selector.Matcher {
+// 关闭注册时只有管理员可以创建用户，因此 CreateUser 不在白名单中.
+func NewAuthzWhiteListMatcher(registrationMode string) selector.Matcher {
 	whitelist := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
+		apiv1.MiniBlog_Healthz_FullMethodName:              {},
+		apiv1.MiniBlog_CreateUser_FullMethodName:           {},
+		apiv1.MiniBlog_Login_FullMethodName:                {},
+		apiv1.MiniBlog_LoginVerify_FullMethodName:          {},
+		apiv1.MiniBlog_OIDCLogin_FullMethodName:            {},
+		apiv1.MiniBlog_OIDCCallback_FullMethodName:         {},
+		apiv1.MiniBlog_RequestMagicLink_FullMethodName:     {},
+		apiv1.MiniBlog_MagicLinkLogin_FullMethodName:       {},
+		apiv1.MiniBlog_RequestLoginCode_FullMethodName:     {},
+		apiv1.MiniBlog_LoginWithCode_FullMethodName:        {},
+		apiv1.MiniBlog_RequestPasswordReset_FullMethodName: {},
+		apiv1.MiniBlog_ResetPassword_FullMethodName:        {},
+		apiv1.MiniBlog_VerifyEmail_FullMethodName:          {},
+		apiv1.MiniBlog_ResendVerification_FullMethodName:   {},
+	}
+	if registrationMode == known.RegistrationModeClosed {
+		delete(whitelist, apiv1.MiniBlog_CreateUser_FullMethodName)
 	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
 		_, ok := whitelist[call.FullMethod()]
 		return !ok
 	})
 }
+
+// NewIdempotencyMatcher 创建幂等键匹配器，只对显式列出的创建资源的方法支持幂等键.
+// 登录、签发 API Key 等方法的响应中包含令牌或密钥，保存响应会将其明文写入幂等键存储，因此不能支持幂等键.
+// PUT 和 DELETE 本身是幂等的，GET 不修改数据，都不需要幂等键. 与 NewGinIdempotentRoutes 保持一致.
+func NewIdempotencyMatcher() selector.Matcher {
+	idempotent := map[string]struct{}{
+		apiv1.MiniBlog_CreateUser_FullMethodName:         {},
+		apiv1.MiniBlog_CreatePost_FullMethodName:         {},
+		apiv1.MiniBlog_CreateOrganization_FullMethodName: {},
+	}
+	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
+		_, ok := idempotent[call.FullMethod()]
+		return ok
+	})
+}
+
+// NewAuditMatcher 创建审计匹配器，只审计修改类操作，即 HTTP 映射不是 GET 的方法.
+// 方法的 HTTP 映射从 apiserver.proto 的 google.api.http 注解中读取，新增接口时无需修改.
+func NewAuditMatcher() selector.Matcher {
+	mutating := make(map[string]struct{})
+	sd := apiv1.File_apiserver_v1_apiserver_proto.Services().ByName("MiniBlog")
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		rule, _ := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if rule.GetGet() == "" {
+			mutating[fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())] = struct{}{}
+		}
+	}
+	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
+		_, ok := mutating[call.FullMethod()]
+		return ok
+	})
+}
@@ -0,0 +1,68 @@
+package apiserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func TestAuditMatcher(t *testing.T) {
+	matcher := NewAuditMatcher()
+	match := func(fullMethod string) bool {
+		return matcher.Match(context.Background(), interceptors.NewServerCallMeta(fullMethod, nil, nil))
+	}
+
+	// 修改类操作需要审计
+	for _, method := range []string{
+		apiv1.MiniBlog_Login_FullMethodName,
+		apiv1.MiniBlog_CreatePost_FullMethodName,
+		apiv1.MiniBlog_UpdatePost_FullMethodName,
+		apiv1.MiniBlog_DeletePost_FullMethodName,
+	} {
+		assert.True(t, match(method), "method %s should be audited", method)
+	}
+
+	// 查询类操作不需要审计
+	for _, method := range []string{
+		apiv1.MiniBlog_Healthz_FullMethodName,
+		apiv1.MiniBlog_GetPost_FullMethodName,
+		apiv1.MiniBlog_ListPost_FullMethodName,
+		apiv1.MiniBlog_ListAuditEvents_FullMethodName,
+	} {
+		assert.False(t, match(method), "method %s should not be audited", method)
+	}
+}
+
+func TestIdempotencyMatcher(t *testing.T) {
+	matcher := NewIdempotencyMatcher()
+	match := func(fullMethod string) bool {
+		return matcher.Match(context.Background(), interceptors.NewServerCallMeta(fullMethod, nil, nil))
+	}
+
+	// 非幂等的创建类操作支持幂等键
+	for _, method := range []string{
+		apiv1.MiniBlog_CreateUser_FullMethodName,
+		apiv1.MiniBlog_CreatePost_FullMethodName,
+	} {
+		assert.True(t, match(method), "method %s should support idempotency keys", method)
+	}
+
+	// 幂等的操作不需要幂等键，响应中包含令牌或密钥的操作不能保存响应
+	for _, method := range []string{
+		apiv1.MiniBlog_GetPost_FullMethodName,
+		apiv1.MiniBlog_UpdatePost_FullMethodName,
+		apiv1.MiniBlog_DeletePost_FullMethodName,
+		apiv1.MiniBlog_Login_FullMethodName,
+		apiv1.MiniBlog_LoginVerify_FullMethodName,
+		apiv1.MiniBlog_ResetPassword_FullMethodName,
+		apiv1.MiniBlog_Impersonate_FullMethodName,
+		apiv1.MiniBlog_CreateAPIKey_FullMethodName,
+		apiv1.MiniBlog_EnrollTOTP_FullMethodName,
+	} {
+		assert.False(t, match(method), "method %s should not support idempotency keys", method)
+	}
+}
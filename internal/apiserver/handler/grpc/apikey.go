@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// CreateAPIKey 创建 API Key.
+func (h *Handler) CreateAPIKey(ctx context.Context, rq *apiv1.CreateAPIKeyRequest) (*apiv1.CreateAPIKeyResponse, error) {
+	return h.biz.APIKeyV1().Create(ctx, rq)
+}
+
+// DeleteAPIKey 删除 API Key.
+func (h *Handler) DeleteAPIKey(ctx context.Context, rq *apiv1.DeleteAPIKeyRequest) (*apiv1.DeleteAPIKeyResponse, error) {
+	return h.biz.APIKeyV1().Delete(ctx, rq)
+}
+
+// ListAPIKey 列出当前用户的 API Key.
+func (h *Handler) ListAPIKey(ctx context.Context, rq *apiv1.ListAPIKeyRequest) (*apiv1.ListAPIKeyResponse, error) {
+	return h.biz.APIKeyV1().List(ctx, rq)
+}
@@ -0,0 +1,11 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// ListAuditEvents 查询审计事件列表.
+func (h *Handler) ListAuditEvents(ctx context.Context, rq *apiv1.ListAuditEventsRequest) (*apiv1.ListAuditEventsResponse, error) {
+	return h.biz.AuditV1().List(ctx, rq)
+}
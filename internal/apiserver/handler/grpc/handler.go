@@ -5,8 +5,15 @@
 package grpc
 
 import (
+	"context"
 	"miniblog/internal/apiserver/biz"
+	"miniblog/internal/pkg/known"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Handler 负责处理模块的请求.
@@ -21,3 +28,22 @@ func NewHandler(biz biz.IBiz) *Handler {
 		biz: biz,
 	}
 }
+
+// incomingHeader 返回请求元数据中 key 对应的值，网关会将 If-Match 等条件请求头转发到同名的元数据中.
+func incomingHeader(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// setCacheHeader 在响应元数据中返回资源的 etag 和最后修改时间，网关会将其作为 ETag 和 Last-Modified 响应头返回.
+func setCacheHeader(ctx context.Context, etag string, lastModified *timestamppb.Timestamp) {
+	md := metadata.Pairs(known.ETag, `"`+etag+`"`)
+	if lastModified != nil {
+		md.Set(known.LastModified, lastModified.AsTime().UTC().Format(http.TimeFormat))
+	}
+	_ = grpc.SetHeader(ctx, md)
+}
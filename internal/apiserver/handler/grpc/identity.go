@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// OIDCLogin 发起 OIDC 登录.
+func (h *Handler) OIDCLogin(ctx context.Context, rq *apiv1.OIDCLoginRequest) (*apiv1.OIDCLoginResponse, error) {
+	return h.biz.UserV1().OIDCLogin(ctx, rq)
+}
+
+// OIDCCallback 处理 OIDC 授权回调.
+func (h *Handler) OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.LoginResponse, error) {
+	return h.biz.UserV1().OIDCCallback(ctx, rq)
+}
+
+// LinkIdentity 关联外部身份.
+func (h *Handler) LinkIdentity(ctx context.Context, rq *apiv1.LinkIdentityRequest) (*apiv1.LinkIdentityResponse, error) {
+	return h.biz.UserV1().LinkIdentity(ctx, rq)
+}
+
+// UnlinkIdentity 解除外部身份关联.
+func (h *Handler) UnlinkIdentity(ctx context.Context, rq *apiv1.UnlinkIdentityRequest) (*apiv1.UnlinkIdentityResponse, error) {
+	return h.biz.UserV1().UnlinkIdentity(ctx, rq)
+}
@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// CreateInvitation 创建邀请码.
+func (h *Handler) CreateInvitation(ctx context.Context, rq *apiv1.CreateInvitationRequest) (*apiv1.CreateInvitationResponse, error) {
+	return h.biz.InvitationV1().Create(ctx, rq)
+}
+
+// DeleteInvitation 删除邀请码.
+func (h *Handler) DeleteInvitation(ctx context.Context, rq *apiv1.DeleteInvitationRequest) (*apiv1.DeleteInvitationResponse, error) {
+	return h.biz.InvitationV1().Delete(ctx, rq)
+}
+
+// ListInvitation 列出邀请码.
+func (h *Handler) ListInvitation(ctx context.Context, rq *apiv1.ListInvitationRequest) (*apiv1.ListInvitationResponse, error) {
+	return h.biz.InvitationV1().List(ctx, rq)
+}
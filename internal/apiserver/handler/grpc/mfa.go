@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// LoginVerify 使用两步验证码完成登录.
+func (h *Handler) LoginVerify(ctx context.Context, rq *apiv1.LoginVerifyRequest) (*apiv1.LoginVerifyResponse, error) {
+	return h.biz.UserV1().LoginVerify(ctx, rq)
+}
+
+// EnrollTOTP 绑定 TOTP 身份验证器.
+func (h *Handler) EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error) {
+	return h.biz.UserV1().EnrollTOTP(ctx, rq)
+}
+
+// VerifyTOTP 确认绑定并开启两步验证.
+func (h *Handler) VerifyTOTP(ctx context.Context, rq *apiv1.VerifyTOTPRequest) (*apiv1.VerifyTOTPResponse, error) {
+	return h.biz.UserV1().VerifyTOTP(ctx, rq)
+}
+
+// DisableTOTP 关闭两步验证.
+func (h *Handler) DisableTOTP(ctx context.Context, rq *apiv1.DisableTOTPRequest) (*apiv1.DisableTOTPResponse, error) {
+	return h.biz.UserV1().DisableTOTP(ctx, rq)
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// CreateOrganization 创建组织.
+func (h *Handler) CreateOrganization(ctx context.Context, rq *apiv1.CreateOrganizationRequest) (*apiv1.CreateOrganizationResponse, error) {
+	return h.biz.OrganizationV1().Create(ctx, rq)
+}
+
+// DeleteOrganization 删除组织.
+func (h *Handler) DeleteOrganization(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) (*apiv1.DeleteOrganizationResponse, error) {
+	return h.biz.OrganizationV1().Delete(ctx, rq)
+}
+
+// GetOrganization 获取组织详情.
+func (h *Handler) GetOrganization(ctx context.Context, rq *apiv1.GetOrganizationRequest) (*apiv1.GetOrganizationResponse, error) {
+	return h.biz.OrganizationV1().Get(ctx, rq)
+}
+
+// ListOrganization 列出当前用户所属的组织.
+func (h *Handler) ListOrganization(ctx context.Context, rq *apiv1.ListOrganizationRequest) (*apiv1.ListOrganizationResponse, error) {
+	return h.biz.OrganizationV1().List(ctx, rq)
+}
+
+// AddOrganizationMember 添加组织成员.
+func (h *Handler) AddOrganizationMember(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) (*apiv1.AddOrganizationMemberResponse, error) {
+	return h.biz.OrganizationV1().AddMember(ctx, rq)
+}
+
+// RemoveOrganizationMember 移除组织成员.
+func (h *Handler) RemoveOrganizationMember(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) (*apiv1.RemoveOrganizationMemberResponse, error) {
+	return h.biz.OrganizationV1().RemoveMember(ctx, rq)
+}
+
+// ListOrganizationMember 列出组织成员.
+func (h *Handler) ListOrganizationMember(ctx context.Context, rq *apiv1.ListOrganizationMemberRequest) (*apiv1.ListOrganizationMemberResponse, error) {
+	return h.biz.OrganizationV1().ListMember(ctx, rq)
+}
@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// RequestPasswordReset 申请重置密码.
+func (h *Handler) RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error) {
+	return h.biz.UserV1().RequestPasswordReset(ctx, rq)
+}
+
+// ResetPassword 使用一次性令牌重置密码.
+func (h *Handler) ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error) {
+	return h.biz.UserV1().ResetPassword(ctx, rq)
+}
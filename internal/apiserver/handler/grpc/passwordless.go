@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// RequestMagicLink 发送邮件登录链接.
+func (h *Handler) RequestMagicLink(ctx context.Context, rq *apiv1.RequestMagicLinkRequest) (*apiv1.RequestMagicLinkResponse, error) {
+	return h.biz.UserV1().RequestMagicLink(ctx, rq)
+}
+
+// MagicLinkLogin 使用邮件登录链接登录.
+func (h *Handler) MagicLinkLogin(ctx context.Context, rq *apiv1.MagicLinkLoginRequest) (*apiv1.LoginResponse, error) {
+	return h.biz.UserV1().MagicLinkLogin(ctx, rq)
+}
+
+// RequestLoginCode 发送短信登录验证码.
+func (h *Handler) RequestLoginCode(ctx context.Context, rq *apiv1.RequestLoginCodeRequest) (*apiv1.RequestLoginCodeResponse, error) {
+	return h.biz.UserV1().RequestLoginCode(ctx, rq)
+}
+
+// LoginWithCode 使用短信验证码登录.
+func (h *Handler) LoginWithCode(ctx context.Context, rq *apiv1.LoginWithCodeRequest) (*apiv1.LoginResponse, error) {
+	return h.biz.UserV1().LoginWithCode(ctx, rq)
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// ListPolicy 查询授权策略列表.
+func (h *Handler) ListPolicy(ctx context.Context, rq *apiv1.ListPolicyRequest) (*apiv1.ListPolicyResponse, error) {
+	return h.biz.PolicyV1().List(ctx, rq)
+}
+
+// AddPolicy 添加授权策略.
+func (h *Handler) AddPolicy(ctx context.Context, rq *apiv1.AddPolicyRequest) (*apiv1.AddPolicyResponse, error) {
+	return h.biz.PolicyV1().Add(ctx, rq)
+}
+
+// RemovePolicy 删除授权策略.
+func (h *Handler) RemovePolicy(ctx context.Context, rq *apiv1.RemovePolicyRequest) (*apiv1.RemovePolicyResponse, error) {
+	return h.biz.PolicyV1().Remove(ctx, rq)
+}
+
+// ExplainAuthorization 解释授权判断的过程.
+func (h *Handler) ExplainAuthorization(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) (*apiv1.ExplainAuthorizationResponse, error) {
+	return h.biz.PolicyV1().Explain(ctx, rq)
+}
+
+// ListRoleAssignment 查询角色分配列表.
+func (h *Handler) ListRoleAssignment(ctx context.Context, rq *apiv1.ListRoleAssignmentRequest) (*apiv1.ListRoleAssignmentResponse, error) {
+	return h.biz.PolicyV1().ListRoleAssignment(ctx, rq)
+}
+
+// AddRoleAssignment 分配角色.
+func (h *Handler) AddRoleAssignment(ctx context.Context, rq *apiv1.AddRoleAssignmentRequest) (*apiv1.AddRoleAssignmentResponse, error) {
+	return h.biz.PolicyV1().AddRoleAssignment(ctx, rq)
+}
+
+// RemoveRoleAssignment 取消角色分配.
+func (h *Handler) RemoveRoleAssignment(ctx context.Context, rq *apiv1.RemoveRoleAssignmentRequest) (*apiv1.RemoveRoleAssignmentResponse, error) {
+	return h.biz.PolicyV1().RemoveRoleAssignment(ctx, rq)
+}
+
+// GetUserRoles 查询用户角色.
+func (h *Handler) GetUserRoles(ctx context.Context, rq *apiv1.GetUserRolesRequest) (*apiv1.GetUserRolesResponse, error) {
+	return h.biz.PolicyV1().GetUserRoles(ctx, rq)
+}
@@ -2,7 +2,11 @@ package grpc
 
 import (
 	"context"
+	"miniblog/internal/pkg/known"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"net/http"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // CreatePost 创建博客帖子.
@@ -12,6 +16,10 @@ func (h *Handler) CreatePost(ctx context.Context, rq *apiv1.CreatePostRequest) (
 
 // UpdatePost 更新博客帖子.
 func (h *Handler) UpdatePost(ctx context.Context, rq *apiv1.UpdatePostRequest) (*apiv1.UpdatePostResponse, error) {
+	// 请求中没有 etag 时，使用 If-Match 元数据
+	if rq.GetEtag() == "" {
+		rq.Etag = incomingHeader(ctx, known.IfMatch)
+	}
 	return h.biz.PostV1().Update(ctx, rq)
 }
 
@@ -21,11 +29,36 @@ func (h *Handler) DeletePost(ctx context.Context, rq *apiv1.DeletePostRequest) (
 }
 
 // GetPost 获取博客帖子.
+// 请求中没有传入条件时，使用 If-None-Match 和 If-Modified-Since 元数据，博客没有修改时返回的 notModified 为 true.
 func (h *Handler) GetPost(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.GetPostResponse, error) {
-	return h.biz.PostV1().Get(ctx, rq)
+	if rq.GetIfNoneMatch() == "" {
+		rq.IfNoneMatch = incomingHeader(ctx, known.IfNoneMatch)
+	}
+	if rq.GetIfModifiedSince() == nil {
+		if t, err := http.ParseTime(incomingHeader(ctx, known.IfModifiedSince)); err == nil {
+			rq.IfModifiedSince = timestamppb.New(t)
+		}
+	}
+
+	resp, err := h.biz.PostV1().Get(ctx, rq)
+	if err != nil {
+		return nil, err
+	}
+	setCacheHeader(ctx, resp.GetEtag(), resp.GetLastModified())
+	return resp, nil
 }
 
 // ListPost 列出所有博客帖子.
+// 请求中没有传入条件时，使用 If-None-Match 元数据，文章列表没有变化时返回的 notModified 为 true.
 func (h *Handler) ListPost(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.ListPostResponse, error) {
-	return h.biz.PostV1().List(ctx, rq)
+	if rq.GetIfNoneMatch() == "" {
+		rq.IfNoneMatch = incomingHeader(ctx, known.IfNoneMatch)
+	}
+
+	resp, err := h.biz.PostV1().List(ctx, rq)
+	if err != nil {
+		return nil, err
+	}
+	setCacheHeader(ctx, resp.GetEtag(), nil)
+	return resp, nil
 }
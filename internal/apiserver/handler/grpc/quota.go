@@ -0,0 +1,11 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// GetMyQuota 查询当前用户的内容配额及其用量.
+func (h *Handler) GetMyQuota(ctx context.Context, rq *apiv1.GetMyQuotaRequest) (*apiv1.GetMyQuotaResponse, error) {
+	return h.biz.QuotaV1().GetMy(ctx, rq)
+}
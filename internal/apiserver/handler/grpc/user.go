@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"miniblog/internal/pkg/known"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
 )
 
@@ -12,6 +13,10 @@ func (h *Handler) CreateUser(ctx context.Context, rq *apiv1.CreateUserRequest) (
 
 // UpdateUser 更新用户信息.
 func (h *Handler) UpdateUser(ctx context.Context, rq *apiv1.UpdateUserRequest) (*apiv1.UpdateUserResponse, error) {
+	// 请求中没有 etag 时，使用 If-Match 元数据
+	if rq.GetEtag() == "" {
+		rq.Etag = incomingHeader(ctx, known.IfMatch)
+	}
 	return h.biz.UserV1().Update(ctx, rq)
 }
 
@@ -43,3 +48,13 @@ func (h *Handler) RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenReques
 func (h *Handler) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error) {
 	return h.biz.UserV1().ChangePassword(ctx, rq)
 }
+
+// Impersonate 以指定用户的身份签发短期令牌.
+func (h *Handler) Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error) {
+	return h.biz.UserV1().Impersonate(ctx, rq)
+}
+
+// UnlockUser 解除用户的登录锁定.
+func (h *Handler) UnlockUser(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error) {
+	return h.biz.UserV1().Unlock(ctx, rq)
+}
@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// VerifyEmail 验证用户邮箱.
+func (h *Handler) VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error) {
+	return h.biz.UserV1().VerifyEmail(ctx, rq)
+}
+
+// ResendVerification 重新发送邮箱验证邮件.
+func (h *Handler) ResendVerification(ctx context.Context, rq *apiv1.ResendVerificationRequest) (*apiv1.ResendVerificationResponse, error) {
+	return h.biz.UserV1().ResendVerification(ctx, rq)
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.APIKeyV1().Create, h.val.ValidateCreateAPIKeyRequest)
+}
+
+func (h *Handler) DeleteAPIKey(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.APIKeyV1().Delete, h.val.ValidateDeleteAPIKeyRequest)
+}
+
+func (h *Handler) ListAPIKey(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.APIKeyV1().List, h.val.ValidateListAPIKeyRequest)
+}
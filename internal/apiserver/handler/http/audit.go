@@ -0,0 +1,11 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListAuditEvents(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AuditV1().List, h.val.ValidateListAuditEventsRequest)
+}
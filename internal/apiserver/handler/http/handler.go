@@ -5,8 +5,17 @@
 package http
 
 import (
+	"encoding/json"
 	"miniblog/internal/apiserver/biz"
+	"miniblog/internal/pkg/conversion"
 	"miniblog/internal/pkg/validation"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/core"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Handler 处理博客模块的请求.
@@ -22,3 +31,114 @@ func NewHandler(biz biz.IBiz, val *validation.Validator) *Handler {
 		val: val,
 	}
 }
+
+// updateBinder 返回更新请求的绑定函数，它在绑定 JSON 请求体的基础上：
+//   - 请求体中没有 etag 时，使用 If-Match 请求头的值；
+//   - 按照 FieldMask 的 JSON 格式（逗号分隔的字段路径）解析 updateMask，
+//     PATCH 请求没有传入 updateMask 时，使用请求体中出现的字段作为 updateMask.
+func updateBinder(c *gin.Context) core.Binder {
+	return func(obj any) error {
+		body, err := c.GetRawData()
+		if err != nil {
+			return err
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return err
+		}
+
+		mask, err := conversion.ParseUpdateMask(fields)
+		if err != nil {
+			return err
+		}
+		if mask == nil && c.Request.Method == http.MethodPatch {
+			pathParams := make([]string, 0, len(c.Params))
+			for _, param := range c.Params {
+				pathParams = append(pathParams, param.Key)
+			}
+			mask = conversion.PatchUpdateMask(fields, pathParams...)
+		}
+
+		if body, err = json.Marshal(fields); err != nil {
+			return err
+		}
+		if err := binding.JSON.BindBody(body, obj); err != nil {
+			return err
+		}
+
+		switch rq := obj.(type) {
+		case *apiv1.UpdatePostRequest:
+			rq.UpdateMask = mask
+			if rq.Etag == "" {
+				rq.Etag = c.GetHeader("If-Match")
+			}
+		case *apiv1.UpdateUserRequest:
+			rq.UpdateMask = mask
+			if rq.Etag == "" {
+				rq.Etag = c.GetHeader("If-Match")
+			}
+		}
+		return nil
+	}
+}
+
+// conditionalResponse 定义了支持条件查询的响应需要实现的方法.
+type conditionalResponse interface {
+	GetNotModified() bool
+	GetEtag() string
+}
+
+// handleConditionalRequest 处理条件查询请求：
+//   - 请求中没有传入条件时，使用 If-None-Match 和 If-Modified-Since 请求头的值；
+//   - 通过 ETag 和 Last-Modified 响应头返回资源当前的 etag 和最后修改时间；
+//   - 资源没有修改时返回 304 且不返回响应体.
+func handleConditionalRequest[T any, R conditionalResponse](c *gin.Context, binder core.Binder, handler core.Handler[T, R], validators ...core.Validator[T]) {
+	var rq T
+	if err := core.ReadRequest(c, &rq, conditionalBinder(c, binder), validators...); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	resp, err := handler(c.Request.Context(), &rq)
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	c.Header("ETag", `"`+resp.GetEtag()+`"`)
+	if r, ok := any(resp).(interface{ GetLastModified() *timestamppb.Timestamp }); ok && r.GetLastModified() != nil {
+		c.Header("Last-Modified", r.GetLastModified().AsTime().UTC().Format(http.TimeFormat))
+	}
+	if resp.GetNotModified() {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	core.WriteResponse(c, resp, nil)
+}
+
+// conditionalBinder 在 binder 绑定请求之后，如果请求中没有传入条件，则使用 If-None-Match 和 If-Modified-Since 请求头的值.
+func conditionalBinder(c *gin.Context, binder core.Binder) core.Binder {
+	return func(obj any) error {
+		if err := binder(obj); err != nil {
+			return err
+		}
+
+		switch rq := obj.(type) {
+		case *apiv1.GetPostRequest:
+			if rq.IfNoneMatch == "" {
+				rq.IfNoneMatch = c.GetHeader("If-None-Match")
+			}
+			if rq.IfModifiedSince == nil {
+				if t, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil {
+					rq.IfModifiedSince = timestamppb.New(t)
+				}
+			}
+		case *apiv1.ListPostRequest:
+			if rq.IfNoneMatch == "" {
+				rq.IfNoneMatch = c.GetHeader("If-None-Match")
+			}
+		}
+		return nil
+	}
+}
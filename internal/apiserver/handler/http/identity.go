@@ -0,0 +1,23 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) OIDCLogin(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.UserV1().OIDCLogin, h.val.ValidateOIDCLoginRequest)
+}
+
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.UserV1().OIDCCallback, h.val.ValidateOIDCCallbackRequest)
+}
+
+func (h *Handler) LinkIdentity(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().LinkIdentity, h.val.ValidateLinkIdentityRequest)
+}
+
+func (h *Handler) UnlinkIdentity(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().UnlinkIdentity, h.val.ValidateUnlinkIdentityRequest)
+}
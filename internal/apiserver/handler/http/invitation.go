@@ -0,0 +1,19 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateInvitation(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.InvitationV1().Create, h.val.ValidateCreateInvitationRequest)
+}
+
+func (h *Handler) DeleteInvitation(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.InvitationV1().Delete, h.val.ValidateDeleteInvitationRequest)
+}
+
+func (h *Handler) ListInvitation(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.InvitationV1().List, h.val.ValidateListInvitationRequest)
+}
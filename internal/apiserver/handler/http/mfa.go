@@ -0,0 +1,23 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) LoginVerify(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().LoginVerify, h.val.ValidateLoginVerifyRequest)
+}
+
+func (h *Handler) EnrollTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().EnrollTOTP, h.val.ValidateEnrollTOTPRequest)
+}
+
+func (h *Handler) VerifyTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().VerifyTOTP, h.val.ValidateVerifyTOTPRequest)
+}
+
+func (h *Handler) DisableTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().DisableTOTP, h.val.ValidateDisableTOTPRequest)
+}
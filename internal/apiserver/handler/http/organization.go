@@ -0,0 +1,35 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateOrganization(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.OrganizationV1().Create, h.val.ValidateCreateOrganizationRequest)
+}
+
+func (h *Handler) DeleteOrganization(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.OrganizationV1().Delete, h.val.ValidateDeleteOrganizationRequest)
+}
+
+func (h *Handler) GetOrganization(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.OrganizationV1().Get, h.val.ValidateGetOrganizationRequest)
+}
+
+func (h *Handler) ListOrganization(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.OrganizationV1().List, h.val.ValidateListOrganizationRequest)
+}
+
+func (h *Handler) AddOrganizationMember(c *gin.Context) {
+	core.HandleAllRequest(c, h.biz.OrganizationV1().AddMember, h.val.ValidateAddOrganizationMemberRequest)
+}
+
+func (h *Handler) RemoveOrganizationMember(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.OrganizationV1().RemoveMember, h.val.ValidateRemoveOrganizationMemberRequest)
+}
+
+func (h *Handler) ListOrganizationMember(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.OrganizationV1().ListMember, h.val.ValidateListOrganizationMemberRequest)
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) RequestPasswordReset(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().RequestPasswordReset, h.val.ValidateRequestPasswordResetRequest)
+}
+
+func (h *Handler) ResetPassword(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ResetPassword, h.val.ValidateResetPasswordRequest)
+}
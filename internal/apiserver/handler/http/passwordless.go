@@ -0,0 +1,23 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) RequestMagicLink(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().RequestMagicLink, h.val.ValidateRequestMagicLinkRequest)
+}
+
+func (h *Handler) MagicLinkLogin(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().MagicLinkLogin, h.val.ValidateMagicLinkLoginRequest)
+}
+
+func (h *Handler) RequestLoginCode(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().RequestLoginCode, h.val.ValidateRequestLoginCodeRequest)
+}
+
+func (h *Handler) LoginWithCode(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().LoginWithCode, h.val.ValidateLoginWithCodeRequest)
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListPolicy(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().List, h.val.ValidateListPolicyRequest)
+}
+
+func (h *Handler) AddPolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().Add, h.val.ValidateAddPolicyRequest)
+}
+
+func (h *Handler) RemovePolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().Remove, h.val.ValidateRemovePolicyRequest)
+}
+
+func (h *Handler) ExplainAuthorization(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().Explain, h.val.ValidateExplainAuthorizationRequest)
+}
+
+func (h *Handler) ListRoleAssignment(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().ListRoleAssignment, h.val.ValidateListRoleAssignmentRequest)
+}
+
+func (h *Handler) AddRoleAssignment(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().AddRoleAssignment, h.val.ValidateAddRoleAssignmentRequest)
+}
+
+func (h *Handler) RemoveRoleAssignment(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().RemoveRoleAssignment, h.val.ValidateRemoveRoleAssignmentRequest)
+}
+
+func (h *Handler) GetUserRoles(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PolicyV1().GetUserRoles, h.val.ValidateGetUserRolesRequest)
+}
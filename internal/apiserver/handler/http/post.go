@@ -11,7 +11,7 @@ func (h *Handler) CreatePost(c *gin.Context) {
 }
 
 func (h *Handler) UpdatePost(c *gin.Context) {
-	core.HandleJSONRequest(c, h.biz.PostV1().Update, h.val.ValidateUpdatePostRequest)
+	core.HandleRequest(c, updateBinder(c), h.biz.PostV1().Update, h.val.ValidateUpdatePostRequest)
 }
 
 func (h *Handler) DeletePost(c *gin.Context) {
@@ -19,9 +19,9 @@ func (h *Handler) DeletePost(c *gin.Context) {
 }
 
 func (h *Handler) GetPost(c *gin.Context) {
-	core.HandleUriRequest(c, h.biz.PostV1().Get, h.val.ValidateGetPostRequest)
+	handleConditionalRequest(c, c.ShouldBindUri, h.biz.PostV1().Get, h.val.ValidateGetPostRequest)
 }
 
 func (h *Handler) ListPost(c *gin.Context) {
-	core.HandleQueryRequest(c, h.biz.PostV1().List, h.val.ValidateListPostRequest)
+	handleConditionalRequest(c, c.ShouldBindQuery, h.biz.PostV1().List, h.val.ValidateListPostRequest)
 }
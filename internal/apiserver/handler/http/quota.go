@@ -0,0 +1,11 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetMyQuota(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.QuotaV1().GetMy, h.val.ValidateGetMyQuotaRequest)
+}
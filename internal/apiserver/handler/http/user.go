@@ -11,7 +11,7 @@ func (h *Handler) CreateUser(c *gin.Context) {
 }
 
 func (h *Handler) UpdateUser(c *gin.Context) {
-	core.HandleJSONRequest(c, h.biz.UserV1().Update, h.val.ValidateUpdateUserRequest)
+	core.HandleRequest(c, updateBinder(c), h.biz.UserV1().Update, h.val.ValidateUpdateUserRequest)
 }
 
 func (h *Handler) DeleteUser(c *gin.Context) {
@@ -37,3 +37,11 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 func (h *Handler) ChangePassword(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().ChangePassword, h.val.ValidateChangePasswordRequest)
 }
+
+func (h *Handler) Impersonate(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().Impersonate, h.val.ValidateImpersonateRequest)
+}
+
+func (h *Handler) UnlockUser(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().Unlock, h.val.ValidateUnlockUserRequest)
+}
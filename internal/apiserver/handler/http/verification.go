@@ -0,0 +1,15 @@
+package http
+
+import (
+	"miniblog/pkg/core"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().VerifyEmail, h.val.ValidateVerifyEmailRequest)
+}
+
+func (h *Handler) ResendVerification(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ResendVerification, h.val.ValidateResendVerificationRequest)
+}
@@ -6,8 +6,11 @@ package apiserver
 
 import (
 	"context"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
 	"miniblog/internal/pkg/server"
 	"net/http"
+	"slices"
 
 	"github.com/gin-contrib/pprof"
 
@@ -29,6 +32,10 @@ var _ server.Server = (*ginServer)(nil)
 func (c *ServerConfig) NewGinServer() server.Server {
 	// 创建 Gin 引擎
 	engine := gin.New()
+	// 只信任配置的反向代理设置的 X-Forwarded-For，否则客户端可以伪造 IP 绕过按 IP 的登录锁定和限流
+	if err := engine.SetTrustedProxies(c.cfg.TrustedProxies); err != nil {
+		log.Errorw("Failed to set trusted proxies", "err", err)
+	}
 
 	// 注册全局中间件，用于恢复 panic、设置 HTTP 头、添加请求 ID 等
 	// 注意：中间件需要在注册路由之前调用，否则对已注册路由不生效。
@@ -38,7 +45,16 @@ func (c *ServerConfig) NewGinServer() server.Server {
 		mw.Cors,
 		mw.Secure,
 		mw.RequestIDMiddleware(),
+		mw.ClientIPMiddleware(),
 	)
+	// 按客户端 IP 限流，已认证的请求在认证之后再按用户限流
+	if c.limiter != nil {
+		engine.Use(mw.RateLimitMiddleware(c.limiter))
+	}
+	// 记录修改类请求的审计事件
+	if c.audit != nil {
+		engine.Use(mw.AuditMiddleware(c.audit))
+	}
 
 	// 注册 REST API 路由
 	c.InstallRESTAPI(engine)
@@ -59,40 +75,151 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 
 	// 注册健康检查接口
 	engine.GET("/healthz", handler.Healthz)
-	// 注册用户登录和令牌刷新接口。这2个接口比较简单，所以没有 API 版本
+	// 注册用户登录和令牌刷新接口。这几个接口比较简单，所以没有 API 版本
 	engine.POST("/login", handler.Login)
-	engine.PUT("/refresh-token", handler.RefreshToken)
+	engine.POST("/login/verify", handler.LoginVerify)                // 两步验证登录
+	engine.GET("/login/oidc", handler.OIDCLogin)                     // 发起 OIDC 登录
+	engine.GET("/login/oidc/callback", handler.OIDCCallback)         // OIDC 授权回调
+	engine.POST("/login/magic-link", handler.RequestMagicLink)       // 申请邮件登录链接
+	engine.POST("/login/magic-link/confirm", handler.MagicLinkLogin) // 使用邮件登录链接登录
+	engine.POST("/login/sms", handler.RequestLoginCode)              // 申请短信登录验证码
+	engine.POST("/login/sms/verify", handler.LoginWithCode)          // 使用短信验证码登录
+	engine.POST("/password-reset", handler.RequestPasswordReset)     // 申请重置密码
+	engine.POST("/password-reset/confirm", handler.ResetPassword)    // 使用一次性令牌重置密码
+	engine.POST("/verify-email", handler.VerifyEmail)                // 验证邮箱
+	engine.POST("/verify-email/resend", handler.ResendVerification)  // 重新发送邮箱验证邮件
 
 	authMiddlewares := []gin.HandlerFunc{
 		mw.AuthnMiddleware(c.retriever),
-		mw.AuthzMiddleware(c.authz),
+		mw.AuthzMiddleware(c.authz, NewGinPermissions()),
+	}
+	if c.limiter != nil {
+		authMiddlewares = slices.Insert(authMiddlewares, 1, mw.UserRateLimitMiddleware(c.limiter))
+	}
+	// 幂等键中间件放在授权之后，使重试的请求同样经过认证和授权
+	var idempotencyMiddlewares []gin.HandlerFunc
+	if c.idempotency != nil {
+		idempotencyMiddlewares = append(idempotencyMiddlewares, mw.IdempotencyMiddleware(c.idempotency, NewGinIdempotentRoutes()))
+		authMiddlewares = append(authMiddlewares, idempotencyMiddlewares...)
 	}
 
+	// 刷新令牌需要先认证，否则无法确定为哪个用户签发新的令牌
+	engine.PUT("/refresh-token", slices.Concat(authMiddlewares, []gin.HandlerFunc{handler.RefreshToken})...)
+
 	// 注册 v1 版本 API 路由分组
 	v1 := engine.Group("/v1")
 	{
 		// 用户相关路由
 		userv1 := v1.Group("/users")
 		{
-			// 创建用户。这里要注意：创建用户是不用进行认证和授权的
-			userv1.POST("", handler.CreateUser)
+			// 创建用户。这里要注意：创建用户是不用进行认证和授权的，
+			// 但关闭注册时只有管理员可以创建用户，因此需要认证和授权
+			if c.cfg.RegistrationMode == known.RegistrationModeClosed {
+				userv1.POST("", slices.Concat(authMiddlewares, []gin.HandlerFunc{handler.CreateUser})...)
+			} else {
+				userv1.POST("", slices.Concat(idempotencyMiddlewares, []gin.HandlerFunc{handler.CreateUser})...)
+			}
 			userv1.Use(authMiddlewares...)
 			userv1.PUT(":userID/change-password", handler.ChangePassword) // 修改用户密码
+			userv1.POST(":userID/unlock", handler.UnlockUser)             // 解除用户登录锁定
+			userv1.POST(":userID/impersonate", handler.Impersonate)       // 代理用户身份
 			userv1.PUT(":userID", handler.UpdateUser)                     // 更新用户信息
+			userv1.PATCH(":userID", handler.UpdateUser)                   // 更新用户的部分信息
 			userv1.DELETE(":userID", handler.DeleteUser)                  // 删除用户
 			userv1.GET(":userID", handler.GetUser)                        // 查询用户详情
+			userv1.GET(":userID/roles", handler.GetUserRoles)             // 查询用户角色
 			userv1.GET("", handler.ListUser)                              // 查询用户列表.
 		}
 
 		// 博客相关路由
 		postv1 := v1.Group("/posts", authMiddlewares...)
 		{
-			postv1.POST("", handler.CreatePost)       // 创建博客
-			postv1.PUT(":postID", handler.UpdatePost) // 更新博客
-			postv1.DELETE("", handler.DeletePost)     // 删除博客
-			postv1.GET(":postID", handler.GetPost)    // 查询博客详情
-			postv1.GET("", handler.ListPost)          // 查询博客列表
+			postv1.POST("", handler.CreatePost)         // 创建博客
+			postv1.PUT(":postID", handler.UpdatePost)   // 更新博客
+			postv1.PATCH(":postID", handler.UpdatePost) // 更新博客的部分字段
+			postv1.DELETE("", handler.DeletePost)       // 删除博客
+			postv1.GET(":postID", handler.GetPost)      // 查询博客详情
+			postv1.GET("", handler.ListPost)            // 查询博客列表
+		}
+
+		// API Key 相关路由
+		apikeyv1 := v1.Group("/api-keys", authMiddlewares...)
+		{
+			apikeyv1.POST("", handler.CreateAPIKey)         // 创建 API Key
+			apikeyv1.DELETE(":keyID", handler.DeleteAPIKey) // 删除 API Key
+			apikeyv1.GET("", handler.ListAPIKey)            // 查询 API Key 列表
+		}
+
+		// 外部身份关联相关路由
+		identityv1 := v1.Group("/identities", authMiddlewares...)
+		{
+			identityv1.POST("", handler.LinkIdentity)              // 关联外部身份
+			identityv1.DELETE(":provider", handler.UnlinkIdentity) // 解除外部身份关联
+		}
+
+		// 邀请码相关路由
+		invitationv1 := v1.Group("/invitations", authMiddlewares...)
+		{
+			invitationv1.POST("", handler.CreateInvitation)        // 创建邀请码
+			invitationv1.DELETE(":code", handler.DeleteInvitation) // 删除邀请码
+			invitationv1.GET("", handler.ListInvitation)           // 查询邀请码列表
+		}
+
+		// 授权策略相关路由
+		policyv1 := v1.Group("/policies", authMiddlewares...)
+		{
+			policyv1.GET("", handler.ListPolicy)                  // 查询授权策略列表
+			policyv1.POST("", handler.AddPolicy)                  // 添加授权策略
+			policyv1.DELETE("", handler.RemovePolicy)             // 删除授权策略
+			policyv1.GET("explain", handler.ExplainAuthorization) // 解释授权判断的过程
 		}
+
+		// 角色分配相关路由
+		rolev1 := v1.Group("/role-assignments", authMiddlewares...)
+		{
+			rolev1.GET("", handler.ListRoleAssignment)      // 查询角色分配列表
+			rolev1.POST("", handler.AddRoleAssignment)      // 分配角色
+			rolev1.DELETE("", handler.RemoveRoleAssignment) // 取消角色分配
+		}
+
+		// 组织相关路由
+		orgv1 := v1.Group("/organizations", authMiddlewares...)
+		{
+			orgv1.POST("", handler.CreateOrganization)                               // 创建组织
+			orgv1.DELETE(":orgID", handler.DeleteOrganization)                       // 删除组织
+			orgv1.GET(":orgID", handler.GetOrganization)                             // 查询组织详情
+			orgv1.GET("", handler.ListOrganization)                                  // 查询当前用户所属的组织列表
+			orgv1.POST(":orgID/members", handler.AddOrganizationMember)              // 添加组织成员
+			orgv1.DELETE(":orgID/members/:userID", handler.RemoveOrganizationMember) // 移除组织成员
+			orgv1.GET(":orgID/members", handler.ListOrganizationMember)              // 查询组织成员列表
+		}
+
+		// 审计日志相关路由
+		auditv1 := v1.Group("/audit-events", authMiddlewares...)
+		{
+			auditv1.GET("", handler.ListAuditEvents) // 查询审计事件列表
+		}
+
+		// 内容配额相关路由
+		v1.GET("/quota", slices.Concat(authMiddlewares, []gin.HandlerFunc{handler.GetMyQuota})...) // 查询当前用户的内容配额
+
+		// 两步验证相关路由
+		totpv1 := v1.Group("/mfa/totp", authMiddlewares...)
+		{
+			totpv1.POST("enroll", handler.EnrollTOTP)   // 绑定 TOTP 身份验证器
+			totpv1.POST("verify", handler.VerifyTOTP)   // 确认绑定并开启两步验证
+			totpv1.POST("disable", handler.DisableTOTP) // 关闭两步验证
+		}
+	}
+}
+
+// NewGinIdempotentRoutes 返回支持幂等键的 Gin 路由，格式与 NewGinPermissions 的键相同，与 NewIdempotencyMatcher 保持一致.
+// 只包含创建资源的路由，登录、签发 API Key 等路由的响应中包含令牌或密钥，不能被保存.
+func NewGinIdempotentRoutes() []string {
+	return []string{
+		"POST /v1/users",
+		"POST /v1/posts",
+		"POST /v1/organizations",
 	}
 }
 
@@ -0,0 +1,49 @@
+package apiserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"miniblog/internal/pkg/known"
+	mw "miniblog/internal/pkg/middleware/http"
+)
+
+// recordingGuard 记录使用幂等键执行的请求，不保存响应.
+type recordingGuard struct {
+	keys []string
+}
+
+func (g *recordingGuard) Do(ctx context.Context, key, fingerprint string, fn func() ([]byte, error)) ([]byte, bool, error) {
+	g.keys = append(g.keys, key)
+	data, err := fn()
+	return data, false, err
+}
+
+func TestGinIdempotentRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	guard := &recordingGuard{}
+	engine := gin.New()
+	engine.Use(mw.IdempotencyMiddleware(guard, NewGinIdempotentRoutes()))
+	for _, path := range []string{"/login", "/v1/api-keys", "/v1/posts"} {
+		engine.POST(path, func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	}
+
+	for _, path := range []string{"/login", "/v1/api-keys", "/v1/posts"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set(known.IdempotencyKey, path)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+
+	// 登录和创建 API Key 的响应中包含令牌或密钥，不会被保存
+	if assert.Len(t, guard.keys, 1) {
+		assert.Contains(t, guard.keys[0], "/v1/posts")
+	}
+}
@@ -0,0 +1,108 @@
+package apiserver
+
+import (
+	"context"
+	"maps"
+	"miniblog/internal/pkg/known"
+	"miniblog/pkg/authz"
+	"slices"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+	adapter "github.com/casbin/gorm-adapter/v3"
+	"gorm.io/gorm"
+)
+
+// legacyGRPCAction 是旧版 gRPC 授权策略使用的动作.
+const legacyGRPCAction = "CALL"
+
+// PolicyMigration 描述一条旧格式授权策略的迁移结果.
+type PolicyMigration struct {
+	// Old 是迁移前的策略，对象为 gRPC 方法名或 HTTP 路由.
+	Old adapter.CasbinRule
+	// New 是迁移后的策略，对象和动作为逻辑权限的资源和动作. 为空表示无法识别，策略保持不变.
+	New []adapter.CasbinRule
+}
+
+// MigratePolicies 将 casbin_rule 表中基于 gRPC 方法名或 HTTP 路由的旧策略改写为基于逻辑权限的策略.
+// 所有改写在同一个事务中完成，dryRun 为 true 时只返回迁移结果，不修改数据库.
+func (cfg *Config) MigratePolicies(ctx context.Context, dryRun bool) ([]PolicyMigration, error) {
+	db, err := cfg.NewDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return MigratePolicies(ctx, db, dryRun)
+}
+
+// MigratePolicies 使用指定的数据库连接迁移旧格式的授权策略，参见 Config.MigratePolicies.
+func MigratePolicies(ctx context.Context, db *gorm.DB, dryRun bool) ([]PolicyMigration, error) {
+	var migrations []PolicyMigration
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 旧格式策略的对象均为以 / 开头的 gRPC 方法名或 HTTP 路由
+		var rules []adapter.CasbinRule
+		if err := tx.Where("ptype = ? AND v1 LIKE ?", "p", "/%").Order("id").Find(&rules).Error; err != nil {
+			return err
+		}
+
+		for _, rule := range rules {
+			migration := PolicyMigration{Old: rule}
+			for _, permission := range legacyPermissions(rule.V1, rule.V2) {
+				resource, action := known.SplitPermission(permission)
+				newRule := rule
+				newRule.ID = 0
+				newRule.V1, newRule.V2 = resource, action
+				migration.New = append(migration.New, newRule)
+			}
+			migrations = append(migrations, migration)
+
+			if dryRun || len(migration.New) == 0 {
+				continue
+			}
+
+			if err := tx.Delete(&adapter.CasbinRule{}, rule.ID).Error; err != nil {
+				return err
+			}
+			for _, newRule := range migration.New {
+				// 多条旧策略可能映射到同一条新策略，已存在时不再重复插入
+				if err := tx.Where(&newRule, "Ptype", "V0", "V1", "V2", "V3", "V4", "V5").FirstOrCreate(&newRule).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if dryRun {
+			return nil
+		}
+		// 迁移直接修改了 casbin_rule 表，通知运行中的实例重新加载策略
+		return authz.NotifyReload(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations, nil
+}
+
+// legacyPermissions 返回旧格式策略（对象为 gRPC 方法名或 HTTP 路由，动作为 CALL 或 HTTP 方法）所覆盖的逻辑权限.
+// 旧策略中的对象可以包含 * 通配符，因此一条策略可能覆盖多个权限. 这里使用 keyMatch2 匹配，使 /v1/users/*/roles
+// 这类中间带通配符的对象只覆盖预期的路由.
+func legacyPermissions(obj, act string) []string {
+	permissions := make(map[string]struct{})
+
+	if act == legacyGRPCAction || act == "*" {
+		for method, permission := range NewGRPCPermissions() {
+			if util.KeyMatch2(method, obj) {
+				permissions[permission] = struct{}{}
+			}
+		}
+	}
+
+	for route, permission := range NewGinPermissions() {
+		method, path, _ := strings.Cut(route, " ")
+		if (act == method || act == "*") && util.KeyMatch2(path, obj) {
+			permissions[permission] = struct{}{}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(permissions))
+}
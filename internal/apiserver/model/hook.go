@@ -8,10 +8,21 @@ import (
 )
 
 var (
-	UserPrefix = "user"
-	PostPrefix = "post"
+	UserPrefix   = "user"
+	PostPrefix   = "post"
+	APIKeyPrefix = "apikey"
+	OrgPrefix    = "org"
 )
 
+// BeforeSave 在手机号为空时不写入 phone 字段，使其保持为 NULL.
+// phone 字段有唯一索引，自动创建的外部身份用户可能没有手机号，写入空字符串会导致唯一索引冲突.
+func (m *UserM) BeforeSave(tx *gorm.DB) error {
+	if m.Phone == "" {
+		tx.Statement.Omits = append(tx.Statement.Omits, "phone")
+	}
+	return nil
+}
+
 // BeforeCreate 在创建数据库记录之前加密明文密码.
 func (m *UserM) BeforeCreate(tx *gorm.DB) error {
 	// Encrypt the user password.
@@ -35,3 +46,15 @@ func (m *PostM) AfterCreate(tx *gorm.DB) error {
 	m.PostID = string(rid.NewResourceID(PostPrefix).New(uint64(m.ID)))
 	return tx.Save(m).Error
 }
+
+// AfterCreate 在创建数据库记录之后生成 keyID.
+func (m *APIKeyM) AfterCreate(tx *gorm.DB) error {
+	m.KeyID = rid.NewResourceID(APIKeyPrefix).New(uint64(m.ID))
+	return tx.Save(m).Error
+}
+
+// AfterCreate 在创建数据库记录之后生成 orgID.
+func (m *OrganizationM) AfterCreate(tx *gorm.DB) error {
+	m.OrgID = rid.NewResourceID(OrgPrefix).New(uint64(m.ID))
+	return tx.Save(m).Error
+}
@@ -19,6 +19,8 @@ type PostM struct {
 	Content   string    `gorm:"column:content;not null;comment:博文内容" json:"content"`                                   // 博文内容
 	CreatedAt time.Time `gorm:"column:createdAt;not null;default:current_timestamp;comment:博文创建时间" json:"createdAt"`   // 博文创建时间
 	UpdatedAt time.Time `gorm:"column:updatedAt;not null;default:current_timestamp;comment:博文最后修改时间" json:"updatedAt"` // 博文最后修改时间
+	OrgID     string    `gorm:"column:orgID;not null;comment:博文所属的组织 ID，为空表示不属于任何组织" json:"orgID"`                     // 博文所属的组织 ID，为空表示不属于任何组织
+	Version   int64     `gorm:"column:version;not null;default:1;comment:博文版本号，每次修改加 1，用于乐观并发控制" json:"version"`       // 博文版本号，每次修改加 1，用于乐观并发控制
 }
 
 // TableName PostM's table name
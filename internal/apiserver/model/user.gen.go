@@ -12,15 +12,18 @@ const TableNameUserM = "user"
 
 // UserM 用户表
 type UserM struct {
-	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	UserID    string    `gorm:"column:userID;not null;uniqueIndex:idx_user_userID;comment:用户唯一 ID" json:"userID"`       // 用户唯一 ID
-	Username  string    `gorm:"column:username;not null;uniqueIndex:idx_user_username;comment:用户名（唯一）" json:"username"` // 用户名（唯一）
-	Password  string    `gorm:"column:password;not null;comment:用户密码（加密后）" json:"password"`                             // 用户密码（加密后）
-	Nickname  string    `gorm:"column:nickname;not null;comment:用户昵称" json:"nickname"`                                  // 用户昵称
-	Email     string    `gorm:"column:email;not null;comment:用户电子邮箱地址" json:"email"`                                    // 用户电子邮箱地址
-	Phone     string    `gorm:"column:phone;not null;uniqueIndex:idx_user_phone;comment:用户手机号" json:"phone"`            // 用户手机号
-	CreatedAt time.Time `gorm:"column:createdAt;not null;default:current_timestamp;comment:用户创建时间" json:"createdAt"`    // 用户创建时间
-	UpdatedAt time.Time `gorm:"column:updatedAt;not null;default:current_timestamp;comment:用户最后修改时间" json:"updatedAt"`  // 用户最后修改时间
+	ID                int64      `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	UserID            string     `gorm:"column:userID;not null;uniqueIndex:idx_user_userID;comment:用户唯一 ID" json:"userID"`              // 用户唯一 ID
+	Username          string     `gorm:"column:username;not null;uniqueIndex:idx_user_username;comment:用户名（唯一）" json:"username"`        // 用户名（唯一）
+	Password          string     `gorm:"column:password;not null;comment:用户密码（加密后）" json:"password"`                                    // 用户密码（加密后）
+	Nickname          string     `gorm:"column:nickname;not null;comment:用户昵称" json:"nickname"`                                         // 用户昵称
+	Email             string     `gorm:"column:email;not null;comment:用户电子邮箱地址" json:"email"`                                           // 用户电子邮箱地址
+	EmailVerified     bool       `gorm:"column:emailVerified;not null;comment:电子邮箱地址是否已验证" json:"emailVerified"`                        // 电子邮箱地址是否已验证
+	Phone             string     `gorm:"column:phone;uniqueIndex:idx_user_phone;comment:用户手机号" json:"phone"`                            // 用户手机号
+	SessionsRevokedAt *time.Time `gorm:"column:sessionsRevokedAt;type:datetime(3);comment:会话吊销时间，在此之前签发的令牌失效" json:"sessionsRevokedAt"` // 会话吊销时间，在此之前签发的令牌失效
+	CreatedAt         time.Time  `gorm:"column:createdAt;not null;default:current_timestamp;comment:用户创建时间" json:"createdAt"`           // 用户创建时间
+	UpdatedAt         time.Time  `gorm:"column:updatedAt;not null;default:current_timestamp;comment:用户最后修改时间" json:"updatedAt"`         // 用户最后修改时间
+	Version           int64      `gorm:"column:version;not null;default:1;comment:用户版本号，每次修改加 1，用于乐观并发控制" json:"version"`               // 用户版本号，每次修改加 1，用于乐观并发控制
 }
 
 // TableName UserM's table name
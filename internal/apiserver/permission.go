@@ -0,0 +1,107 @@
+package apiserver
+
+import (
+	"miniblog/internal/pkg/known"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+// NewGRPCPermissions 返回各 gRPC 方法对应的逻辑权限. gRPC-Gateway 模式下请求同样经过 gRPC 拦截器，使用相同的权限.
+// 需要授权但未在此声明的方法一律拒绝访问.
+func NewGRPCPermissions() map[string]string {
+	return map[string]string{
+		apiv1.MiniBlog_CreateUser_FullMethodName:               known.PermissionUserCreate,
+		apiv1.MiniBlog_UpdateUser_FullMethodName:               known.PermissionUserUpdate,
+		apiv1.MiniBlog_DeleteUser_FullMethodName:               known.PermissionUserDelete,
+		apiv1.MiniBlog_GetUser_FullMethodName:                  known.PermissionUserGet,
+		apiv1.MiniBlog_ListUser_FullMethodName:                 known.PermissionUserList,
+		apiv1.MiniBlog_ChangePassword_FullMethodName:           known.PermissionUserChangePassword,
+		apiv1.MiniBlog_UnlockUser_FullMethodName:               known.PermissionUserUnlock,
+		apiv1.MiniBlog_Impersonate_FullMethodName:              known.PermissionUserImpersonate,
+		apiv1.MiniBlog_GetUserRoles_FullMethodName:             known.PermissionUserGetRoles,
+		apiv1.MiniBlog_RefreshToken_FullMethodName:             known.PermissionTokenRefresh,
+		apiv1.MiniBlog_CreatePost_FullMethodName:               known.PermissionPostCreate,
+		apiv1.MiniBlog_UpdatePost_FullMethodName:               known.PermissionPostUpdate,
+		apiv1.MiniBlog_DeletePost_FullMethodName:               known.PermissionPostDelete,
+		apiv1.MiniBlog_GetPost_FullMethodName:                  known.PermissionPostGet,
+		apiv1.MiniBlog_ListPost_FullMethodName:                 known.PermissionPostList,
+		apiv1.MiniBlog_CreateAPIKey_FullMethodName:             known.PermissionAPIKeyCreate,
+		apiv1.MiniBlog_DeleteAPIKey_FullMethodName:             known.PermissionAPIKeyDelete,
+		apiv1.MiniBlog_ListAPIKey_FullMethodName:               known.PermissionAPIKeyList,
+		apiv1.MiniBlog_LinkIdentity_FullMethodName:             known.PermissionIdentityLink,
+		apiv1.MiniBlog_UnlinkIdentity_FullMethodName:           known.PermissionIdentityUnlink,
+		apiv1.MiniBlog_EnrollTOTP_FullMethodName:               known.PermissionMFAEnroll,
+		apiv1.MiniBlog_VerifyTOTP_FullMethodName:               known.PermissionMFAVerify,
+		apiv1.MiniBlog_DisableTOTP_FullMethodName:              known.PermissionMFADisable,
+		apiv1.MiniBlog_CreateInvitation_FullMethodName:         known.PermissionInvitationCreate,
+		apiv1.MiniBlog_DeleteInvitation_FullMethodName:         known.PermissionInvitationDelete,
+		apiv1.MiniBlog_ListInvitation_FullMethodName:           known.PermissionInvitationList,
+		apiv1.MiniBlog_ListPolicy_FullMethodName:               known.PermissionPolicyList,
+		apiv1.MiniBlog_AddPolicy_FullMethodName:                known.PermissionPolicyAdd,
+		apiv1.MiniBlog_RemovePolicy_FullMethodName:             known.PermissionPolicyRemove,
+		apiv1.MiniBlog_ExplainAuthorization_FullMethodName:     known.PermissionPolicyExplain,
+		apiv1.MiniBlog_ListRoleAssignment_FullMethodName:       known.PermissionRoleAssignmentList,
+		apiv1.MiniBlog_AddRoleAssignment_FullMethodName:        known.PermissionRoleAssignmentAdd,
+		apiv1.MiniBlog_RemoveRoleAssignment_FullMethodName:     known.PermissionRoleAssignmentRemove,
+		apiv1.MiniBlog_CreateOrganization_FullMethodName:       known.PermissionOrganizationCreate,
+		apiv1.MiniBlog_DeleteOrganization_FullMethodName:       known.PermissionOrganizationDelete,
+		apiv1.MiniBlog_GetOrganization_FullMethodName:          known.PermissionOrganizationGet,
+		apiv1.MiniBlog_ListOrganization_FullMethodName:         known.PermissionOrganizationList,
+		apiv1.MiniBlog_AddOrganizationMember_FullMethodName:    known.PermissionOrganizationMemberAdd,
+		apiv1.MiniBlog_RemoveOrganizationMember_FullMethodName: known.PermissionOrganizationMemberRemove,
+		apiv1.MiniBlog_ListOrganizationMember_FullMethodName:   known.PermissionOrganizationMemberList,
+		apiv1.MiniBlog_ListAuditEvents_FullMethodName:          known.PermissionAuditEventList,
+		apiv1.MiniBlog_GetMyQuota_FullMethodName:               known.PermissionQuotaGet,
+	}
+}
+
+// NewGinPermissions 返回各 Gin 路由对应的逻辑权限，键的格式为 "<METHOD> <路由模板>"，例如 "DELETE /v1/users/:userID".
+// 需要授权但未在此声明的路由一律拒绝访问.
+func NewGinPermissions() map[string]string {
+	return map[string]string{
+		"POST /v1/users":                                  known.PermissionUserCreate,
+		"PUT /v1/users/:userID":                           known.PermissionUserUpdate,
+		"PATCH /v1/users/:userID":                         known.PermissionUserUpdate,
+		"DELETE /v1/users/:userID":                        known.PermissionUserDelete,
+		"GET /v1/users/:userID":                           known.PermissionUserGet,
+		"GET /v1/users":                                   known.PermissionUserList,
+		"PUT /v1/users/:userID/change-password":           known.PermissionUserChangePassword,
+		"POST /v1/users/:userID/unlock":                   known.PermissionUserUnlock,
+		"POST /v1/users/:userID/impersonate":              known.PermissionUserImpersonate,
+		"GET /v1/users/:userID/roles":                     known.PermissionUserGetRoles,
+		"PUT /refresh-token":                              known.PermissionTokenRefresh,
+		"POST /v1/posts":                                  known.PermissionPostCreate,
+		"PUT /v1/posts/:postID":                           known.PermissionPostUpdate,
+		"PATCH /v1/posts/:postID":                         known.PermissionPostUpdate,
+		"DELETE /v1/posts":                                known.PermissionPostDelete,
+		"GET /v1/posts/:postID":                           known.PermissionPostGet,
+		"GET /v1/posts":                                   known.PermissionPostList,
+		"POST /v1/api-keys":                               known.PermissionAPIKeyCreate,
+		"DELETE /v1/api-keys/:keyID":                      known.PermissionAPIKeyDelete,
+		"GET /v1/api-keys":                                known.PermissionAPIKeyList,
+		"POST /v1/identities":                             known.PermissionIdentityLink,
+		"DELETE /v1/identities/:provider":                 known.PermissionIdentityUnlink,
+		"POST /v1/mfa/totp/enroll":                        known.PermissionMFAEnroll,
+		"POST /v1/mfa/totp/verify":                        known.PermissionMFAVerify,
+		"POST /v1/mfa/totp/disable":                       known.PermissionMFADisable,
+		"POST /v1/invitations":                            known.PermissionInvitationCreate,
+		"DELETE /v1/invitations/:code":                    known.PermissionInvitationDelete,
+		"GET /v1/invitations":                             known.PermissionInvitationList,
+		"GET /v1/policies":                                known.PermissionPolicyList,
+		"POST /v1/policies":                               known.PermissionPolicyAdd,
+		"DELETE /v1/policies":                             known.PermissionPolicyRemove,
+		"GET /v1/policies/explain":                        known.PermissionPolicyExplain,
+		"GET /v1/role-assignments":                        known.PermissionRoleAssignmentList,
+		"POST /v1/role-assignments":                       known.PermissionRoleAssignmentAdd,
+		"DELETE /v1/role-assignments":                     known.PermissionRoleAssignmentRemove,
+		"POST /v1/organizations":                          known.PermissionOrganizationCreate,
+		"DELETE /v1/organizations/:orgID":                 known.PermissionOrganizationDelete,
+		"GET /v1/organizations/:orgID":                    known.PermissionOrganizationGet,
+		"GET /v1/organizations":                           known.PermissionOrganizationList,
+		"POST /v1/organizations/:orgID/members":           known.PermissionOrganizationMemberAdd,
+		"DELETE /v1/organizations/:orgID/members/:userID": known.PermissionOrganizationMemberRemove,
+		"GET /v1/organizations/:orgID/members":            known.PermissionOrganizationMemberList,
+		"GET /v1/audit-events":                            known.PermissionAuditEventList,
+		"GET /v1/quota":                                   known.PermissionQuotaGet,
+	}
+}
@@ -0,0 +1,78 @@
+package apiserver
+
+import (
+	"context"
+	"miniblog/internal/pkg/known"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func TestGRPCPermissions(t *testing.T) {
+	permissions := NewGRPCPermissions()
+	whitelist := NewAuthzWhiteListMatcher(known.RegistrationModeOpen)
+
+	for _, method := range apiv1.MiniBlog_ServiceDesc.Methods {
+		fullMethod := "/" + apiv1.MiniBlog_ServiceDesc.ServiceName + "/" + method.MethodName
+		_, ok := permissions[fullMethod]
+		// 白名单匹配器对需要授权的方法返回 true
+		if whitelist.Match(context.Background(), interceptors.NewServerCallMeta(fullMethod, nil, nil)) {
+			assert.True(t, ok, "method %s requires authorization but has no permission", fullMethod)
+		}
+	}
+
+	for method, permission := range permissions {
+		assert.Contains(t, known.AvailablePermissions, permission, "method %s", method)
+	}
+}
+
+func TestGinPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	(&ServerConfig{cfg: &Config{}}).InstallRESTAPI(engine)
+
+	var routes []string
+	for _, route := range engine.Routes() {
+		routes = append(routes, route.Method+" "+route.Path)
+	}
+
+	permissions := NewGinPermissions()
+	for route, permission := range permissions {
+		assert.Contains(t, routes, route)
+		assert.Contains(t, known.AvailablePermissions, permission, "route %s", route)
+	}
+
+	for _, route := range routes {
+		// 注册用户是公开接口，其余 /v1 接口均需要授权
+		if !strings.Contains(route, " /v1/") || route == "POST /v1/users" {
+			continue
+		}
+		assert.Contains(t, permissions, route)
+	}
+}
+
+func TestLegacyPermissions(t *testing.T) {
+	tests := []struct {
+		obj, act string
+		want     []string
+	}{
+		{obj: "/v1.MiniBlog/DeleteUser", act: "CALL", want: []string{known.PermissionUserDelete}},
+		{obj: "/v1/users/*", act: "DELETE", want: []string{known.PermissionUserDelete}},
+		{obj: "/v1/users", act: "GET", want: []string{known.PermissionUserList}},
+		{obj: "/v1/users/*/roles", act: "GET", want: []string{known.PermissionUserGetRoles}},
+		{obj: "/v1/policies", act: "*", want: []string{known.PermissionPolicyAdd, known.PermissionPolicyList, known.PermissionPolicyRemove}},
+		{obj: "/v1.MiniBlog/*Invitation", act: "CALL", want: []string{known.PermissionInvitationCreate, known.PermissionInvitationDelete, known.PermissionInvitationList}},
+		{obj: "/v1/unknown", act: "GET", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		got := legacyPermissions(tt.obj, tt.act)
+		assert.True(t, slices.Equal(tt.want, got), "%s %s: want %v, got %v", tt.act, tt.obj, tt.want, got)
+	}
+}
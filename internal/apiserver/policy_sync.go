@@ -0,0 +1,127 @@
+package apiserver
+
+import (
+	"context"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/authz"
+	genericoptions "miniblog/pkg/options"
+
+	adapter "github.com/casbin/gorm-adapter/v3"
+	"gorm.io/gorm"
+)
+
+// ownerCondition 是只允许资源所有者访问的 ABAC 条件.
+const ownerCondition = "r2.res.OwnerID == r2.sub"
+
+// BuiltinPolicies 返回内置的授权策略，使不导入 configs/miniblog.sql 的空数据库也可以正常工作：
+// 管理员拥有全部权限，普通用户不能管理其他用户、邀请码和授权策略，不能查询审计日志，只能修改和删除自己的帖子，
+// 组织管理员可以管理组织以及组织内的帖子.
+func BuiltinPolicies() []authz.Rule {
+	rules := []authz.Rule{
+		{"p", known.RoleAdmin, "*", "*", known.PolicyEffectAllow},
+		{"p2", known.RoleAdmin, "*", "*", "true", known.PolicyEffectAllow},
+	}
+
+	for _, permission := range []string{
+		known.PermissionUserDelete,
+		known.PermissionUserList,
+		known.PermissionUserGetRoles,
+		known.PermissionInvitationCreate,
+		known.PermissionInvitationDelete,
+		known.PermissionInvitationList,
+		"policy:*",
+		"role-assignment:*",
+		"audit-event:*",
+	} {
+		resource, action := known.SplitPermission(permission)
+		rules = append(rules, authz.Rule{"p", known.RoleUser, resource, action, known.PolicyEffectDeny})
+	}
+
+	for _, permission := range []string{known.PermissionPostUpdate, known.PermissionPostDelete} {
+		resource, action := known.SplitPermission(permission)
+		rules = append(rules, authz.Rule{"p2", known.RoleUser, resource, action, ownerCondition, known.PolicyEffectAllow})
+	}
+
+	// 组织管理员可以管理组织、组织成员以及组织内的所有博客，组织成员可以查看组织和组织成员
+	for _, rule := range [][]string{
+		{known.RoleOrgAdmin, "post:*"},
+		{known.RoleOrgAdmin, "organization:*"},
+		{known.RoleOrgAdmin, "organization-member:*"},
+		{known.RoleOrgMember, known.PermissionOrganizationGet},
+		{known.RoleOrgMember, known.PermissionOrganizationMemberList},
+	} {
+		resource, action := known.SplitPermission(rule[1])
+		rules = append(rules, authz.Rule{"p2", rule[0], resource, action, "true", known.PolicyEffectAllow})
+	}
+
+	return rules
+}
+
+// SyncPolicies 将内置策略和策略包 file 中的规则与 casbin_rule 表比较，并按 mode 在同一个事务中应用差异.
+// file 为空时只同步内置策略.
+func SyncPolicies(ctx context.Context, db *gorm.DB, file string, mode string) (*authz.SyncResult, error) {
+	rules := BuiltinPolicies()
+	if file != "" {
+		bundle, err := authz.LoadBundle(file)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, bundle...)
+	}
+
+	return authz.SyncPolicies(ctx, db, rules, mode)
+}
+
+// syncPoliciesOnStartup 在启动时按配置同步授权策略. check 模式下只记录与策略包不一致的规则.
+// casbin_rule 表中没有任何规则时（例如没有导入 configs/miniblog.sql 的空数据库）总是写入内置策略和策略包，
+// 否则按配置的方式同步，使默认的 check 模式不会重新插入管理员删除的规则.
+func (cfg *Config) syncPoliciesOnStartup(ctx context.Context, db *gorm.DB) error {
+	mode := cfg.AuthzOptions.SyncMode
+	if mode == genericoptions.AuthzSyncModeNone {
+		return nil
+	}
+
+	empty, err := policiesEmpty(ctx, db)
+	if err != nil {
+		return err
+	}
+	if empty {
+		mode = authz.SyncModeAdditive
+	}
+
+	result, err := SyncPolicies(ctx, db, cfg.AuthzOptions.PolicyFile, mode)
+	if err != nil {
+		return err
+	}
+
+	if mode == authz.SyncModeCheck {
+		for _, rule := range result.Missing {
+			log.Warnw("Policy missing from database", "rule", rule.String())
+		}
+		for _, rule := range result.Extra {
+			log.Warnw("Policy not in bundle", "rule", rule.String())
+		}
+		return nil
+	}
+
+	removed := 0
+	if mode == authz.SyncModeAuthoritative {
+		removed = len(result.Extra)
+	}
+	log.Infow("Synced policies", "mode", mode, "added", len(result.Missing), "removed", removed)
+	return nil
+}
+
+// policiesEmpty 判断 casbin_rule 表是否不存在或者没有任何规则.
+func policiesEmpty(ctx context.Context, db *gorm.DB) (bool, error) {
+	if !db.Migrator().HasTable(&adapter.CasbinRule{}) {
+		return true, nil
+	}
+
+	var count int64
+	if err := db.WithContext(ctx).Model(&adapter.CasbinRule{}).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
@@ -0,0 +1,49 @@
+package apiserver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"miniblog/pkg/authz"
+	genericoptions "miniblog/pkg/options"
+)
+
+func TestSyncPoliciesOnStartup(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "authz.db")), &gorm.Config{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	cfg := &Config{AuthzOptions: genericoptions.NewAuthzOptions()}
+	require.Equal(t, authz.SyncModeCheck, cfg.AuthzOptions.SyncMode)
+
+	// 空数据库写入内置策略
+	require.NoError(t, cfg.syncPoliciesOnStartup(ctx, db))
+	total := int64(len(BuiltinPolicies()))
+	assert.Equal(t, total, countRules(t, db))
+
+	// 管理员删除的规则在默认的 check 模式下不会被重新插入
+	require.NoError(t, db.Exec("DELETE FROM casbin_rule WHERE ptype = ? AND v0 = ?", "p", "role::user").Error)
+	remaining := countRules(t, db)
+	require.Less(t, remaining, total)
+	require.NoError(t, cfg.syncPoliciesOnStartup(ctx, db))
+	assert.Equal(t, remaining, countRules(t, db))
+
+	// 显式配置 additive 模式时插入缺少的规则
+	cfg.AuthzOptions.SyncMode = authz.SyncModeAdditive
+	require.NoError(t, cfg.syncPoliciesOnStartup(ctx, db))
+	assert.Equal(t, total, countRules(t, db))
+}
+
+func countRules(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, db.Table("casbin_rule").Count(&count).Error)
+	return count
+}
@@ -6,20 +6,31 @@ package apiserver
 
 import (
 	"context"
+	"errors"
 	"miniblog/internal/apiserver/biz"
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	"miniblog/internal/pkg/server"
 	"miniblog/internal/pkg/validation"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"miniblog/internal/apiserver/store"
 	mw "miniblog/internal/pkg/middleware/grpc"
+	"miniblog/pkg/audit"
+	"miniblog/pkg/authenticator"
+	"miniblog/pkg/authn"
 	"miniblog/pkg/authz"
+	"miniblog/pkg/cipher"
+	"miniblog/pkg/idempotency"
+	"miniblog/pkg/lockout"
 	genericoptions "miniblog/pkg/options"
+	"miniblog/pkg/ratelimit"
 	"miniblog/pkg/store/where"
 	"miniblog/pkg/token"
 
@@ -45,13 +56,33 @@ const (
 // Config 运行时配置结构体, 用于存储应用相关的配置
 // 不用 viper.Get, 因为这种方式能更加清晰知道应用提供了哪些配置项
 type Config struct {
-	ServerMode   string
-	JWTKey       string
-	Expiration   time.Duration
-	GRPCOptions  *genericoptions.GRPCOptions
-	HTTPOptions  *genericoptions.HTTPOptions
-	MySQLOptions *genericoptions.MySQLOptions
-	TLSOptions   *genericoptions.TLSOptions
+	ServerMode           string
+	JWTKey               string
+	Expiration           time.Duration
+	EncryptionKey        string
+	GRPCOptions          *genericoptions.GRPCOptions
+	HTTPOptions          *genericoptions.HTTPOptions
+	MySQLOptions         *genericoptions.MySQLOptions
+	TLSOptions           *genericoptions.TLSOptions
+	RedisOptions         *genericoptions.RedisOptions
+	LockoutOptions       *genericoptions.LockoutOptions
+	AuthzOptions         *genericoptions.AuthzOptions
+	RateLimitOptions     *genericoptions.RateLimitOptions
+	IdempotencyOptions   *genericoptions.IdempotencyOptions
+	QuotaOptions         *genericoptions.QuotaOptions
+	AuditOptions         *genericoptions.AuditOptions
+	LDAPOptions          *genericoptions.LDAPOptions
+	OIDCOptions          *genericoptions.OIDCOptions
+	PasswordOptions      *genericoptions.PasswordOptions
+	MailOptions          *genericoptions.MailOptions
+	SMSOptions           *genericoptions.SMSOptions
+	PublicURL            string
+	AllowUnverifiedLogin bool
+	AllowUnverifiedPost  bool
+	RegistrationMode     string
+	JITProvisioning      bool
+	LinkByEmail          bool
+	TrustedProxies       []string
 }
 
 // UnionServer 定义一个联合服务器. 根据 ServerMode 决定要启动的服务器类型.
@@ -66,6 +97,8 @@ type Config struct {
 // HTTP 反向代理服务器依赖 gRPC 服务器，所以在开启 HTTP 反向代理服务器时，会先启动 gRPC 服务器.
 type UnionServer struct {
 	srv server.Server
+	// audit 为审计事件记录器，未开启审计日志时为 nil.
+	audit *audit.Recorder
 }
 
 // ServerConfig 包含服务器的核心依赖和配置.
@@ -75,6 +108,11 @@ type ServerConfig struct {
 	val       *validation.Validator
 	retriever mw.UserRetriever
 	authz     mw.Authorizer
+	audit     *audit.Recorder
+	// limiter 为请求限流器，未开启限流时为 nil.
+	limiter *ratelimit.Limiter
+	// idempotency 为幂等键守卫，未开启幂等键时为 nil.
+	idempotency *idempotency.Guard
 }
 
 // NewUnionServer 根据配置创建联合服务器.
@@ -84,10 +122,17 @@ func (cfg *Config) NewUnionServer() (*UnionServer, error) {
 	// 初始化 token 包
 	token.Init(cfg.JWTKey, "userID", 2*time.Hour)
 
+	// 初始化 authn 包，设置新密码使用的哈希算法和参数
+	authn.Init(cfg.PasswordOptions.HashConfig())
+
 	// 注册租赁，在之后调用 where.T(ctx)，就相当于加了个 userID = 用户明确的用户ID 的条件
-	where.RegisterTenant("userID", func(ctx context.Context) string {
+	where.RegisterTenant(known.TenantUser, func(ctx context.Context) string {
 		return contextx.UserID(ctx)
 	})
+	// 注册组织维度的租户，store 层通过 where.TenantScope 自动加上 orgID = 请求访问的组织 ID 的条件
+	where.RegisterTenant(known.TenantOrganization, func(ctx context.Context) string {
+		return contextx.OrgID(ctx)
+	})
 
 	// 创建服务配置，这些配置可用来创建服务器
 	serverConfig, err := cfg.NewServerConfig()
@@ -109,7 +154,7 @@ func (cfg *Config) NewUnionServer() (*UnionServer, error) {
 		srv, err = serverConfig.NewGRPCServerOr()
 	}
 
-	return &UnionServer{srv: srv}, nil
+	return &UnionServer{srv: srv, audit: serverConfig.audit}, nil
 }
 
 // Run 运行应用.
@@ -135,6 +180,13 @@ func (s *UnionServer) Run() error {
 	// 优先关闭依赖的服务器，再关闭被依赖的服务器
 	s.srv.GracefulStop(ctx)
 
+	// 服务器停止后不再产生审计事件，等待缓冲区中的审计事件写入完成
+	if s.audit != nil {
+		if err := s.audit.Close(ctx); err != nil {
+			log.Errorw("Failed to flush audit events", "err", err)
+		}
+	}
+
 	log.Infow("Server exited")
 	return nil
 }
@@ -149,19 +201,86 @@ func (cfg *Config) NewServerConfig() (*ServerConfig, error) {
 	}
 	store := store.NewStore(db)
 
+	// 注册 API Key 解析器，使 token.ParseRequest 同时支持 JWT 和 API Key
+	token.RegisterAPIKeyResolver((&APIKeyResolver{store: store}).Resolve)
+
+	// 同步内置策略和策略包，需要在授权器加载策略之前完成
+	if err := cfg.syncPoliciesOnStartup(context.Background(), db); err != nil {
+		log.Errorw("Failed to sync policies", "err", err)
+		return nil, err
+	}
+
 	// 创建授权器
-	authz, err := authz.NewAuthz(db)
+	authz, err := cfg.NewAuthz(db)
 	if err != nil {
 		log.Errorw("Failed to new authorizer", "err", err)
 		return nil, err
 	}
 
+	// 创建加密器，用于加密落库的敏感数据
+	cipher, err := cipher.New(cfg.EncryptionKey)
+	if err != nil {
+		log.Errorw("Failed to new cipher", "err", err)
+		return nil, err
+	}
+
+	// 创建登录防暴力破解守卫
+	loginGuard, err := cfg.NewLoginGuard()
+	if err != nil {
+		log.Errorw("Failed to new login guard", "err", err)
+		return nil, err
+	}
+
+	// 创建认证方式注册表
+	authenticators, err := cfg.NewAuthenticators(store)
+	if err != nil {
+		log.Errorw("Failed to new authenticators", "err", err)
+		return nil, err
+	}
+
+	// 创建密码策略
+	passwordPolicy, err := cfg.PasswordOptions.Policy()
+	if err != nil {
+		log.Errorw("Failed to load password policy", "err", err)
+		return nil, err
+	}
+
+	// 创建内容配额
+	quotaPlans, err := cfg.QuotaOptions.Plans()
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建审计事件记录器
+	recorder, err := cfg.NewAuditRecorder(store)
+	if err != nil {
+		log.Errorw("Failed to new audit recorder", "err", err)
+		return nil, err
+	}
+
+	// 创建请求限流器
+	limiter, err := cfg.NewRateLimiter()
+	if err != nil {
+		log.Errorw("Failed to new rate limiter", "err", err)
+		return nil, err
+	}
+
+	// 创建幂等键守卫
+	idempotencyGuard, err := cfg.NewIdempotencyGuard()
+	if err != nil {
+		log.Errorw("Failed to new idempotency guard", "err", err)
+		return nil, err
+	}
+
 	return &ServerConfig{
-		cfg:       cfg,
-		biz:       biz.NewBiz(store, authz),
-		val:       validation.New(store),
-		retriever: &UserRetriever{store: store},
-		authz:     authz,
+		cfg:         cfg,
+		biz:         biz.NewBiz(store, authz, cipher, loginGuard, authenticators, cfg.MailOptions.NewMailer(), cfg.SMSOptions.NewSender(), quotaPlans, cfg.PublicURL, cfg.AllowUnverifiedLogin, cfg.AllowUnverifiedPost, cfg.RegistrationMode),
+		val:         validation.New(store, passwordPolicy),
+		retriever:   &UserRetriever{store: store, authz: authz},
+		authz:       authz,
+		audit:       recorder,
+		limiter:     limiter,
+		idempotency: idempotencyGuard,
 	}, nil
 }
 
@@ -170,12 +289,172 @@ func (cfg *Config) NewDB() (*gorm.DB, error) {
 	return cfg.MySQLOptions.NewDB()
 }
 
-// UserRetriever 定义一个用户数据获取器. 用来获取用户信息.
+// NewAuthz 创建一个 *authz.Authz 实例，根据配置通过数据库版本行或 Redis 发布/订阅在实例之间同步策略变更.
+func (cfg *Config) NewAuthz(db *gorm.DB) (*authz.Authz, error) {
+	switch cfg.AuthzOptions.Watcher {
+	case genericoptions.AuthzWatcherDB:
+		watcher, err := authz.NewDBWatcher(db, cfg.AuthzOptions.PollInterval)
+		if err != nil {
+			return nil, err
+		}
+		return authz.NewAuthz(db, authz.WithWatcher(watcher))
+	case genericoptions.AuthzWatcherRedis:
+		rdb, err := cfg.RedisOptions.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		watcher, err := authz.NewRedisWatcher(rdb, cfg.AuthzOptions.Channel)
+		if err != nil {
+			return nil, err
+		}
+		return authz.NewAuthz(db, authz.WithWatcher(watcher))
+	default:
+		return authz.NewAuthz(db, authz.WithAutoLoadPolicyTime(cfg.AuthzOptions.PollInterval))
+	}
+}
+
+// NewLoginGuard 创建一个 *lockout.Guard 实例，根据配置在内存或 Redis 中记录登录失败次数.
+func (cfg *Config) NewLoginGuard() (*lockout.Guard, error) {
+	var store lockout.Store = lockout.NewMemoryStore()
+	if cfg.LockoutOptions.Backend == genericoptions.LockoutBackendRedis {
+		rdb, err := cfg.RedisOptions.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		store = lockout.NewRedisStore(rdb, "miniblog:lockout:")
+	}
+
+	return lockout.New(store, cfg.LockoutOptions.Policy()), nil
+}
+
+// NewRateLimiter 创建一个 *ratelimit.Limiter 实例，根据配置在内存或 Redis 中保存令牌桶. 未开启限流时返回 nil.
+func (cfg *Config) NewRateLimiter() (*ratelimit.Limiter, error) {
+	if !cfg.RateLimitOptions.Enabled() {
+		return nil, nil
+	}
+
+	rules, err := cfg.RateLimitOptions.Rules()
+	if err != nil {
+		return nil, err
+	}
+
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimitOptions.Backend == genericoptions.RateLimitBackendRedis {
+		rdb, err := cfg.RedisOptions.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		store = ratelimit.NewRedisStore(rdb, "miniblog:ratelimit:")
+	}
+
+	return ratelimit.New(store, cfg.RateLimitOptions.DefaultRule(), rules), nil
+}
+
+// NewIdempotencyGuard 创建一个 *idempotency.Guard 实例，根据配置在内存或 Redis 中保存幂等记录. 未开启幂等键时返回 nil.
+func (cfg *Config) NewIdempotencyGuard() (*idempotency.Guard, error) {
+	if !cfg.IdempotencyOptions.Enabled() {
+		return nil, nil
+	}
+
+	var store idempotency.Store = idempotency.NewMemoryStore()
+	if cfg.IdempotencyOptions.Backend == genericoptions.IdempotencyBackendRedis {
+		rdb, err := cfg.RedisOptions.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		store = idempotency.NewRedisStore(rdb, "miniblog:idempotency:")
+	}
+
+	return idempotency.New(store, cfg.IdempotencyOptions.TTL), nil
+}
+
+// NewAuthenticators 根据配置创建认证方式注册表. 本地密码认证始终启用，LDAP 和 OIDC 按配置启用.
+func (cfg *Config) NewAuthenticators(store store.IStore) (*authenticator.Registry, error) {
+	registry := authenticator.NewRegistry()
+	registry.JITProvisioning = cfg.JITProvisioning
+	registry.LinkByEmail = cfg.LinkByEmail
+
+	registry.Register(authenticator.NewLocal(func(ctx context.Context, username string) (string, string, bool, error) {
+		userM, err := store.User().Get(ctx, where.F("username", username))
+		if err != nil {
+			if errors.Is(err, errno.ErrUserNotFound) {
+				return "", "", false, nil
+			}
+			return "", "", false, err
+		}
+		return userM.UserID, userM.Password, true, nil
+	}, func(ctx context.Context, userID, oldHashedPassword, hashedPassword string) error {
+		if err := store.User().UpdatePassword(ctx, userID, oldHashedPassword, hashedPassword); err != nil {
+			log.W(ctx).Errorw("Failed to rehash user password", "userID", userID, "err", err)
+			return err
+		}
+		return nil
+	}))
+
+	if cfg.LDAPOptions.Enabled() {
+		registry.Register(cfg.LDAPOptions.NewAuthenticator())
+	}
+
+	if cfg.OIDCOptions.Enabled() {
+		provider, err := cfg.OIDCOptions.NewProvider(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		registry.SetOIDC(provider)
+	}
+
+	log.Infow("Initialized authentication providers", "providers", registry.Names())
+	return registry, nil
+}
+
+// UserRetriever 定义一个用户数据获取器. 用来获取用户信息、用户角色以及用户在组织中的角色.
 type UserRetriever struct {
 	store store.IStore
+	authz *authz.Authz
 }
 
 // GetUser 根据用户 ID 获取用户信息.
 func (r *UserRetriever) GetUser(ctx context.Context, userID string) (*model.UserM, error) {
 	return r.store.User().Get(ctx, where.F("userID", userID))
 }
+
+// GetRoles 获取用户实际拥有的角色，包括通过角色继承获得的角色.
+func (r *UserRetriever) GetRoles(ctx context.Context, userID string) ([]string, error) {
+	return r.authz.GetImplicitRolesForUser(userID)
+}
+
+// GetOrganizationRoles 获取用户在组织中的角色，用户不是组织成员时返回空.
+func (r *UserRetriever) GetOrganizationRoles(ctx context.Context, userID, orgID string) ([]string, error) {
+	return r.authz.DomainRoles(userID, orgID)
+}
+
+// APIKeyResolver 定义一个 API Key 解析器. 用来将 API Key 解析为用户身份.
+type APIKeyResolver struct {
+	store store.IStore
+}
+
+// Resolve 根据 API Key 明文查找对应的用户，并返回身份凭证.
+func (r *APIKeyResolver) Resolve(ctx context.Context, apiKey string) (*token.Credential, error) {
+	apiKeyM, err := r.store.APIKey().Get(ctx, where.F("secretHash", token.HashAPIKey(apiKey)))
+	if err != nil {
+		return nil, errno.ErrAPIKeyInvalid
+	}
+
+	now := time.Now()
+	if apiKeyM.ExpiresAt != nil && apiKeyM.ExpiresAt.Before(now) {
+		return nil, errno.ErrAPIKeyInvalid
+	}
+
+	// 记录 API Key 的最后使用时间，距离上次记录不足 APIKeyLastUsedInterval 时跳过，失败时不影响认证结果
+	if before := now.Add(-known.APIKeyLastUsedInterval); apiKeyM.LastUsedAt == nil || apiKeyM.LastUsedAt.Before(before) {
+		if err := r.store.APIKey().TouchLastUsed(ctx, apiKeyM.ID, now, before); err != nil {
+			log.Warnw("Failed to update api key last used time", "keyID", apiKeyM.KeyID, "err", err)
+		}
+	}
+
+	credential := &token.Credential{Identity: apiKeyM.UserID, KeyID: apiKeyM.KeyID}
+	if apiKeyM.Scopes != "" {
+		credential.Scopes = strings.Split(apiKeyM.Scopes, ",")
+	}
+	return credential, nil
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyStore 定义了 api key 模块在 store 层所实现的方法.
+type APIKeyStore interface {
+	Create(ctx context.Context, obj *model.APIKeyM) error
+	Update(ctx context.Context, obj *model.APIKeyM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.APIKeyM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.APIKeyM, error)
+
+	APIKeyExpansion
+}
+
+// APIKeyExpansion 定义了 API Key 操作的附加方法.
+type APIKeyExpansion interface {
+	// TouchLastUsed 将 API Key 的最后使用时间更新为 usedAt，仅当原来的值为空或早于 before 时更新.
+	// 只更新 lastUsedAt 一列，不会覆盖并发修改的其他字段，API Key 已被删除时不做任何操作.
+	TouchLastUsed(ctx context.Context, id int64, usedAt, before time.Time) error
+}
+
+// apiKeyStore 是 APIKeyStore 接口的实现.
+type apiKeyStore struct {
+	store *datastore
+}
+
+// 确保 apiKeyStore 实现了 APIKeyStore 接口.
+var _ APIKeyStore = (*apiKeyStore)(nil)
+
+// newAPIKeyStore 创建 apiKeyStore 的实例.
+func newAPIKeyStore(store *datastore) *apiKeyStore {
+	return &apiKeyStore{
+		store: store,
+	}
+}
+
+// Create 插入一条 API Key 记录.
+func (s *apiKeyStore) Create(ctx context.Context, obj *model.APIKeyM) error {
+	if err := s.store.DB(ctx).Create(&obj).Error; err != nil {
+		log.Errorw("Failed to insert api key into database", "err", err, "keyID", obj.KeyID)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// TouchLastUsed 更新 API Key 的最后使用时间.
+func (s *apiKeyStore) TouchLastUsed(ctx context.Context, id int64, usedAt, before time.Time) error {
+	err := s.store.DB(ctx).Model(&model.APIKeyM{}).
+		Where("id = ? AND (lastUsedAt IS NULL OR lastUsedAt < ?)", id, before).
+		Update("lastUsedAt", usedAt).Error
+	if err != nil {
+		log.Errorw("Failed to update api key last used time in database", "err", err, "id", id)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Update 更新 API Key 数据库记录.
+func (s *apiKeyStore) Update(ctx context.Context, obj *model.APIKeyM) error {
+	if err := s.store.DB(ctx).Save(obj).Error; err != nil {
+		log.Errorw("Failed to update api key in database", "err", err, "keyID", obj.KeyID)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Delete 根据条件删除 API Key 记录.
+func (s *apiKeyStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(new(model.APIKeyM)).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorw("Failed to delete api key from database", "err", err, "conditions", opts)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Get 根据条件查询 API Key 记录.
+func (s *apiKeyStore) Get(ctx context.Context, opts *where.Options) (*model.APIKeyM, error) {
+	var obj model.APIKeyM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		log.Errorw("Failed to retrieve api key from database", "err", err, "conditions", opts)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAPIKeyNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	return &obj, nil
+}
+
+// List 返回 API Key 列表和总数.
+func (s *apiKeyStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.APIKeyM, err error) {
+	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list api keys from database", "err", err, "conditions", opts)
+		err = errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return
+}
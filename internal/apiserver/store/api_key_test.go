@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniblog/internal/apiserver/model"
+	"miniblog/pkg/store/where"
+)
+
+func TestAPIKeyStoreTouchLastUsed(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	apiKeyM := &model.APIKeyM{UserID: "user-000001", Name: "ci", Prefix: "mb_", SecretHash: "hash"}
+	require.NoError(t, s.APIKey().Create(ctx, apiKeyM))
+
+	lastUsedAt := func() time.Time {
+		t.Helper()
+		got, err := s.APIKey().Get(ctx, where.F("keyID", apiKeyM.KeyID))
+		require.NoError(t, err)
+		require.NotNil(t, got.LastUsedAt)
+		return *got.LastUsedAt
+	}
+
+	now := time.Now().Truncate(time.Second)
+	require.NoError(t, s.APIKey().TouchLastUsed(ctx, apiKeyM.ID, now, now.Add(-time.Minute)))
+	assert.True(t, lastUsedAt().Equal(now))
+
+	// 距离上次记录不足间隔时不更新
+	later := now.Add(30 * time.Second)
+	require.NoError(t, s.APIKey().TouchLastUsed(ctx, apiKeyM.ID, later, later.Add(-time.Minute)))
+	assert.True(t, lastUsedAt().Equal(now))
+
+	// 超过间隔后更新
+	later = now.Add(2 * time.Minute)
+	require.NoError(t, s.APIKey().TouchLastUsed(ctx, apiKeyM.ID, later, later.Add(-time.Minute)))
+	assert.True(t, lastUsedAt().Equal(later))
+}
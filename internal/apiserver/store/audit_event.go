@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+)
+
+// AuditEventStore 定义了 audit_event 模块在 store 层所实现的方法. 审计事件只能写入和查询，不能修改和删除.
+type AuditEventStore interface {
+	Create(ctx context.Context, objs ...*model.AuditEventM) error
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AuditEventM, error)
+
+	AuditEventExpansion
+}
+
+// AuditEventExpansion 定义了审计事件操作的附加方法.
+type AuditEventExpansion interface{}
+
+// auditEventStore 是 AuditEventStore 接口的实现.
+type auditEventStore struct {
+	store *datastore
+}
+
+// 确保 auditEventStore 实现了 AuditEventStore 接口.
+var _ AuditEventStore = (*auditEventStore)(nil)
+
+// newAuditEventStore 创建 auditEventStore 的实例.
+func newAuditEventStore(store *datastore) *auditEventStore {
+	return &auditEventStore{
+		store: store,
+	}
+}
+
+// Create 批量插入审计事件.
+func (s *auditEventStore) Create(ctx context.Context, objs ...*model.AuditEventM) error {
+	if len(objs) == 0 {
+		return nil
+	}
+	if err := s.store.DB(ctx).Create(&objs).Error; err != nil {
+		log.Errorw("Failed to insert audit events into database", "err", err, "count", len(objs))
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// List 返回审计事件列表和总数，按时间倒序排列.
+func (s *auditEventStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.AuditEventM, err error) {
+	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list audit events from database", "err", err, "conditions", opts)
+		err = errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return
+}
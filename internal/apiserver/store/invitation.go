@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InvitationStore 定义了 invitation 模块在 store 层所实现的方法.
+type InvitationStore interface {
+	Create(ctx context.Context, obj *model.InvitationM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.InvitationM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.InvitationM, error)
+
+	InvitationExpansion
+}
+
+// InvitationExpansion 定义了邀请码操作的附加方法.
+type InvitationExpansion interface {
+	// Consume 将邀请码的使用次数加 1. 邀请码不存在、已过期或已用完时返回 errno.ErrInvitationInvalid.
+	// 检查和更新在同一条 SQL 中完成，并发注册时不会超出最大使用次数.
+	Consume(ctx context.Context, code string) error
+}
+
+// invitationStore 是 InvitationStore 接口的实现.
+type invitationStore struct {
+	store *datastore
+}
+
+// 确保 invitationStore 实现了 InvitationStore 接口.
+var _ InvitationStore = (*invitationStore)(nil)
+
+// newInvitationStore 创建 invitationStore 的实例.
+func newInvitationStore(store *datastore) *invitationStore {
+	return &invitationStore{
+		store: store,
+	}
+}
+
+// Create 插入一条邀请码记录.
+func (s *invitationStore) Create(ctx context.Context, obj *model.InvitationM) error {
+	if err := s.store.DB(ctx).Create(&obj).Error; err != nil {
+		log.Errorw("Failed to insert invitation into database", "err", err, "createdBy", obj.CreatedBy)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Delete 根据条件删除邀请码记录.
+func (s *invitationStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(new(model.InvitationM)).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorw("Failed to delete invitation from database", "err", err, "conditions", opts)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Get 根据条件查询邀请码记录.
+func (s *invitationStore) Get(ctx context.Context, opts *where.Options) (*model.InvitationM, error) {
+	var obj model.InvitationM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvitationInvalid
+		}
+		log.Errorw("Failed to retrieve invitation from database", "err", err, "conditions", opts)
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	return &obj, nil
+}
+
+// List 返回邀请码列表和总数.
+func (s *invitationStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.InvitationM, err error) {
+	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list invitations from database", "err", err, "conditions", opts)
+		err = errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return
+}
+
+// Consume 将邀请码的使用次数加 1.
+func (s *invitationStore) Consume(ctx context.Context, code string) error {
+	result := s.store.DB(ctx).Model(&model.InvitationM{}).
+		Where("code = ? AND usedCount < maxUses AND expiresAt > ?", code, time.Now()).
+		Update("usedCount", gorm.Expr("usedCount + 1"))
+	if result.Error != nil {
+		log.Errorw("Failed to consume invitation", "err", result.Error)
+		return errno.ErrDBWrite.WithMessage("%s", result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errno.ErrInvitationInvalid
+	}
+	return nil
+}
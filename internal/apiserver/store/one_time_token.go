@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OneTimeTokenStore 定义了 one time token 模块在 store 层所实现的方法.
+type OneTimeTokenStore interface {
+	Create(ctx context.Context, obj *model.OneTimeTokenM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.OneTimeTokenM, error)
+
+	OneTimeTokenExpansion
+}
+
+// OneTimeTokenExpansion 定义了一次性令牌操作的附加方法.
+type OneTimeTokenExpansion interface {
+	// Consume 将未使用的令牌标记为已使用. 令牌已被使用时返回 errno.ErrOneTimeTokenInvalid，保证令牌只能使用一次.
+	Consume(ctx context.Context, obj *model.OneTimeTokenM) error
+}
+
+// oneTimeTokenStore 是 OneTimeTokenStore 接口的实现.
+type oneTimeTokenStore struct {
+	store *datastore
+}
+
+// 确保 oneTimeTokenStore 实现了 OneTimeTokenStore 接口.
+var _ OneTimeTokenStore = (*oneTimeTokenStore)(nil)
+
+// newOneTimeTokenStore 创建 oneTimeTokenStore 的实例.
+func newOneTimeTokenStore(store *datastore) *oneTimeTokenStore {
+	return &oneTimeTokenStore{
+		store: store,
+	}
+}
+
+// Create 插入一条一次性令牌记录.
+func (s *oneTimeTokenStore) Create(ctx context.Context, obj *model.OneTimeTokenM) error {
+	if err := s.store.DB(ctx).Create(&obj).Error; err != nil {
+		log.Errorw("Failed to insert one time token into database", "err", err, "userID", obj.UserID, "purpose", obj.Purpose)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Delete 根据条件删除一次性令牌记录.
+func (s *oneTimeTokenStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(new(model.OneTimeTokenM)).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorw("Failed to delete one time token from database", "err", err, "conditions", opts)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Get 根据条件查询一次性令牌记录.
+func (s *oneTimeTokenStore) Get(ctx context.Context, opts *where.Options) (*model.OneTimeTokenM, error) {
+	var obj model.OneTimeTokenM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrOneTimeTokenInvalid
+		}
+		log.Errorw("Failed to retrieve one time token from database", "err", err, "conditions", opts)
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	return &obj, nil
+}
+
+// Consume 将未使用的令牌标记为已使用.
+func (s *oneTimeTokenStore) Consume(ctx context.Context, obj *model.OneTimeTokenM) error {
+	now := time.Now()
+	result := s.store.DB(ctx).Model(obj).Where("usedAt IS NULL").Update("usedAt", now)
+	if result.Error != nil {
+		log.Errorw("Failed to consume one time token", "err", result.Error, "userID", obj.UserID, "purpose", obj.Purpose)
+		return errno.ErrDBWrite.WithMessage("%s", result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errno.ErrOneTimeTokenInvalid
+	}
+
+	obj.UsedAt = &now
+	return nil
+}
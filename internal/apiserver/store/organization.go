@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+
+	"gorm.io/gorm"
+)
+
+// OrganizationStore 定义了 organization 模块在 store 层所实现的方法.
+type OrganizationStore interface {
+	Create(ctx context.Context, obj *model.OrganizationM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.OrganizationM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.OrganizationM, error)
+
+	OrganizationExpansion
+}
+
+// OrganizationExpansion 定义了组织操作的附加方法.
+type OrganizationExpansion interface{}
+
+// organizationStore 是 OrganizationStore 接口的实现.
+type organizationStore struct {
+	store *datastore
+}
+
+// 确保 organizationStore 实现了 OrganizationStore 接口.
+var _ OrganizationStore = (*organizationStore)(nil)
+
+// newOrganizationStore 创建 organizationStore 的实例.
+func newOrganizationStore(store *datastore) *organizationStore {
+	return &organizationStore{
+		store: store,
+	}
+}
+
+// Create 插入一条组织记录.
+func (s *organizationStore) Create(ctx context.Context, obj *model.OrganizationM) error {
+	if err := s.store.DB(ctx).Create(&obj).Error; err != nil {
+		log.Errorw("Failed to insert organization into database", "err", err, "organization", obj)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Delete 根据条件删除组织记录.
+func (s *organizationStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(new(model.OrganizationM)).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorw("Failed to delete organization from database", "err", err, "conditions", opts)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Get 根据条件查询组织记录.
+func (s *organizationStore) Get(ctx context.Context, opts *where.Options) (*model.OrganizationM, error) {
+	var obj model.OrganizationM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrOrganizationNotFound
+		}
+		log.Errorw("Failed to retrieve organization from database", "err", err, "conditions", opts)
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	return &obj, nil
+}
+
+// List 返回组织列表和总数.
+func (s *organizationStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.OrganizationM, err error) {
+	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to list organizations from database", "err", err, "conditions", opts)
+		err = errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return
+}
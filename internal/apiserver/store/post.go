@@ -5,6 +5,7 @@ import (
 	"errors"
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	"miniblog/pkg/store/where"
 
@@ -12,6 +13,8 @@ import (
 )
 
 // PostStore 定义了 post 模块在 store 层所实现的方法.
+// 博客按组织隔离，查询和删除时自动加上 orgID 等于请求访问的组织 ID 的条件，
+// 因此只能修改通过 Get 查询到的博客.
 type PostStore interface {
 	Create(ctx context.Context, obj *model.PostM) error
 	Update(ctx context.Context, obj *model.PostM) error
@@ -23,7 +26,10 @@ type PostStore interface {
 }
 
 // PostExpansion 定义了用户操作的附加方法.
-type PostExpansion interface{}
+type PostExpansion interface {
+	// CountByUser 返回用户在所有组织中的博客数. 与 List 不同，不会加上组织隔离条件.
+	CountByUser(ctx context.Context, userID string) (int64, error)
+}
 
 // postStore 是 PostStore 接口的实现.
 type postStore struct {
@@ -49,17 +55,28 @@ func (s *postStore) Create(ctx context.Context, obj *model.PostM) error {
 	return nil
 }
 
+// Update 仅当数据库中的版本号与 obj.Version 一致时才会更新，并将版本号加 1，
+// 否则说明博客已被并发修改，返回 errno.ErrPreconditionFailed.
 func (s *postStore) Update(ctx context.Context, obj *model.PostM) error {
-	if err := s.store.DB(ctx).Save(&obj).Error; err != nil {
+	version := obj.Version
+	obj.Version++
+	// 指定 Select 避免 Save 在未更新任何记录时退化为插入
+	result := s.store.DB(ctx).Select("*").Where("version = ?", version).Save(obj)
+	if err := result.Error; err != nil {
+		obj.Version = version
 		log.Errorw("Failed to update post in database", "err", err, "post", obj)
 		return errno.ErrDBWrite.WithMessage("%s", err.Error())
 	}
+	if result.RowsAffected == 0 {
+		obj.Version = version
+		return errno.ErrPreconditionFailed
+	}
 
 	return nil
 }
 
 func (s *postStore) Delete(ctx context.Context, opts *where.Options) error {
-	err := s.store.DB(ctx, opts).Delete(new(model.PostM)).Error
+	err := s.store.DB(ctx, opts, s.tenant(ctx)).Delete(new(model.PostM)).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		log.Errorw("Failed to delete post from database", "err", err, "conditions", opts)
 		return errno.ErrDBWrite.WithMessage("%s", err.Error())
@@ -70,7 +87,7 @@ func (s *postStore) Delete(ctx context.Context, opts *where.Options) error {
 
 func (s *postStore) Get(ctx context.Context, opts *where.Options) (*model.PostM, error) {
 	var obj model.PostM
-	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+	if err := s.store.DB(ctx, opts, s.tenant(ctx)).First(&obj).Error; err != nil {
 		log.Errorw("Failed to retrieve post from database", "err", err, "conditions", opts)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errno.ErrPostNotFound
@@ -82,10 +99,24 @@ func (s *postStore) Get(ctx context.Context, opts *where.Options) (*model.PostM,
 }
 
 func (s *postStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.PostM, err error) {
-	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Error
+	err = s.store.DB(ctx, opts, s.tenant(ctx)).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Error
 	if err != nil {
 		log.Errorw("Failed to list posts from database", "err", err, "conditions", opts)
 		err = errno.ErrDBRead.WithMessage("%s", err.Error())
 	}
 	return
 }
+
+func (s *postStore) CountByUser(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	if err := s.store.DB(ctx).Model(&model.PostM{}).Where("userID = ?", userID).Count(&count).Error; err != nil {
+		log.Errorw("Failed to count posts from database", "err", err, "userID", userID)
+		return 0, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	return count, nil
+}
+
+// tenant 返回博客的租户隔离条件.
+func (s *postStore) tenant(ctx context.Context) where.Where {
+	return where.TenantScope(ctx, known.TenantOrganization)
+}
@@ -24,6 +24,14 @@ type IStore interface {
 	// 得到各张表的接口
 	User() UserStore
 	Post() PostStore
+	APIKey() APIKeyStore
+	UserTOTP() UserTOTPStore
+	UserIdentity() UserIdentityStore
+	OneTimeToken() OneTimeTokenStore
+	Invitation() InvitationStore
+	Organization() OrganizationStore
+	AuditEvent() AuditEventStore
+	UserQuota() UserQuotaStore
 }
 
 // transactionKey 用于在 context.Context 中存储事务上下文的键.
@@ -89,3 +97,43 @@ func (store *datastore) User() UserStore {
 func (store *datastore) Post() PostStore {
 	return newPostStore(store)
 }
+
+// APIKey 返回一个实现了 APIKeyStore 接口的实例.
+func (store *datastore) APIKey() APIKeyStore {
+	return newAPIKeyStore(store)
+}
+
+// UserTOTP 返回一个实现了 UserTOTPStore 接口的实例.
+func (store *datastore) UserTOTP() UserTOTPStore {
+	return newUserTOTPStore(store)
+}
+
+// UserIdentity 返回一个实现了 UserIdentityStore 接口的实例.
+func (store *datastore) UserIdentity() UserIdentityStore {
+	return newUserIdentityStore(store)
+}
+
+// OneTimeToken 返回一个实现了 OneTimeTokenStore 接口的实例.
+func (store *datastore) OneTimeToken() OneTimeTokenStore {
+	return newOneTimeTokenStore(store)
+}
+
+// Invitation 返回一个实现了 InvitationStore 接口的实例.
+func (store *datastore) Invitation() InvitationStore {
+	return newInvitationStore(store)
+}
+
+// Organization 返回一个实现了 OrganizationStore 接口的实例.
+func (store *datastore) Organization() OrganizationStore {
+	return newOrganizationStore(store)
+}
+
+// AuditEvent 返回一个实现了 AuditEventStore 接口的实例.
+func (store *datastore) AuditEvent() AuditEventStore {
+	return newAuditEventStore(store)
+}
+
+// UserQuota 返回一个实现了 UserQuotaStore 接口的实例.
+func (store *datastore) UserQuota() UserQuotaStore {
+	return newUserQuotaStore(store)
+}
@@ -23,7 +23,16 @@ type UserStore interface {
 }
 
 // UserExpansion 定义了用户操作的附加方法.
-type UserExpansion interface{}
+// 这些方法只更新指定的列，用于登录等内部流程，不会因为用户记录被并发修改而失败.
+type UserExpansion interface {
+	// UpdatePassword 仅当用户的密码仍为 oldPassword 时将其更新为 newPassword，用于登录后重新加密密码.
+	// 密码不在用户资源的表示中，因此不增加版本号，之后使用旧记录的 Update 可能写回旧的密码哈希，
+	// 二者对应同一个密码，下次登录时会再次重新加密. 密码已被并发修改时返回 errno.ErrPreconditionFailed.
+	UpdatePassword(ctx context.Context, userID, oldPassword, newPassword string) error
+	// MarkEmailVerified 仅当用户的邮箱仍为 email 时将其标记为已验证，并将版本号加 1.
+	// 邮箱已被并发修改时返回 errno.ErrPreconditionFailed，已验证时直接返回.
+	MarkEmailVerified(ctx context.Context, userID, email string) error
+}
 
 // userStore 是 UserStore 接口的实现.
 type userStore struct {
@@ -51,12 +60,63 @@ func (s *userStore) Create(ctx context.Context, obj *model.UserM) error {
 }
 
 // Update 更新用户数据库记录.
-// 请求中没有传入的参数将会被零值替代
+// 请求中没有传入的参数将会被零值替代.
+// 仅当数据库中的版本号与 obj.Version 一致时才会更新，并将版本号加 1，
+// 否则说明记录已被并发修改，返回 errno.ErrPreconditionFailed.
 func (s *userStore) Update(ctx context.Context, obj *model.UserM) error {
-	if err := s.store.DB(ctx).Save(obj).Error; err != nil {
+	version := obj.Version
+	obj.Version++
+	// 指定 Select 避免 Save 在未更新任何记录时退化为插入
+	result := s.store.DB(ctx).Select("*").Where("version = ?", version).Save(obj)
+	if err := result.Error; err != nil {
+		obj.Version = version
 		log.Errorw("Failed to update user in database", "err", err, "user", obj)
 		return errno.ErrDBWrite.WithMessage("%s", err.Error())
 	}
+	if result.RowsAffected == 0 {
+		obj.Version = version
+		return errno.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// UpdatePassword 仅当密码没有被并发修改时更新用户的密码.
+func (s *userStore) UpdatePassword(ctx context.Context, userID, oldPassword, newPassword string) error {
+	result := s.store.DB(ctx).Model(&model.UserM{}).
+		Where("userID = ? AND password = ?", userID, oldPassword).
+		Update("password", newPassword)
+	if err := result.Error; err != nil {
+		log.Errorw("Failed to update user password in database", "err", err, "userID", userID)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	if result.RowsAffected == 0 {
+		return errno.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// MarkEmailVerified 仅当邮箱没有被并发修改时将用户的邮箱标记为已验证.
+func (s *userStore) MarkEmailVerified(ctx context.Context, userID, email string) error {
+	result := s.store.DB(ctx).Model(&model.UserM{}).
+		Where("userID = ? AND email = ? AND emailVerified = ?", userID, email, false).
+		Updates(map[string]any{"emailVerified": true, "version": gorm.Expr("version + 1")})
+	if err := result.Error; err != nil {
+		log.Errorw("Failed to mark user email verified in database", "err", err, "userID", userID)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// 没有更新任何记录时，区分邮箱已验证和邮箱已被修改
+	var count int64
+	if err := s.store.DB(ctx).Model(&model.UserM{}).Where("userID = ? AND email = ?", userID, email).Count(&count).Error; err != nil {
+		log.Errorw("Failed to count users from database", "err", err, "userID", userID)
+		return errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	if count == 0 {
+		return errno.ErrPreconditionFailed
+	}
 	return nil
 }
 
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+
+	"gorm.io/gorm"
+)
+
+// UserIdentityStore 定义了 user identity 模块在 store 层所实现的方法.
+type UserIdentityStore interface {
+	Create(ctx context.Context, obj *model.UserIdentityM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.UserIdentityM, error)
+
+	UserIdentityExpansion
+}
+
+// UserIdentityExpansion 定义了外部身份操作的附加方法.
+type UserIdentityExpansion interface{}
+
+// userIdentityStore 是 UserIdentityStore 接口的实现.
+type userIdentityStore struct {
+	store *datastore
+}
+
+// 确保 userIdentityStore 实现了 UserIdentityStore 接口.
+var _ UserIdentityStore = (*userIdentityStore)(nil)
+
+// newUserIdentityStore 创建 userIdentityStore 的实例.
+func newUserIdentityStore(store *datastore) *userIdentityStore {
+	return &userIdentityStore{
+		store: store,
+	}
+}
+
+// Create 插入一条外部身份关联记录.
+func (s *userIdentityStore) Create(ctx context.Context, obj *model.UserIdentityM) error {
+	if err := s.store.DB(ctx).Create(&obj).Error; err != nil {
+		log.Errorw("Failed to insert user identity into database", "err", err, "userID", obj.UserID, "provider", obj.Provider)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Delete 根据条件删除外部身份关联记录.
+func (s *userIdentityStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(new(model.UserIdentityM)).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorw("Failed to delete user identity from database", "err", err, "conditions", opts)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Get 根据条件查询外部身份关联记录.
+func (s *userIdentityStore) Get(ctx context.Context, opts *where.Options) (*model.UserIdentityM, error) {
+	var obj model.UserIdentityM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrIdentityNotFound
+		}
+		log.Errorw("Failed to retrieve user identity from database", "err", err, "conditions", opts)
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserQuotaStore 定义了 user quota 模块在 store 层所实现的方法.
+type UserQuotaStore interface {
+	Get(ctx context.Context, opts *where.Options) (*model.UserQuotaM, error)
+
+	UserQuotaExpansion
+}
+
+// UserQuotaExpansion 定义了配额用量的附加操作.
+type UserQuotaExpansion interface {
+	// Init 在用户的配额用量记录不存在时创建记录，已占用的存储空间根据用户现有的博客计算.
+	Init(ctx context.Context, userID string) error
+	// Consume 原子地为用户增加 day 当天创建的博客数 posts 和占用的存储空间 bytes.
+	// 增加后超过 maxPosts 或 maxStorage 时不做任何修改并返回 false，上限小于等于 0 表示不限制.
+	// posts 为 0 或 bytes 小于等于 0 时不校验对应的上限，占用的存储空间最小为 0.
+	Consume(ctx context.Context, userID string, day string, posts, bytes, maxPosts, maxStorage int64) (bool, error)
+}
+
+// userQuotaStore 是 UserQuotaStore 接口的实现.
+type userQuotaStore struct {
+	store *datastore
+}
+
+// 确保 userQuotaStore 实现了 UserQuotaStore 接口.
+var _ UserQuotaStore = (*userQuotaStore)(nil)
+
+// newUserQuotaStore 创建 userQuotaStore 的实例.
+func newUserQuotaStore(store *datastore) *userQuotaStore {
+	return &userQuotaStore{
+		store: store,
+	}
+}
+
+// Get 根据条件查询配额用量记录.
+func (s *userQuotaStore) Get(ctx context.Context, opts *where.Options) (*model.UserQuotaM, error) {
+	var obj model.UserQuotaM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrNotFound
+		}
+		log.Errorw("Failed to retrieve user quota from database", "err", err, "conditions", opts)
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	return &obj, nil
+}
+
+// Init 创建用户的配额用量记录，记录已存在时不做任何修改.
+func (s *userQuotaStore) Init(ctx context.Context, userID string) error {
+	var count int64
+	err := s.store.DB(ctx).Model(&model.UserQuotaM{}).Where("userID = ?", userID).Count(&count).Error
+	if err != nil {
+		log.Errorw("Failed to count user quota in database", "err", err, "userID", userID)
+		return errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+	if count > 0 {
+		return nil
+	}
+
+	// 开启配额之前创建的博客同样占用存储空间
+	var storage int64
+	err = s.store.DB(ctx).Model(&model.PostM{}).Where("userID = ?", userID).
+		Select("COALESCE(SUM(LENGTH(title) + LENGTH(content)), 0)").Scan(&storage).Error
+	if err != nil {
+		log.Errorw("Failed to sum post storage in database", "err", err, "userID", userID)
+		return errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	// 并发创建时以先创建的记录为准
+	obj := &model.UserQuotaM{UserID: userID, StorageBytes: storage}
+	if err := s.store.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(obj).Error; err != nil {
+		log.Errorw("Failed to insert user quota into database", "err", err, "userID", userID)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Consume 使用一条带条件的 UPDATE 语句检查并增加用量，保证并发请求不会超过配额.
+// postsToday 需要在 postsDay 之前赋值，因为 MySQL 按顺序执行赋值，后面的表达式会读取到前面赋值后的值.
+func (s *userQuotaStore) Consume(ctx context.Context, userID string, day string, posts, bytes, maxPosts, maxStorage int64) (bool, error) {
+	result := s.store.DB(ctx).Exec(`UPDATE user_quota SET
+		postsToday = CASE WHEN postsDay = @day THEN postsToday ELSE 0 END + @posts,
+		postsDay = @day,
+		storageBytes = CASE WHEN storageBytes + @bytes < 0 THEN 0 ELSE storageBytes + @bytes END
+	WHERE userID = @userID
+		AND (@posts = 0 OR @maxPosts <= 0 OR CASE WHEN postsDay = @day THEN postsToday ELSE 0 END + @posts <= @maxPosts)
+		AND (@bytes <= 0 OR @maxStorage <= 0 OR storageBytes + @bytes <= @maxStorage)`,
+		map[string]any{
+			"userID":     userID,
+			"day":        day,
+			"posts":      posts,
+			"bytes":      bytes,
+			"maxPosts":   maxPosts,
+			"maxStorage": maxStorage,
+		})
+	if result.Error != nil {
+		log.Errorw("Failed to update user quota in database", "err", result.Error, "userID", userID)
+		return false, errno.ErrDBWrite.WithMessage("%s", result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/pkg/store/where"
+)
+
+func newTestStore(t *testing.T) *datastore {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "miniblog.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.UserM{}, &model.PostM{}, &model.APIKeyM{}))
+	return &datastore{core: db}
+}
+
+func createTestUser(t *testing.T, s *datastore) *model.UserM {
+	t.Helper()
+
+	userM := &model.UserM{Username: "colin", Password: "miniblog1234", Nickname: "colin", Email: "colin@example.com", Phone: "18110000000"}
+	require.NoError(t, s.User().Create(context.Background(), userM))
+	return userM
+}
+
+func TestUserStoreUpdate(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	userID := createTestUser(t, s).UserID
+
+	first, err := s.User().Get(ctx, where.F("userID", userID))
+	require.NoError(t, err)
+	stale, err := s.User().Get(ctx, where.F("userID", userID))
+	require.NoError(t, err)
+
+	first.Nickname = "first"
+	require.NoError(t, s.User().Update(ctx, first))
+	assert.Equal(t, stale.Version+1, first.Version)
+
+	// 使用过期的版本号更新时返回 ErrPreconditionFailed，并且不修改数据库中的记录
+	stale.Nickname = "stale"
+	assert.ErrorIs(t, s.User().Update(ctx, stale), errno.ErrPreconditionFailed)
+	assert.Equal(t, first.Version-1, stale.Version)
+
+	got, err := s.User().Get(ctx, where.F("userID", userID))
+	require.NoError(t, err)
+	assert.Equal(t, "first", got.Nickname)
+	assert.Equal(t, first.Version, got.Version)
+}
+
+func TestUserStoreUpdatePassword(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	userM := createTestUser(t, s)
+
+	// 登录时读取的密码哈希
+	hashed := userM.Password
+
+	// 并发修改用户资料不影响重新加密密码，重新加密也不会使用户资料的版本号过期
+	profile, err := s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	profile.Nickname = "concurrent"
+	require.NoError(t, s.User().Update(ctx, profile))
+
+	require.NoError(t, s.User().UpdatePassword(ctx, userM.UserID, hashed, "rehashed"))
+	profile.Nickname = "still-current"
+	require.NoError(t, s.User().Update(ctx, profile))
+
+	// 密码已被修改时不会覆盖新密码
+	assert.ErrorIs(t, s.User().UpdatePassword(ctx, userM.UserID, "changed", "overwritten"), errno.ErrPreconditionFailed)
+	got, err := s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.NotEqual(t, "overwritten", got.Password)
+}
+
+func TestUserStoreMarkEmailVerified(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	userM := createTestUser(t, s)
+
+	require.NoError(t, s.User().MarkEmailVerified(ctx, userM.UserID, userM.Email))
+	got, err := s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.True(t, got.EmailVerified)
+	assert.Equal(t, userM.Version+1, got.Version)
+
+	// 已验证时直接返回
+	require.NoError(t, s.User().MarkEmailVerified(ctx, userM.UserID, userM.Email))
+
+	// 邮箱已被修改时不标记为已验证
+	assert.ErrorIs(t, s.User().MarkEmailVerified(ctx, userM.UserID, "old@example.com"), errno.ErrPreconditionFailed)
+}
+
+func TestPostStoreUpdate(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	postM := &model.PostM{UserID: "user-000001", Title: "title", Content: "content"}
+	require.NoError(t, s.Post().Create(ctx, postM))
+
+	first, err := s.Post().Get(ctx, where.F("postID", postM.PostID))
+	require.NoError(t, err)
+	stale, err := s.Post().Get(ctx, where.F("postID", postM.PostID))
+	require.NoError(t, err)
+
+	first.Title = "first"
+	require.NoError(t, s.Post().Update(ctx, first))
+
+	stale.Title = "stale"
+	assert.ErrorIs(t, s.Post().Update(ctx, stale), errno.ErrPreconditionFailed)
+
+	got, err := s.Post().Get(ctx, where.F("postID", postM.PostID))
+	require.NoError(t, err)
+	assert.Equal(t, "first", got.Title)
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/store/where"
+
+	"gorm.io/gorm"
+)
+
+// UserTOTPStore 定义了 user totp 模块在 store 层所实现的方法.
+type UserTOTPStore interface {
+	Create(ctx context.Context, obj *model.UserTOTPM) error
+	Update(ctx context.Context, obj *model.UserTOTPM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.UserTOTPM, error)
+
+	UserTOTPExpansion
+}
+
+// UserTOTPExpansion 定义了 TOTP 操作的附加方法.
+type UserTOTPExpansion interface {
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	UseRecoveryCodes(ctx context.Context, userID string, oldCodes, newCodes string) (bool, error)
+}
+
+// userTOTPStore 是 UserTOTPStore 接口的实现.
+type userTOTPStore struct {
+	store *datastore
+}
+
+// 确保 userTOTPStore 实现了 UserTOTPStore 接口.
+var _ UserTOTPStore = (*userTOTPStore)(nil)
+
+// newUserTOTPStore 创建 userTOTPStore 的实例.
+func newUserTOTPStore(store *datastore) *userTOTPStore {
+	return &userTOTPStore{
+		store: store,
+	}
+}
+
+// Create 插入一条 TOTP 记录.
+func (s *userTOTPStore) Create(ctx context.Context, obj *model.UserTOTPM) error {
+	if err := s.store.DB(ctx).Create(&obj).Error; err != nil {
+		log.Errorw("Failed to insert user totp into database", "err", err, "userID", obj.UserID)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Update 更新 TOTP 数据库记录.
+func (s *userTOTPStore) Update(ctx context.Context, obj *model.UserTOTPM) error {
+	if err := s.store.DB(ctx).Save(obj).Error; err != nil {
+		log.Errorw("Failed to update user totp in database", "err", err, "userID", obj.UserID)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Delete 根据条件删除 TOTP 记录.
+func (s *userTOTPStore) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(new(model.UserTOTPM)).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorw("Failed to delete user totp from database", "err", err, "conditions", opts)
+		return errno.ErrDBWrite.WithMessage("%s", err.Error())
+	}
+	return nil
+}
+
+// Get 根据条件查询 TOTP 记录.
+func (s *userTOTPStore) Get(ctx context.Context, opts *where.Options) (*model.UserTOTPM, error) {
+	var obj model.UserTOTPM
+	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrTOTPNotEnrolled
+		}
+		log.Errorw("Failed to retrieve user totp from database", "err", err, "conditions", opts)
+		return nil, errno.ErrDBRead.WithMessage("%s", err.Error())
+	}
+
+	return &obj, nil
+}
+
+// UseStep 将用户最后一次使用的时间步更新为 step.
+// 仅当 step 大于已使用的时间步时才会更新，并发请求使用同一个验证码时只有一个会返回 true.
+func (s *userTOTPStore) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.UserTOTPM{}).
+		Where("userID = ? AND lastUsedStep < ?", userID, step).
+		Update("lastUsedStep", step)
+	if result.Error != nil {
+		log.Errorw("Failed to update user totp step in database", "err", result.Error, "userID", userID)
+		return false, errno.ErrDBWrite.WithMessage("%s", result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UseRecoveryCodes 将用户的恢复码从 oldCodes 更新为 newCodes.
+// 仅当恢复码仍为 oldCodes 时才会更新，并发请求使用同一个恢复码时只有一个会返回 true.
+func (s *userTOTPStore) UseRecoveryCodes(ctx context.Context, userID string, oldCodes, newCodes string) (bool, error) {
+	result := s.store.DB(ctx).Model(&model.UserTOTPM{}).
+		Where("userID = ? AND recoveryCodes = ?", userID, oldCodes).
+		Update("recoveryCodes", newCodes)
+	if result.Error != nil {
+		log.Errorw("Failed to update user totp recovery codes in database", "err", result.Error, "userID", userID)
+		return false, errno.ErrDBWrite.WithMessage("%s", result.Error.Error())
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -10,6 +10,18 @@ type (
 	userNameKey struct{}
 	// userIDKey 定义用户 ID 的上下文键.
 	userIDKey struct{}
+	// apiKeyIDKey 定义 API Key ID 的上下文键.
+	apiKeyIDKey struct{}
+	// scopesKey 定义 API Key 权限范围的上下文键.
+	scopesKey struct{}
+	// clientIPKey 定义客户端 IP 的上下文键.
+	clientIPKey struct{}
+	// rolesKey 定义用户角色的上下文键.
+	rolesKey struct{}
+	// orgIDKey 定义组织 ID 的上下文键.
+	orgIDKey struct{}
+	// impersonatorKey 定义代理身份的真实操作者的上下文键.
+	impersonatorKey struct{}
 )
 
 // WithRequestID 将请求 ID 存放到上下文中.
@@ -43,3 +55,70 @@ func UserID(ctx context.Context) string {
 	userID, _ := ctx.Value(userIDKey{}).(string)
 	return userID
 }
+
+// WithAPIKey 将请求使用的 API Key ID 及其权限范围存放到上下文中.
+func WithAPIKey(ctx context.Context, keyID string, scopes []string) context.Context {
+	ctx = context.WithValue(ctx, apiKeyIDKey{}, keyID)
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// APIKeyID 从上下文中提取 API Key ID. 如果请求不是通过 API Key 认证，返回空字符串.
+func APIKeyID(ctx context.Context) string {
+	keyID, _ := ctx.Value(apiKeyIDKey{}).(string)
+	return keyID
+}
+
+// Scopes 从上下文中提取 API Key 的权限范围.
+func Scopes(ctx context.Context) []string {
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	return scopes
+}
+
+// WithRoles 将用户实际拥有的角色（包括通过角色继承获得的角色）存放到上下文中.
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// Roles 从上下文中提取用户实际拥有的角色.
+func Roles(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+// WithClientIP 将客户端 IP 存放到上下文中.
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, clientIP)
+}
+
+// ClientIP 从上下文中提取客户端 IP.
+func ClientIP(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPKey{}).(string)
+	return clientIP
+}
+
+// WithOrgID 将请求访问的组织 ID 存放到上下文中.
+func WithOrgID(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgIDKey{}, orgID)
+}
+
+// OrgID 从上下文中提取请求访问的组织 ID. 未指定组织时返回空字符串.
+func OrgID(ctx context.Context) string {
+	orgID, _ := ctx.Value(orgIDKey{}).(string)
+	return orgID
+}
+
+// WithImpersonator 将代理身份的真实操作者的用户 ID 存放到上下文中，此时 UserID 返回被代理的用户 ID.
+func WithImpersonator(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, impersonatorKey{}, userID)
+}
+
+// Impersonator 从上下文中提取代理身份的真实操作者的用户 ID. 请求不是代理身份发起时返回空字符串.
+func Impersonator(ctx context.Context) string {
+	userID, _ := ctx.Value(impersonatorKey{}).(string)
+	return userID
+}
+
+// IsImpersonated 判断请求是否由代理身份发起.
+func IsImpersonated(ctx context.Context) bool {
+	return Impersonator(ctx) != ""
+}
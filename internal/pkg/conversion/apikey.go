@@ -0,0 +1,31 @@
+package conversion
+
+import (
+	"miniblog/internal/apiserver/model"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// APIKeyModelToAPIKeyV1 将模型层的 APIKeyM 转换为 Protobuf 层的 APIKey.
+// 注意：返回结果中不包含 API Key 的哈希值.
+func APIKeyModelToAPIKeyV1(apiKeyModel *model.APIKeyM) *apiv1.APIKey {
+	apiKey := &apiv1.APIKey{
+		KeyID:     apiKeyModel.KeyID,
+		UserID:    apiKeyModel.UserID,
+		Name:      apiKeyModel.Name,
+		Prefix:    apiKeyModel.Prefix,
+		CreatedAt: timestamppb.New(apiKeyModel.CreatedAt),
+	}
+	if apiKeyModel.Scopes != "" {
+		apiKey.Scopes = strings.Split(apiKeyModel.Scopes, ",")
+	}
+	if apiKeyModel.ExpiresAt != nil {
+		apiKey.ExpiresAt = timestamppb.New(*apiKeyModel.ExpiresAt)
+	}
+	if apiKeyModel.LastUsedAt != nil {
+		apiKey.LastUsedAt = timestamppb.New(*apiKeyModel.LastUsedAt)
+	}
+	return apiKey
+}
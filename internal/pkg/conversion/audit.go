@@ -0,0 +1,31 @@
+package conversion
+
+import (
+	"miniblog/internal/apiserver/model"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditEventModelToAuditEventV1 将模型层的 AuditEventM 转换为 Protobuf 层的 AuditEvent.
+func AuditEventModelToAuditEventV1(eventModel *model.AuditEventM) *apiv1.AuditEvent {
+	var resourceIDs []string
+	if eventModel.ResourceIDs != "" {
+		resourceIDs = strings.Split(eventModel.ResourceIDs, ",")
+	}
+
+	return &apiv1.AuditEvent{
+		RequestID:      eventModel.RequestID,
+		UserID:         eventModel.UserID,
+		ImpersonatorID: eventModel.ImpersonatorID,
+		Method:         eventModel.Method,
+		ResourceIDs:    resourceIDs,
+		Code:           eventModel.Code,
+		Reason:         eventModel.Reason,
+		Request:        eventModel.Request,
+		ClientIP:       eventModel.ClientIP,
+		Latency:        eventModel.Latency,
+		CreatedAt:      timestamppb.New(eventModel.CreatedAt),
+	}
+}
@@ -0,0 +1,42 @@
+package conversion
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// VersionToETag 将模型层的版本号转换为 Protobuf 层的 etag.
+func VersionToETag(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// MatchETag 判断请求中的 etag 是否与资源当前的 etag 一致.
+// etag 可以是 If-Match、If-None-Match 请求头的格式：带引号或 W/ 前缀，多个值用逗号分隔，* 匹配任意版本.
+func MatchETag(etag string, current string) bool {
+	for _, tag := range strings.Split(etag, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		tag = strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+		if tag == current {
+			return true
+		}
+	}
+	return false
+}
+
+// NotModified 判断条件请求中的资源自调用方缓存后是否没有修改.
+// 传入 ifNoneMatch 时只比较 etag，否则比较资源的最后修改时间（精确到秒），lastModified 为零值时表示资源没有最后修改时间.
+func NotModified(ifNoneMatch string, ifModifiedSince *timestamppb.Timestamp, etag string, lastModified time.Time) bool {
+	if ifNoneMatch != "" {
+		return MatchETag(ifNoneMatch, etag)
+	}
+	if ifModifiedSince == nil || lastModified.IsZero() {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince.AsTime())
+}
@@ -0,0 +1,70 @@
+package conversion
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// UpdatePaths 返回更新请求要更新的字段.
+// 传入 updateMask 时返回其中的字段，否则返回请求中设置了值的 optional 字段，以兼容不传 updateMask 的请求.
+func UpdatePaths(rq proto.Message, mask *fieldmaskpb.FieldMask) []string {
+	if len(mask.GetPaths()) > 0 {
+		return mask.GetPaths()
+	}
+
+	var paths []string
+	rq.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.HasOptionalKeyword() {
+			paths = append(paths, string(fd.Name()))
+		}
+		return true
+	})
+	return paths
+}
+
+// updateMaskFields 是请求体中 updateMask 字段的 JSON 名称和 Protobuf 名称.
+var updateMaskFields = []string{"updateMask", "update_mask"}
+
+// ParseUpdateMask 从 JSON 请求体 fields 中取出并解析 updateMask，请求体中没有 updateMask 时返回 nil.
+func ParseUpdateMask(fields map[string]json.RawMessage) (*fieldmaskpb.FieldMask, error) {
+	for _, name := range updateMaskFields {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		delete(fields, name)
+
+		mask := new(fieldmaskpb.FieldMask)
+		if err := protojson.Unmarshal(raw, mask); err != nil {
+			return nil, err
+		}
+		return mask, nil
+	}
+	return nil, nil
+}
+
+// PatchUpdateMask 在 PATCH 请求的 JSON 请求体 fields 中没有 updateMask 时，返回请求体中出现的字段作为 updateMask，
+// 使 PATCH 请求只更新请求体中传入的字段；请求体中已经有 updateMask 时返回 nil.
+// etag 用于并发控制，pathParams 中的路径参数用于定位资源，都不是要更新的字段.
+func PatchUpdateMask(fields map[string]json.RawMessage, pathParams ...string) *fieldmaskpb.FieldMask {
+	for _, name := range updateMaskFields {
+		if _, ok := fields[name]; ok {
+			return nil
+		}
+	}
+
+	mask := &fieldmaskpb.FieldMask{Paths: []string{}}
+	for key := range fields {
+		if key != "etag" && !slices.Contains(pathParams, key) {
+			mask.Paths = append(mask.Paths, key)
+		}
+	}
+	sort.Strings(mask.Paths)
+	return mask
+}
@@ -0,0 +1,58 @@
+package conversion
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func TestUpdatePaths(t *testing.T) {
+	rq := &apiv1.UpdatePostRequest{PostID: "post-000001", Title: proto.String("title"), Etag: "1"}
+
+	// 没有 updateMask 时只更新设置了值的 optional 字段
+	assert.Equal(t, []string{"title"}, UpdatePaths(rq, nil))
+	assert.Equal(t, []string{"title"}, UpdatePaths(rq, &fieldmaskpb.FieldMask{}))
+
+	// updateMask 中没有设置值的字段同样会被更新，用于清空字段
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"title", "content"}}
+	assert.Equal(t, []string{"title", "content"}, UpdatePaths(rq, mask))
+}
+
+func TestParseUpdateMask(t *testing.T) {
+	for _, name := range []string{"updateMask", "update_mask"} {
+		fields := map[string]json.RawMessage{name: json.RawMessage(`"title,content"`), "title": json.RawMessage(`"t"`)}
+		mask, err := ParseUpdateMask(fields)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"title", "content"}, mask.GetPaths(), name)
+		assert.NotContains(t, fields, name)
+	}
+
+	mask, err := ParseUpdateMask(map[string]json.RawMessage{"title": json.RawMessage(`"t"`)})
+	require.NoError(t, err)
+	assert.Nil(t, mask)
+
+	_, err = ParseUpdateMask(map[string]json.RawMessage{"updateMask": json.RawMessage(`1`)})
+	assert.Error(t, err)
+}
+
+func TestPatchUpdateMask(t *testing.T) {
+	fields := map[string]json.RawMessage{
+		"postID":  json.RawMessage(`"post-000001"`),
+		"title":   json.RawMessage(`"t"`),
+		"content": json.RawMessage(`""`),
+		"etag":    json.RawMessage(`"1"`),
+	}
+	// etag 和路径参数不是要更新的字段
+	assert.Equal(t, []string{"content", "title"}, PatchUpdateMask(fields, "postID").GetPaths())
+
+	// 请求体中已经有 updateMask 时不覆盖
+	for _, name := range []string{"updateMask", "update_mask"} {
+		assert.Nil(t, PatchUpdateMask(map[string]json.RawMessage{name: json.RawMessage(`"title"`)}), name)
+	}
+}
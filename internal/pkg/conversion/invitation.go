@@ -0,0 +1,20 @@
+package conversion
+
+import (
+	"miniblog/internal/apiserver/model"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// InvitationModelToInvitationV1 将模型层的 InvitationM 转换为 Protobuf 层的 Invitation.
+func InvitationModelToInvitationV1(invitationModel *model.InvitationM) *apiv1.Invitation {
+	return &apiv1.Invitation{
+		Code:      invitationModel.Code,
+		CreatedBy: invitationModel.CreatedBy,
+		MaxUses:   invitationModel.MaxUses,
+		UsedCount: invitationModel.UsedCount,
+		ExpiresAt: timestamppb.New(invitationModel.ExpiresAt),
+		CreatedAt: timestamppb.New(invitationModel.CreatedAt),
+	}
+}
@@ -0,0 +1,14 @@
+package conversion
+
+import (
+	"miniblog/internal/apiserver/model"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/core"
+)
+
+// OrganizationModelToOrganizationV1 将模型层的 OrganizationM 转换为 Protobuf 层的 Organization.
+func OrganizationModelToOrganizationV1(orgModel *model.OrganizationM) *apiv1.Organization {
+	var protoOrg apiv1.Organization
+	_ = core.CopyWithConverters(&protoOrg, orgModel)
+	return &protoOrg
+}
@@ -0,0 +1,45 @@
+package conversion
+
+import (
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authz"
+)
+
+// PolicyRuleToPolicyV1 将 Casbin 的 p 规则转换为 Protobuf 层的 Policy.
+func PolicyRuleToPolicyV1(rule []string) *apiv1.Policy {
+	rule = append(rule, make([]string, 4)...)
+	return &apiv1.Policy{
+		Subject: rule[0],
+		Object:  rule[1],
+		Action:  rule[2],
+		Effect:  rule[3],
+	}
+}
+
+// GroupingRuleToRoleAssignmentV1 将 Casbin 的 g 规则转换为 Protobuf 层的 RoleAssignment.
+func GroupingRuleToRoleAssignmentV1(rule []string) *apiv1.RoleAssignment {
+	rule = append(rule, make([]string, 2)...)
+	return &apiv1.RoleAssignment{
+		Subject: rule[0],
+		Role:    rule[1],
+	}
+}
+
+// ExplanationToExplainAuthorizationResponseV1 将授权判断的解释转换为 Protobuf 层的 ExplainAuthorizationResponse.
+func ExplanationToExplainAuthorizationResponseV1(explanation *authz.Explanation) *apiv1.ExplainAuthorizationResponse {
+	resp := &apiv1.ExplainAuthorizationResponse{
+		Allowed:         explanation.Allowed,
+		EffectiveRoles:  explanation.Roles,
+		MatchedPolicies: make([]*apiv1.MatchedPolicy, 0, len(explanation.Matches)),
+	}
+	for _, match := range explanation.Matches {
+		resp.MatchedPolicies = append(resp.MatchedPolicies, &apiv1.MatchedPolicy{
+			Policy:    PolicyRuleToPolicyV1(match.Policy),
+			RoleChain: match.RoleChain,
+		})
+	}
+	if len(explanation.DecidingPolicy) > 0 {
+		resp.DecidingPolicy = PolicyRuleToPolicyV1(explanation.DecidingPolicy)
+	}
+	return resp
+}
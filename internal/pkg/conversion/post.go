@@ -10,6 +10,7 @@ import (
 func PostModelToPostV1(postModel *model.PostM) *apiv1.Post {
 	var protoBuf apiv1.Post
 	_ = core.CopyWithConverters(&protoBuf, postModel)
+	protoBuf.Etag = VersionToETag(postModel.Version)
 	return &protoBuf
 }
 
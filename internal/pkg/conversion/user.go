@@ -10,6 +10,7 @@ import (
 func UserModelToUserV1(userModel *model.UserM) *apiv1.User {
 	var protoBuf apiv1.User
 	_ = core.CopyWithConverters(&protoBuf, userModel)
+	protoBuf.Etag = VersionToETag(userModel.Version)
 	return &protoBuf
 }
 
@@ -0,0 +1,18 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrAPIKeyNotFound 表示未找到指定的 API Key.
+	ErrAPIKeyNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.APIKeyNotFound", Message: "API key not found."}
+
+	// ErrAPIKeyInvalid 表示 API Key 无效或已过期.
+	ErrAPIKeyInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.APIKeyInvalid", Message: "API key was invalid or expired."}
+
+	// ErrAPIKeyScopeDenied 表示 API Key 的权限范围不允许访问该资源.
+	ErrAPIKeyScopeDenied = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.APIKeyScopeDenied", Message: "API key scope does not allow this operation."}
+)
@@ -7,6 +7,7 @@ package errno
 import (
 	"net/http"
 
+	"miniblog/internal/pkg/known"
 	"miniblog/pkg/errorsx"
 )
 
@@ -44,6 +45,12 @@ var (
 	// ErrTokenInvalid 表示 JWT Token 格式无效.
 	ErrTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenInvalid", Message: "Token was invalid."}
 
+	// ErrRateLimitExceeded 表示请求过于频繁，触发了限流.
+	ErrRateLimitExceeded = &errorsx.ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.RateLimitExceeded", Message: "Too many requests, please try again later."}
+
+	// ErrPreconditionFailed 表示资源已被修改，请求携带的 etag 与当前版本不一致.
+	ErrPreconditionFailed = &errorsx.ErrorX{Code: http.StatusPreconditionFailed, Reason: "FailedPrecondition.PreconditionFailed", Message: "The resource has been modified, please reload it and try again."}
+
 	// ErrDBRead 表示数据库读取失败.
 	ErrDBRead = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBRead", Message: "Database read failure."}
 
@@ -56,3 +63,9 @@ var (
 	// ErrRemoveRole 表示在删除角色时发生错误.
 	ErrRemoveRole = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.RemoveRole", Message: "Error occurred while removing the role."}
 )
+
+// RateLimitExceeded 返回一个新的 ErrRateLimitExceeded 错误，并在元数据中携带需要等待的秒数.
+// 每次返回新的实例，避免并发请求修改共享的错误变量.
+func RateLimitExceeded(retryAfter string) *errorsx.ErrorX {
+	return errorsx.New(ErrRateLimitExceeded.Code, ErrRateLimitExceeded.Reason, "%s", ErrRateLimitExceeded.Message).KV(known.RetryAfter, retryAfter)
+}
@@ -0,0 +1,18 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrIdempotencyKeyReused 表示幂等键已被请求内容不同的请求使用.
+	ErrIdempotencyKeyReused = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.IdempotencyKeyReused", Message: "Idempotency key was already used with a different request."}
+
+	// ErrIdempotencyKeyInProgress 表示使用同一个幂等键的请求仍在处理中.
+	ErrIdempotencyKeyInProgress = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.IdempotencyKeyInProgress", Message: "A request with the same idempotency key is still in progress, please try again later."}
+
+	// ErrIdempotencyKeyInvalid 表示幂等键格式不合法.
+	ErrIdempotencyKeyInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.IdempotencyKeyInvalid", Message: "Idempotency key must be at most 255 characters long."}
+)
@@ -0,0 +1,27 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrAuthProviderNotFound 表示认证方式不存在或未启用.
+	ErrAuthProviderNotFound = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.AuthProviderNotFound", Message: "Authentication provider is not found or not enabled."}
+
+	// ErrAuthProviderUnavailable 表示外部认证服务不可用.
+	ErrAuthProviderUnavailable = &errorsx.ErrorX{Code: http.StatusServiceUnavailable, Reason: "Unavailable.AuthProviderUnavailable", Message: "Authentication provider is unavailable."}
+
+	// ErrIdentityNotFound 表示外部身份尚未关联到任何用户.
+	ErrIdentityNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.IdentityNotFound", Message: "External identity is not linked."}
+
+	// ErrIdentityAlreadyLinked 表示外部身份已经关联到其他用户，或者当前用户已经关联了该认证方式的身份.
+	ErrIdentityAlreadyLinked = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "AlreadyExist.IdentityAlreadyLinked", Message: "External identity is already linked."}
+
+	// ErrProvisioningDisabled 表示外部身份没有关联的用户，且未开启自动创建用户.
+	ErrProvisioningDisabled = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.ProvisioningDisabled", Message: "No miniblog account is linked to this identity."}
+
+	// ErrOIDCStateInvalid 表示 OIDC 回调中的 state 无效或已过期.
+	ErrOIDCStateInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.OIDCStateInvalid", Message: "OIDC state is invalid or expired."}
+)
@@ -0,0 +1,18 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrRegistrationClosed 表示当前实例关闭了注册.
+	ErrRegistrationClosed = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.RegistrationClosed", Message: "Registration is closed."}
+
+	// ErrInvitationRequired 表示当前实例只允许通过邀请码注册.
+	ErrInvitationRequired = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.InvitationRequired", Message: "An invitation code is required to register."}
+
+	// ErrInvitationInvalid 表示邀请码不存在、已过期或已用完.
+	ErrInvitationInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.InvitationInvalid", Message: "Invitation code is invalid, expired or used up."}
+)
@@ -0,0 +1,24 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrTOTPNotEnrolled 表示用户尚未绑定 TOTP 两步验证.
+	ErrTOTPNotEnrolled = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.TOTPNotEnrolled", Message: "TOTP two-factor authentication is not enrolled."}
+
+	// ErrTOTPAlreadyEnabled 表示用户已经开启了 TOTP 两步验证.
+	ErrTOTPAlreadyEnabled = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "AlreadyExist.TOTPAlreadyEnabled", Message: "TOTP two-factor authentication is already enabled."}
+
+	// ErrTOTPNotEnabled 表示用户尚未开启 TOTP 两步验证.
+	ErrTOTPNotEnabled = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "FailedPrecondition.TOTPNotEnabled", Message: "TOTP two-factor authentication is not enabled."}
+
+	// ErrTOTPCodeInvalid 表示两步验证码或恢复码错误.
+	ErrTOTPCodeInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TOTPCodeInvalid", Message: "Two-factor authentication code is invalid."}
+
+	// ErrChallengeTokenInvalid 表示两步验证挑战令牌无效或已过期.
+	ErrChallengeTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.ChallengeTokenInvalid", Message: "Challenge token was invalid or expired."}
+)
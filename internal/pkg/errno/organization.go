@@ -0,0 +1,18 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrOrganizationNotFound 表示未找到指定组织.
+	ErrOrganizationNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.OrganizationNotFound", Message: "Organization not found."}
+
+	// ErrNotOrganizationMember 表示请求用户不是所访问组织的成员.
+	ErrNotOrganizationMember = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.NotOrganizationMember", Message: "You are not a member of this organization."}
+
+	// ErrOrganizationMemberNotFound 表示用户不是组织成员.
+	ErrOrganizationMemberNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.OrganizationMemberNotFound", Message: "Organization member not found."}
+)
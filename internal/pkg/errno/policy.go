@@ -0,0 +1,24 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrPolicyAlreadyExists 表示授权策略已存在.
+	ErrPolicyAlreadyExists = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "AlreadyExists.PolicyAlreadyExists", Message: "Policy already exists."}
+
+	// ErrPolicyNotFound 表示授权策略不存在.
+	ErrPolicyNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PolicyNotFound", Message: "Policy not found."}
+
+	// ErrRoleAssignmentAlreadyExists 表示角色分配已存在.
+	ErrRoleAssignmentAlreadyExists = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "AlreadyExists.RoleAssignmentAlreadyExists", Message: "Role assignment already exists."}
+
+	// ErrRoleAssignmentNotFound 表示角色分配不存在.
+	ErrRoleAssignmentNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.RoleAssignmentNotFound", Message: "Role assignment not found."}
+
+	// ErrRemoveOwnAdminRole 表示管理员不能取消自己的管理员角色，避免系统中没有可用的管理员.
+	ErrRemoveOwnAdminRole = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.RemoveOwnAdminRole", Message: "Administrators cannot remove their own admin role."}
+)
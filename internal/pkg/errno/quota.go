@@ -0,0 +1,31 @@
+package errno
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrDailyPostQuotaExceeded 表示当天创建的博客数已达到配额上限.
+	ErrDailyPostQuotaExceeded = &errorsx.ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.DailyPostQuotaExceeded", Message: "Daily post quota exceeded."}
+
+	// ErrStorageQuotaExceeded 表示博客占用的存储空间已达到配额上限.
+	ErrStorageQuotaExceeded = &errorsx.ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.StorageQuotaExceeded", Message: "Storage quota exceeded."}
+)
+
+// DailyPostQuotaExceeded 返回一个新的 ErrDailyPostQuotaExceeded 错误，并在元数据中携带配额上限和重置时间.
+func DailyPostQuotaExceeded(limit int64, resetAt time.Time) *errorsx.ErrorX {
+	return errorsx.New(ErrDailyPostQuotaExceeded.Code, ErrDailyPostQuotaExceeded.Reason,
+		"Daily post quota of %d exceeded, the quota resets at %s.", limit, resetAt.Format(time.RFC3339)).
+		KV("quota", "daily-posts", "limit", strconv.FormatInt(limit, 10), "resetAt", resetAt.Format(time.RFC3339))
+}
+
+// StorageQuotaExceeded 返回一个新的 ErrStorageQuotaExceeded 错误，并在元数据中携带配额上限. 存储配额不会自动重置.
+func StorageQuotaExceeded(limit int64) *errorsx.ErrorX {
+	return errorsx.New(ErrStorageQuotaExceeded.Code, ErrStorageQuotaExceeded.Reason,
+		"Storage quota of %d bytes exceeded, delete some posts to free up space.", limit).
+		KV("quota", "storage", "limit", strconv.FormatInt(limit, 10))
+}
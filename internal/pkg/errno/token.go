@@ -0,0 +1,15 @@
+package errno
+
+import (
+	"net/http"
+
+	"miniblog/pkg/errorsx"
+)
+
+var (
+	// ErrOneTimeTokenInvalid 表示一次性令牌不存在、已过期或已被使用.
+	ErrOneTimeTokenInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.OneTimeTokenInvalid", Message: "Token is invalid, expired or already used."}
+
+	// ErrSessionRevoked 表示访问令牌签发后用户的会话已被吊销，例如重置了密码，需要重新登录.
+	ErrSessionRevoked = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.SessionRevoked", Message: "Session has been revoked, please log in again."}
+)
@@ -23,6 +23,24 @@ var (
 	// ErrUserAlreadyExists 表示用户已存在.
 	ErrUserAlreadyExists = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "AlreadyExist.UserAlreadyExists", Message: "User already exists."}
 
+	// ErrLoginFailed 表示用户名或密码错误. 登录时不区分用户不存在和密码错误，避免泄露用户是否存在.
+	ErrLoginFailed = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.LoginFailed", Message: "Invalid username or password."}
+
+	// ErrTooManyLoginAttempts 表示登录失败次数过多，需要等待后重试.
+	ErrTooManyLoginAttempts = &errorsx.ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.TooManyLoginAttempts", Message: "Too many failed login attempts, please try again later."}
+
 	// ErrUserNotFound 表示未找到指定用户.
 	ErrUserNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.UserNotFound", Message: "User not found."}
+
+	// ErrEmailNotVerified 表示用户的电子邮箱尚未验证，不允许执行当前操作.
+	ErrEmailNotVerified = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.EmailNotVerified", Message: "Email address is not verified."}
+
+	// ErrVerificationTokenInvalid 表示邮箱验证令牌无效、已过期，或者用户已修改了邮箱.
+	ErrVerificationTokenInvalid = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.VerificationTokenInvalid", Message: "Verification token is invalid or expired."}
+
+	// ErrImpersonationForbidden 表示代理身份不能执行当前操作，例如修改密码和删除账号.
+	ErrImpersonationForbidden = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.ImpersonationForbidden", Message: "This operation is not allowed while impersonating another user."}
+
+	// ErrTooManyCodeRequests 表示申请登录链接或验证码过于频繁，需要等待后重试.
+	ErrTooManyCodeRequests = &errorsx.ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.TooManyCodeRequests", Message: "Too many login code requests, please try again later."}
 )
@@ -1,5 +1,7 @@
 package known
 
+import "time"
+
 // 定义 HTTP/gRPC Header.
 // gRPC 底层使用了 HTTP/2 作为传输协议，而 HTTP/2 的规范
 // 规定 Header 的键必须是小写的。因此，在 gRPC 中，所有的 Header 键都会被强制转换为小写，
@@ -15,15 +17,116 @@ const (
 	XUserID = "x-user-id"
 	// XUsername 用来定义上下文的键，代表请求用户名.
 	XUsername = "x-username"
+	// XOrganizationID 用来定义请求头中的键，代表请求访问的组织 ID.
+	XOrganizationID = "x-organization-id"
+	// XImpersonatorID 用来定义日志中的键，代表代理身份的真实操作者的用户 ID.
+	XImpersonatorID = "x-impersonator-id"
+	// RetryAfter 用来定义响应头中的键，代表被限流的请求需要等待的秒数. 这是标准 Header，因此没有 x- 前缀.
+	RetryAfter = "retry-after"
+	// IdempotencyKey 用来定义请求头中的键，代表非幂等请求的幂等键，重试时使用相同的幂等键不会重复执行请求.
+	IdempotencyKey = "idempotency-key"
+	// IdempotentReplayed 用来定义响应头中的键，值为 true 时表示响应是同一个幂等键第一次请求的响应.
+	IdempotentReplayed = "idempotent-replayed"
+	// IfMatch 用来定义请求头中的键，值为资源的 etag，仅当资源当前版本与之一致时才执行更新. 这是标准 Header，因此没有 x- 前缀.
+	IfMatch = "if-match"
+	// IfNoneMatch 用来定义请求头中的键，值为调用方缓存的 etag，与资源当前的 etag 一致时不返回资源. 这是标准 Header，因此没有 x- 前缀.
+	IfNoneMatch = "if-none-match"
+	// IfModifiedSince 用来定义请求头中的键，值为调用方缓存的时间，资源在此之后没有修改时不返回资源. 这是标准 Header，因此没有 x- 前缀.
+	IfModifiedSince = "if-modified-since"
+	// ETag 用来定义响应头中的键，值为资源当前的 etag. 这是标准 Header，因此没有 x- 前缀.
+	ETag = "etag"
+	// LastModified 用来定义响应头中的键，值为资源的最后修改时间. 这是标准 Header，因此没有 x- 前缀.
+	LastModified = "last-modified"
 )
 
-// 定义其它常量
+// 定义 where.RegisterTenant 注册的租户维度，值为数据表中对应的列名.
 const (
-	// Admin 用户名.
-	AdminUsername = "root"
+	// TenantUser 按用户隔离数据，值取自请求用户的 ID.
+	TenantUser = "userID"
+	// TenantOrganization 按组织隔离数据，值取自请求访问的组织 ID，未指定组织时为空.
+	TenantOrganization = "orgID"
+)
 
+// 定义其它常量
+const (
 	// MaxErrGroupConcurrency 定义了 errgroup 的最大并发任务数量.
 	// 用于限制 errgroup 中同时执行的 Goroutine 数量，从而防止资源耗尽，提升程序的稳定性.
 	// 根据场景需求，可以调整该值大小.
 	MaxErrGroupConcurrency = 1000
+
+	// MaxIdempotencyKeyLength 是幂等键的最大长度.
+	MaxIdempotencyKeyLength = 255
+
+	// TOTPIssuer 是 TOTP 两步验证在身份验证器应用中显示的发行方名称.
+	TOTPIssuer = "miniblog"
+
+	// TOTPSkew 是校验 TOTP 验证码时允许前后偏移的时间步数量，用于容忍客户端时钟误差.
+	TOTPSkew = 1
+
+	// RecoveryCodeCount 是开启两步验证时生成的一次性恢复码数量.
+	RecoveryCodeCount = 10
+
+	// PurposeMFAChallenge 是两步验证挑战令牌的用途.
+	PurposeMFAChallenge = "mfa-challenge"
+
+	// MFAChallengeExpiration 是两步验证挑战令牌的有效期.
+	MFAChallengeExpiration = 5 * time.Minute
+
+	// PurposeOIDCState 是 OIDC 登录 state 令牌的用途.
+	PurposeOIDCState = "oidc-state"
+
+	// OIDCStateExpiration 是 OIDC 登录 state 令牌的有效期.
+	OIDCStateExpiration = 10 * time.Minute
+
+	// PurposePasswordReset 是重置密码一次性令牌的用途.
+	PurposePasswordReset = "password-reset"
+
+	// PasswordResetExpiration 是重置密码一次性令牌的有效期.
+	PasswordResetExpiration = 30 * time.Minute
+
+	// PurposeEmailVerification 是邮箱验证令牌的用途.
+	PurposeEmailVerification = "email-verification"
+
+	// EmailVerificationExpiration 是邮箱验证令牌的有效期.
+	EmailVerificationExpiration = 24 * time.Hour
+
+	// PurposeMagicLink 是邮件登录链接一次性令牌的用途.
+	PurposeMagicLink = "magic-link"
+
+	// MagicLinkExpiration 是邮件登录链接的有效期.
+	MagicLinkExpiration = 15 * time.Minute
+
+	// PurposeLoginCode 是短信登录验证码的用途.
+	PurposeLoginCode = "login-code"
+
+	// LoginCodeExpiration 是短信登录验证码的有效期.
+	LoginCodeExpiration = 5 * time.Minute
+
+	// LoginCodeLength 是短信登录验证码的位数.
+	LoginCodeLength = 6
+
+	// SMSSendTimeout 是异步发送短信的超时时间.
+	SMSSendTimeout = 30 * time.Second
+
+	// APIKeyLastUsedInterval 是更新 API Key 最后使用时间的最小间隔，避免每个请求都写数据库.
+	APIKeyLastUsedInterval = time.Minute
+
+	// ImpersonationExpiration 是代理身份 token 的有效期.
+	ImpersonationExpiration = 15 * time.Minute
+
+	// InvitationExpiration 是创建邀请码时未指定过期时间时使用的默认有效期.
+	InvitationExpiration = 7 * 24 * time.Hour
+
+	// MailSendTimeout 是异步发送邮件的超时时间.
+	MailSendTimeout = 30 * time.Second
+)
+
+// 定义注册模式.
+const (
+	// RegistrationModeOpen 表示任何人都可以注册.
+	RegistrationModeOpen = "open"
+	// RegistrationModeInviteOnly 表示只能通过邀请码注册.
+	RegistrationModeInviteOnly = "invite-only"
+	// RegistrationModeClosed 表示关闭注册，只有管理员可以创建用户.
+	RegistrationModeClosed = "closed"
 )
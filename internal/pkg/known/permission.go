@@ -0,0 +1,163 @@
+package known
+
+import "strings"
+
+// 定义授权使用的逻辑权限，格式为 "<资源>:<动作>".
+// 同一个操作在 gRPC、gRPC-Gateway 和 Gin 模式下使用相同的权限，casbin 策略中的 obj 对应资源，act 对应动作，
+// 例如 p, role::user, user, delete, deny.
+const (
+	PermissionUserCreate         = "user:create"
+	PermissionUserUpdate         = "user:update"
+	PermissionUserDelete         = "user:delete"
+	PermissionUserGet            = "user:get"
+	PermissionUserList           = "user:list"
+	PermissionUserChangePassword = "user:change-password"
+	PermissionUserUnlock         = "user:unlock"
+	PermissionUserGetRoles       = "user:get-roles"
+	PermissionUserImpersonate    = "user:impersonate"
+
+	PermissionTokenRefresh = "token:refresh"
+
+	PermissionPostCreate = "post:create"
+	PermissionPostUpdate = "post:update"
+	PermissionPostDelete = "post:delete"
+	PermissionPostGet    = "post:get"
+	PermissionPostList   = "post:list"
+
+	PermissionAPIKeyCreate = "apikey:create"
+	PermissionAPIKeyDelete = "apikey:delete"
+	PermissionAPIKeyList   = "apikey:list"
+
+	PermissionIdentityLink   = "identity:link"
+	PermissionIdentityUnlink = "identity:unlink"
+
+	PermissionMFAEnroll  = "mfa:enroll"
+	PermissionMFAVerify  = "mfa:verify"
+	PermissionMFADisable = "mfa:disable"
+
+	PermissionInvitationCreate = "invitation:create"
+	PermissionInvitationDelete = "invitation:delete"
+	PermissionInvitationList   = "invitation:list"
+
+	PermissionPolicyList    = "policy:list"
+	PermissionPolicyAdd     = "policy:add"
+	PermissionPolicyRemove  = "policy:remove"
+	PermissionPolicyExplain = "policy:explain"
+
+	PermissionRoleAssignmentList   = "role-assignment:list"
+	PermissionRoleAssignmentAdd    = "role-assignment:add"
+	PermissionRoleAssignmentRemove = "role-assignment:remove"
+
+	PermissionOrganizationCreate = "organization:create"
+	PermissionOrganizationGet    = "organization:get"
+	PermissionOrganizationList   = "organization:list"
+	PermissionOrganizationDelete = "organization:delete"
+
+	PermissionOrganizationMemberAdd    = "organization-member:add"
+	PermissionOrganizationMemberRemove = "organization-member:remove"
+	PermissionOrganizationMemberList   = "organization-member:list"
+
+	PermissionAuditEventList = "audit-event:list"
+
+	PermissionQuotaGet = "quota:get"
+)
+
+// 定义业务层判断使用的管理员能力. 能力没有对应的接口，必须通过 allow 策略显式授予，参见 authz.Authz.Can.
+const (
+	// PermissionUserListAll 表示查询用户列表时可以看到所有用户，而不仅是自己.
+	PermissionUserListAll = "user:list-all"
+)
+
+// AvailablePermissions 包含所有合法的逻辑权限.
+var AvailablePermissions = []string{
+	PermissionUserCreate,
+	PermissionUserUpdate,
+	PermissionUserDelete,
+	PermissionUserGet,
+	PermissionUserList,
+	PermissionUserChangePassword,
+	PermissionUserUnlock,
+	PermissionUserGetRoles,
+	PermissionUserImpersonate,
+	PermissionTokenRefresh,
+	PermissionPostCreate,
+	PermissionPostUpdate,
+	PermissionPostDelete,
+	PermissionPostGet,
+	PermissionPostList,
+	PermissionAPIKeyCreate,
+	PermissionAPIKeyDelete,
+	PermissionAPIKeyList,
+	PermissionIdentityLink,
+	PermissionIdentityUnlink,
+	PermissionMFAEnroll,
+	PermissionMFAVerify,
+	PermissionMFADisable,
+	PermissionInvitationCreate,
+	PermissionInvitationDelete,
+	PermissionInvitationList,
+	PermissionPolicyList,
+	PermissionPolicyAdd,
+	PermissionPolicyRemove,
+	PermissionPolicyExplain,
+	PermissionRoleAssignmentList,
+	PermissionRoleAssignmentAdd,
+	PermissionRoleAssignmentRemove,
+	PermissionOrganizationCreate,
+	PermissionOrganizationGet,
+	PermissionOrganizationList,
+	PermissionOrganizationDelete,
+	PermissionOrganizationMemberAdd,
+	PermissionOrganizationMemberRemove,
+	PermissionOrganizationMemberList,
+	PermissionAuditEventList,
+	PermissionQuotaGet,
+	PermissionUserListAll,
+}
+
+// permissionScopes 定义通过 API Key 使用各权限时所需的权限范围.
+// 未在此声明的权限不允许通过限定了权限范围的 API Key 使用.
+var permissionScopes = map[string]string{
+	PermissionUserGet:    ScopeUsersRead,
+	PermissionUserList:   ScopeUsersRead,
+	PermissionUserUpdate: ScopeUsersWrite,
+	PermissionPostCreate: ScopePostsWrite,
+	PermissionPostUpdate: ScopePostsWrite,
+	PermissionPostDelete: ScopePostsWrite,
+	PermissionPostGet:    ScopePostsRead,
+	PermissionPostList:   ScopePostsRead,
+	PermissionQuotaGet:   ScopePostsRead,
+}
+
+// PermissionScope 返回通过 API Key 使用 permission 时所需的权限范围，未声明时返回空字符串.
+func PermissionScope(permission string) string {
+	return permissionScopes[permission]
+}
+
+// impersonationForbidden 定义代理身份不能执行的敏感操作：修改凭证和账号信息（修改邮箱、手机号后可以重置密码）、删除账号，
+// 以及延长或扩散代理身份的操作.
+var impersonationForbidden = map[string]struct{}{
+	PermissionUserUpdate:         {},
+	PermissionUserChangePassword: {},
+	PermissionUserDelete:         {},
+	PermissionUserImpersonate:    {},
+	PermissionTokenRefresh:       {},
+	PermissionAPIKeyCreate:       {},
+	PermissionIdentityLink:       {},
+	PermissionIdentityUnlink:     {},
+	PermissionMFAEnroll:          {},
+	PermissionMFAVerify:          {},
+	PermissionMFADisable:         {},
+}
+
+// ImpersonationAllowed 判断代理身份能否使用 permission.
+func ImpersonationAllowed(permission string) bool {
+	_, forbidden := impersonationForbidden[permission]
+	return !forbidden
+}
+
+// SplitPermission 将权限拆分为 casbin 策略中的资源（obj）和动作（act）.
+func SplitPermission(permission string) (resource, action string) {
+	resource, action, _ = strings.Cut(permission, ":")
+	return resource, action
+}
@@ -6,3 +6,24 @@ const (
 	// Role for administrators.
 	RoleAdmin = "role::admin"
 )
+
+// 定义组织中的角色，通过 casbin 的 g2 规则按组织分配，例如 g2, user-000001, role::org-admin, org-000001.
+const (
+	// RoleOrgAdmin 是组织管理员，可以管理组织成员以及组织内的所有博客.
+	RoleOrgAdmin = "role::org-admin"
+	// RoleOrgMember 是组织成员，可以在组织内发布博客.
+	RoleOrgMember = "role::org-member"
+)
+
+// OrganizationRoles 包含所有合法的组织角色.
+var OrganizationRoles = []string{RoleOrgAdmin, RoleOrgMember}
+
+const (
+	// RolePrefix 是角色名称的前缀，用于区分角色和用户 ID.
+	RolePrefix = "role::"
+
+	// PolicyEffectAllow 表示授权策略允许访问.
+	PolicyEffectAllow = "allow"
+	// PolicyEffectDeny 表示授权策略拒绝访问.
+	PolicyEffectDeny = "deny"
+)
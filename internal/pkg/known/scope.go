@@ -0,0 +1,32 @@
+package known
+
+import "slices"
+
+// 定义 API Key 支持的权限范围.
+const (
+	// ScopePostsRead 允许读取博客.
+	ScopePostsRead = "posts:read"
+	// ScopePostsWrite 允许创建、修改和删除博客.
+	ScopePostsWrite = "posts:write"
+	// ScopeUsersRead 允许读取用户信息.
+	ScopeUsersRead = "users:read"
+	// ScopeUsersWrite 允许修改用户信息.
+	ScopeUsersWrite = "users:write"
+)
+
+// AvailableScopes 包含所有合法的 API Key 权限范围.
+var AvailableScopes = []string{
+	ScopePostsRead,
+	ScopePostsWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+}
+
+// HasScope 判断 granted 中是否包含 required 权限范围.
+// 当 granted 为空时，表示 API Key 没有限制权限范围.
+func HasScope(granted []string, required string) bool {
+	if len(granted) == 0 {
+		return true
+	}
+	return required != "" && slices.Contains(granted, required)
+}
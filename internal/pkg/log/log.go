@@ -191,7 +191,8 @@ func (l *zapLogger) W(ctx context.Context) Logger {
 
 	// 定义一个映射，关联 context 提取函数和日志字段名
 	contextExtractors := map[string]func(context.Context) string{
-		known.XRequestID: contextx.RequestID, // 提取请求 ID
+		known.XRequestID:      contextx.RequestID,    // 提取请求 ID
+		known.XImpersonatorID: contextx.Impersonator, // 提取代理身份的真实操作者，使代理期间的每条日志都可以追溯
 	}
 
 	//  遍历映射，从 context 中提取值并添加到日志中
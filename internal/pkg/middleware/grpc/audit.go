@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/audit"
+	"miniblog/pkg/errorsx"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// AuditRecorder 用于定义审计事件记录器的实现.
+type AuditRecorder interface {
+	Record(event *audit.Event) bool
+}
+
+// AuditInterceptor 是一个 gRPC 拦截器，用于记录请求的审计事件.
+// 拦截器需要放在认证拦截器之后，以便获取操作者. 是否审计某个方法由调用方通过 selector 决定.
+func AuditInterceptor(recorder AuditRecorder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		event := &audit.Event{
+			Time:           start,
+			RequestID:      contextx.RequestID(ctx),
+			UserID:         contextx.UserID(ctx),
+			ImpersonatorID: contextx.Impersonator(ctx),
+			Method:         info.FullMethod,
+			Code:           http.StatusOK,
+			ClientIP:       contextx.ClientIP(ctx),
+			Latency:        time.Since(start),
+		}
+
+		// 请求和响应中的资源 ID 都是目标资源，例如删除的博客 ID 和新创建的博客 ID
+		var requestIDs, responseIDs []string
+		event.Request, requestIDs = audit.Summarize(marshalMessage(req))
+		if err != nil {
+			errx := errorsx.FromError(err)
+			event.Code, event.Reason = errx.Code, errx.Reason
+		} else {
+			responseIDs = audit.ResourceIDs(marshalMessage(resp))
+		}
+		event.ResourceIDs = audit.MergeIDs(requestIDs, responseIDs)
+
+		if !recorder.Record(event) {
+			log.W(ctx).Warnw("Audit buffer is full, dropping audit event", "method", info.FullMethod)
+		}
+		return resp, err
+	}
+}
+
+// marshalMessage 将 Protobuf 消息编码为 JSON，v 不是 Protobuf 消息时返回空.
+func marshalMessage(v any) []byte {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil
+	}
+	data, _ := protojson.Marshal(msg)
+	return data
+}
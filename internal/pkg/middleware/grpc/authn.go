@@ -10,34 +10,83 @@ import (
 	"miniblog/pkg/token"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
-// UserRetriever 用于根据用户 ID 获取用户信息的接口.
+// UserRetriever 用于根据用户 ID 获取用户信息、用户角色以及用户在组织中的角色的接口.
 type UserRetriever interface {
 	GetUser(ctx context.Context, userID string) (*model.UserM, error)
+	GetRoles(ctx context.Context, userID string) ([]string, error)
+	GetOrganizationRoles(ctx context.Context, userID, orgID string) ([]string, error)
 }
 
 func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
-		userID, err := token.ParseRequest(ctx)
+		// 解析 JWT 或 API Key
+		credential, err := token.ParseRequestCredential(ctx)
 		if err != nil {
 			log.Errorw("Failed to parse request", "err", err)
 			return nil, errno.ErrTokenInvalid.WithMessage(err.Error(), "")
 		}
 
-		log.Debugw("Token parsing successful", "userID", userID)
+		log.Debugw("Token parsing successful", "userID", credential.Identity, "keyID", credential.KeyID)
 
 		// 获取用户信息
-		userM, err := retriever.GetUser(ctx, userID)
+		userM, err := retriever.GetUser(ctx, credential.Identity)
 		if err != nil {
 			log.Errorw("Failed to get user", "err", err)
 			return nil, errno.ErrUnauthenticated.WithMessage(err.Error(), "")
 		}
 
+		// 会话吊销之前签发的 JWT 不再有效
+		if credential.KeyID == "" && userM.SessionsRevokedAt != nil && !credential.IssuedAt.After(*userM.SessionsRevokedAt) {
+			return nil, errno.ErrSessionRevoked
+		}
+
+		// 代理身份 token 还需要校验真实操作者仍然存在，且其会话没有被吊销
+		if credential.Actor != "" {
+			actorM, err := retriever.GetUser(ctx, credential.Actor)
+			if err != nil {
+				log.Errorw("Failed to get impersonator", "err", err)
+				return nil, errno.ErrUnauthenticated.WithMessage(err.Error(), "")
+			}
+			if actorM.SessionsRevokedAt != nil && !credential.IssuedAt.After(*actorM.SessionsRevokedAt) {
+				return nil, errno.ErrSessionRevoked
+			}
+		}
+
+		// 获取用户实际拥有的角色，供业务层做权限判断
+		roles, err := retriever.GetRoles(ctx, userM.UserID)
+		if err != nil {
+			log.Errorw("Failed to get user roles", "err", err)
+			return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+
+		// 请求通过 x-organization-id 指定访问的组织时，只有组织成员可以访问
+		if orgID := organizationID(ctx); orgID != "" {
+			orgRoles, err := retriever.GetOrganizationRoles(ctx, userM.UserID, orgID)
+			if err != nil {
+				log.Errorw("Failed to get organization roles", "err", err)
+				return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+			}
+			if len(orgRoles) == 0 {
+				return nil, errno.ErrNotOrganizationMember
+			}
+			ctx = contextx.WithOrgID(ctx, orgID)
+		}
+
 		// 往 ctx 中注入 userIDKey{} 和 userNameKey{}
 		// 具体对应的是请求用户自己本身的 userID 和 userName
 		ctx = contextx.WithUserID(ctx, userM.UserID)
 		ctx = contextx.WithUsername(ctx, userM.Username)
+		ctx = contextx.WithRoles(ctx, roles)
+		if credential.KeyID != "" {
+			ctx = contextx.WithAPIKey(ctx, credential.KeyID, credential.Scopes)
+		}
+		if credential.Actor != "" {
+			ctx = contextx.WithImpersonator(ctx, credential.Actor)
+			log.W(ctx).Infow("Request made under impersonation", "userID", userM.UserID, "impersonator", credential.Actor, "method", info.FullMethod)
+		}
 
 		// 将用户信息存入上下文
 		ctx = context.WithValue(ctx, known.XUserID, userM.UserID)
@@ -47,3 +96,12 @@ func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// organizationID 从请求元数据中提取请求访问的组织 ID.
+func organizationID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if orgIDs := md.Get(known.XOrganizationID); len(orgIDs) > 0 {
+		return orgIDs[0]
+	}
+	return ""
+}
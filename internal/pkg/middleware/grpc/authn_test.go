@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"miniblog/internal/apiserver/model"
+	"miniblog/internal/pkg/errno"
+	"miniblog/pkg/token"
+)
+
+type fakeUserRetriever struct {
+	userM *model.UserM
+}
+
+func (f *fakeUserRetriever) GetUser(ctx context.Context, userID string) (*model.UserM, error) {
+	return f.userM, nil
+}
+
+func (f *fakeUserRetriever) GetRoles(ctx context.Context, userID string) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeUserRetriever) GetOrganizationRoles(ctx context.Context, userID, orgID string) ([]string, error) {
+	return nil, nil
+}
+
+func TestAuthnInterceptorSessionRevoked(t *testing.T) {
+	// 等到一秒的前半段，保证重置密码前后签发的 token 都在同一秒内
+	for time.Now().Nanosecond() > int(500*time.Millisecond) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	before, _, err := token.Sign("user-000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 与重置密码中的处理保持一致
+	time.Sleep(2 * time.Millisecond)
+	revokedAt := time.Now().Truncate(time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	after, _, err := token.Sign("user-000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retriever := &fakeUserRetriever{userM: &model.UserM{UserID: "user-000001", SessionsRevokedAt: &revokedAt}}
+	interceptor := AuthnInterceptor(retriever)
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	call := func(tk string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+tk))
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+		return err
+	}
+
+	if err := call(before); !errors.Is(err, errno.ErrSessionRevoked) {
+		t.Errorf("token issued before revocation: error = %v, want %v", err, errno.ErrSessionRevoked)
+	}
+	// 与重置密码在同一秒内重新登录获得的 token 仍然有效
+	if err := call(after); err != nil {
+		t.Errorf("token issued after revocation in the same second: error = %v, want nil", err)
+	}
+}
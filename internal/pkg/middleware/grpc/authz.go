@@ -4,6 +4,7 @@ import (
 	"context"
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 
 	"google.golang.org/grpc"
@@ -15,11 +16,20 @@ type Authorizer interface {
 }
 
 // AuthzInterceptor 是一个 gRPC 拦截器，用于进行请求授权.
-func AuthzInterceptor(authorizer Authorizer) grpc.UnaryServerInterceptor {
+// permissions 定义了各个 gRPC 方法对应的逻辑权限，键为 gRPC 方法全名. 授权时使用权限中的资源和动作作为 casbin 的 obj 和 act，
+// 因此同一条策略在 gRPC、gRPC-Gateway 和 Gin 模式下都生效. 未在 permissions 中声明的方法一律拒绝访问.
+// 代理身份的敏感操作限制和 API Key 的权限范围校验在 casbin 授权通过之后进行，权限没有对应的权限范围时不允许通过限定了权限范围的 API Key 访问.
+func AuthzInterceptor(authorizer Authorizer, permissions map[string]string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
 		subject := contextx.UserID(ctx) // 获取用户 ID
-		object := info.FullMethod       // 获取请求资源
-		action := "CALL"                // 默认操作
+
+		// 获取请求方法对应的逻辑权限
+		permission, ok := permissions[info.FullMethod]
+		if !ok {
+			log.Warnw("No permission defined for method", "method", info.FullMethod)
+			return nil, errno.ErrPermissionDenied.WithMessage("access denied: no permission defined for %s", info.FullMethod)
+		}
+		object, action := known.SplitPermission(permission)
 
 		// 记录授权上下文信息
 		log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
@@ -35,6 +45,18 @@ func AuthzInterceptor(authorizer Authorizer) grpc.UnaryServerInterceptor {
 			)
 		}
 
+		// 代理身份不能执行修改密码、删除账号等敏感操作
+		if contextx.IsImpersonated(ctx) && !known.ImpersonationAllowed(permission) {
+			log.W(ctx).Warnw("Operation forbidden under impersonation", "permission", permission)
+			return nil, errno.ErrImpersonationForbidden
+		}
+
+		// 如果请求通过 API Key 认证，还需要校验 API Key 的权限范围
+		if keyID := contextx.APIKeyID(ctx); keyID != "" && !known.HasScope(contextx.Scopes(ctx), known.PermissionScope(permission)) {
+			log.Debugw("API key scope denied", "keyID", keyID, "permission", permission, "scope", known.PermissionScope(permission))
+			return nil, errno.ErrAPIKeyScopeDenied
+		}
+
 		// 继续处理请求
 		return handler(ctx, req)
 	}
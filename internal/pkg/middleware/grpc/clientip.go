@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"miniblog/internal/pkg/contextx"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIPInterceptor 是一个 gRPC 拦截器，用于将客户端 IP 注入到请求上下文中.
+func ClientIPInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(contextx.WithClientIP(ctx, clientIP(ctx)), req)
+	}
+}
+
+// clientIP 返回请求的客户端 IP.
+// 只有当请求来自本机（即 grpc-gateway 反向代理）时才信任 x-forwarded-for，
+// 并且只使用最右边的地址：grpc-gateway 会把请求方的地址追加在客户端传入的 X-Forwarded-For 之后，左边的地址可以被客户端伪造.
+func clientIP(ctx context.Context) string {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	if addr := net.ParseIP(ip); addr == nil || !addr.IsLoopback() {
+		return ip
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+		hops := strings.Split(forwarded[len(forwarded)-1], ",")
+		if last := strings.TrimSpace(hops[len(hops)-1]); last != "" {
+			return last
+		}
+	}
+	return ip
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded string
+		want      string
+	}{
+		{name: "direct client ignores header", peer: "203.0.113.7:5000", forwarded: "198.51.100.1", want: "203.0.113.7"},
+		{name: "gateway without header", peer: "127.0.0.1:5000", want: "127.0.0.1"},
+		{name: "gateway appended address", peer: "127.0.0.1:5000", forwarded: "203.0.113.7", want: "203.0.113.7"},
+		{name: "spoofed entries are ignored", peer: "127.0.0.1:5000", forwarded: "198.51.100.1, 198.51.100.2, 203.0.113.7", want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, _ := net.ResolveTCPAddr("tcp", tt.peer)
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			if tt.forwarded != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", tt.forwarded))
+			}
+			if got := clientIP(ctx); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
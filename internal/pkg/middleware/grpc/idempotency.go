@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/idempotency"
+)
+
+// IdempotencyGuard 用于定义幂等键守卫的实现.
+type IdempotencyGuard interface {
+	Do(ctx context.Context, key, fingerprint string, fn func() ([]byte, error)) ([]byte, bool, error)
+}
+
+// IdempotencyInterceptor 是一个 gRPC 拦截器，用于支持非幂等请求的幂等键.
+// 请求携带 idempotency-key 元数据时，同一个调用方使用相同幂等键的重试直接返回第一次请求的响应，并设置 idempotent-replayed 元数据；
+// 请求内容不同时返回冲突. 幂等键按用户隔离，未认证的请求按客户端 IP 隔离.
+// 需要放在授权拦截器之后，使重试的请求同样经过认证和授权.
+func IdempotencyInterceptor(guard IdempotencyGuard) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(known.IdempotencyKey)
+		if len(values) == 0 || values[0] == "" {
+			return handler(ctx, req)
+		}
+		if len(values[0]) > known.MaxIdempotencyKeyLength {
+			return nil, errno.ErrIdempotencyKeyInvalid
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+
+		var (
+			resp       any
+			handlerErr error
+			executed   bool
+		)
+		data, _, err := guard.Do(ctx, idempotencyScope(ctx, values[0]), idempotency.Fingerprint([]byte(info.FullMethod), body), func() ([]byte, error) {
+			executed = true
+			if resp, handlerErr = handler(ctx, req); handlerErr != nil {
+				return nil, handlerErr
+			}
+			// 保存响应的类型，重放时据此反序列化
+			anyResp, err := anypb.New(resp.(proto.Message))
+			if err != nil {
+				return nil, err
+			}
+			return proto.Marshal(anyResp)
+		})
+		if executed {
+			if err != nil && handlerErr == nil {
+				log.W(ctx).Errorw("Failed to save idempotent response", "method", info.FullMethod, "err", err)
+			}
+			return resp, handlerErr
+		}
+
+		switch {
+		case errors.Is(err, idempotency.ErrKeyReused):
+			return nil, errno.ErrIdempotencyKeyReused
+		case errors.Is(err, idempotency.ErrInProgress):
+			return nil, errno.ErrIdempotencyKeyInProgress
+		case err != nil:
+			log.W(ctx).Errorw("Failed to check idempotency key", "method", info.FullMethod, "err", err)
+			return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+
+		var anyResp anypb.Any
+		if err := proto.Unmarshal(data, &anyResp); err != nil {
+			return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+		replay, err := anyResp.UnmarshalNew()
+		if err != nil {
+			return nil, errno.ErrInternal.WithMessage("%s", err.Error())
+		}
+
+		log.W(ctx).Infow("Replayed idempotent response", "method", info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(known.IdempotentReplayed, "true"))
+		return replay, nil
+	}
+}
+
+// idempotencyScope 返回按调用方隔离的幂等键.
+func idempotencyScope(ctx context.Context, key string) string {
+	if userID := contextx.UserID(ctx); userID != "" {
+		return "user:" + userID + ":" + key
+	}
+	return "ip:" + contextx.ClientIP(ctx) + ":" + key
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+)
+
+// RateLimiter 用于定义限流器接口的实现.
+type RateLimiter interface {
+	Allow(ctx context.Context, method string, keys ...string) (time.Duration, error)
+}
+
+// RateLimitInterceptor 是一个 gRPC 拦截器，按客户端 IP 限制请求频率. 需要放在认证拦截器之前，使未认证和认证失败的请求同样被限流.
+// 被限流的请求返回 RESOURCE_EXHAUSTED，并通过 retry-after 元数据返回需要等待的秒数.
+// 限流器出错时放行请求，避免存储故障导致服务不可用.
+func RateLimitInterceptor(limiter RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := rateLimit(ctx, limiter, info.FullMethod, "ip:"+contextx.ClientIP(ctx)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// UserRateLimitInterceptor 是一个 gRPC 拦截器，按用户 ID 限制已认证请求的频率，需要放在认证拦截器之后.
+func UserRateLimitInterceptor(limiter RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if userID := contextx.UserID(ctx); userID != "" {
+			if err := rateLimit(ctx, limiter, info.FullMethod, "user:"+userID); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimit 检查 key 调用 method 是否超过限流规则，超过时返回错误.
+func rateLimit(ctx context.Context, limiter RateLimiter, method string, key string) error {
+	wait, err := limiter.Allow(ctx, method, key)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check rate limit", "method", method, "err", err)
+		return nil
+	}
+	if wait > 0 {
+		retryAfter := strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10)
+		log.W(ctx).Warnw("Request rejected due to rate limit", "method", method, "retry-after", retryAfter)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(known.RetryAfter, retryAfter))
+		return errno.RateLimitExceeded(retryAfter)
+	}
+	return nil
+}
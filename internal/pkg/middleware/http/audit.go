@@ -0,0 +1,107 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/audit"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAuditBodySize 定义审计时读取的请求体和响应体的最大长度.
+const maxAuditBodySize = 64 << 10
+
+// AuditRecorder 用于定义审计事件记录器的实现.
+type AuditRecorder interface {
+	Record(event *audit.Event) bool
+}
+
+// auditWriter 在写入响应的同时保存响应体，用于提取新创建资源的 ID 和错误原因.
+type auditWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 写入响应，并保存不超过 maxAuditBodySize 的响应体.
+func (w *auditWriter) Write(data []byte) (int, error) {
+	if w.body.Len() < maxAuditBodySize {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// AuditMiddleware 是一个 gin 中间件，用于记录修改类请求（GET、HEAD、OPTIONS 以外的请求）的审计事件.
+// 中间件需要全局注册，操作者从后续认证中间件更新后的请求上下文中获取，未匹配到路由的请求不记录.
+func AuditMiddleware(recorder AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		// 读取请求体后需要放回，供后续的处理函数绑定请求参数
+		var body []byte
+		if c.Request.Body != nil {
+			body, _ = io.ReadAll(io.LimitReader(c.Request.Body, maxAuditBodySize))
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+		}
+
+		writer := &auditWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		if c.FullPath() == "" {
+			return
+		}
+
+		ctx := c.Request.Context()
+		event := &audit.Event{
+			Time:           start,
+			RequestID:      contextx.RequestID(ctx),
+			UserID:         contextx.UserID(ctx),
+			ImpersonatorID: contextx.Impersonator(ctx),
+			Method:         c.Request.Method + " " + c.FullPath(),
+			Code:           writer.Status(),
+			ClientIP:       contextx.ClientIP(ctx),
+			Latency:        time.Since(start),
+		}
+
+		// 资源 ID 可能出现在路径参数、查询参数、请求体和响应体中
+		var pathIDs, requestIDs, responseIDs []string
+		for _, param := range c.Params {
+			if strings.HasSuffix(param.Key, "ID") {
+				pathIDs = append(pathIDs, param.Value)
+			}
+		}
+		for key, values := range c.Request.URL.Query() {
+			if strings.HasSuffix(key, "ID") || strings.HasSuffix(key, "IDs") {
+				pathIDs = append(pathIDs, values...)
+			}
+		}
+		event.Request, requestIDs = audit.Summarize(body)
+		if event.Code >= http.StatusBadRequest {
+			var errResp struct {
+				Reason string `json:"reason"`
+			}
+			_ = json.Unmarshal(writer.body.Bytes(), &errResp)
+			event.Reason = errResp.Reason
+		} else {
+			responseIDs = audit.ResourceIDs(writer.body.Bytes())
+		}
+		event.ResourceIDs = audit.MergeIDs(pathIDs, requestIDs, responseIDs)
+
+		if !recorder.Record(event) {
+			log.W(ctx).Warnw("Audit buffer is full, dropping audit event", "method", event.Method)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"miniblog/internal/apiserver/model"
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	"miniblog/pkg/core"
 	"miniblog/pkg/token"
@@ -12,32 +13,89 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// UserRetriever 用于根据用户 ID 获取用户信息的接口.
+// UserRetriever 用于根据用户 ID 获取用户信息、用户角色以及用户在组织中的角色的接口.
 type UserRetriever interface {
 	GetUser(ctx context.Context, userID string) (*model.UserM, error)
+	GetRoles(ctx context.Context, userID string) ([]string, error)
+	GetOrganizationRoles(ctx context.Context, userID, orgID string) ([]string, error)
 }
 
 // AuthnMiddleware 是一个认证中间件，用于从 gin.Context 中提取 token 并验证 token 是否合法.
 func AuthnMiddleware(retriever UserRetriever) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID, err := token.ParseRequest(c)
+		// 解析 JWT 或 API Key
+		credential, err := token.ParseRequestCredential(c)
 		if err != nil {
 			core.WriteResponse(c, nil, errno.ErrTokenInvalid.WithMessage(err.Error(), ""))
 			c.Abort()
 			return
 		}
 
-		log.Debugw("Token parsing successful", "userID", userID)
+		log.Debugw("Token parsing successful", "userID", credential.Identity, "keyID", credential.KeyID)
 
-		userM, err := retriever.GetUser(c, userID)
+		userM, err := retriever.GetUser(c, credential.Identity)
 		if err != nil {
 			core.WriteResponse(c, nil, errno.ErrUnauthenticated.WithMessage(err.Error(), ""))
 			c.Abort()
 			return
 		}
 
+		// 会话吊销之前签发的 JWT 不再有效
+		if credential.KeyID == "" && userM.SessionsRevokedAt != nil && !credential.IssuedAt.After(*userM.SessionsRevokedAt) {
+			core.WriteResponse(c, nil, errno.ErrSessionRevoked)
+			c.Abort()
+			return
+		}
+
+		// 代理身份 token 还需要校验真实操作者仍然存在，且其会话没有被吊销
+		if credential.Actor != "" {
+			actorM, err := retriever.GetUser(c, credential.Actor)
+			if err != nil {
+				core.WriteResponse(c, nil, errno.ErrUnauthenticated.WithMessage(err.Error(), ""))
+				c.Abort()
+				return
+			}
+			if actorM.SessionsRevokedAt != nil && !credential.IssuedAt.After(*actorM.SessionsRevokedAt) {
+				core.WriteResponse(c, nil, errno.ErrSessionRevoked)
+				c.Abort()
+				return
+			}
+		}
+
+		// 获取用户实际拥有的角色，供业务层做权限判断
+		roles, err := retriever.GetRoles(c, userM.UserID)
+		if err != nil {
+			core.WriteResponse(c, nil, errno.ErrInternal.WithMessage("%s", err.Error()))
+			c.Abort()
+			return
+		}
+
 		ctx := contextx.WithUserID(c.Request.Context(), userM.UserID)
 		ctx = contextx.WithUsername(ctx, userM.Username)
+		ctx = contextx.WithRoles(ctx, roles)
+		if credential.KeyID != "" {
+			ctx = contextx.WithAPIKey(ctx, credential.KeyID, credential.Scopes)
+		}
+		if credential.Actor != "" {
+			ctx = contextx.WithImpersonator(ctx, credential.Actor)
+			log.W(ctx).Infow("Request made under impersonation", "userID", userM.UserID, "impersonator", credential.Actor, "route", c.Request.Method+" "+c.FullPath())
+		}
+
+		// 请求通过 X-Organization-ID 指定访问的组织时，只有组织成员可以访问
+		if orgID := c.GetHeader(known.XOrganizationID); orgID != "" {
+			orgRoles, err := retriever.GetOrganizationRoles(c, userM.UserID, orgID)
+			if err != nil {
+				core.WriteResponse(c, nil, errno.ErrInternal.WithMessage("%s", err.Error()))
+				c.Abort()
+				return
+			}
+			if len(orgRoles) == 0 {
+				core.WriteResponse(c, nil, errno.ErrNotOrganizationMember)
+				c.Abort()
+				return
+			}
+			ctx = contextx.WithOrgID(ctx, orgID)
+		}
 		c.Request = c.Request.WithContext(ctx)
 
 		// 继续后续的操作
@@ -3,6 +3,7 @@ package http
 import (
 	"miniblog/internal/pkg/contextx"
 	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	"miniblog/pkg/core"
 
@@ -14,12 +15,25 @@ type Authorizer interface {
 	Authorize(sub, obj, act string) (bool, error)
 }
 
-// AuthzInterceptor 是一个 gin 中间件，用于进行请求授权.
-func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
+// AuthzMiddleware 是一个 gin 中间件，用于进行请求授权.
+// permissions 定义了各个路由对应的逻辑权限，键的格式为 "<METHOD> <路由模板>"，例如 "DELETE /v1/users/:userID".
+// 授权时使用权限中的资源和动作作为 casbin 的 obj 和 act，因此同一条策略在 gRPC、gRPC-Gateway 和 Gin 模式下都生效.
+// 未在 permissions 中声明的路由一律拒绝访问.
+// 代理身份的敏感操作限制和 API Key 的权限范围校验在 casbin 授权通过之后进行，权限没有对应的权限范围时不允许通过限定了权限范围的 API Key 访问.
+func AuthzMiddleware(authorizer Authorizer, permissions map[string]string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		subject := contextx.UserID(c.Request.Context())
-		object := c.Request.URL.Path
-		action := c.Request.Method
+
+		// 获取请求路由对应的逻辑权限
+		route := c.Request.Method + " " + c.FullPath()
+		permission, ok := permissions[route]
+		if !ok {
+			log.Warnw("No permission defined for route", "route", route)
+			core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage("access denied: no permission defined for %s", route))
+			c.Abort()
+			return
+		}
+		object, action := known.SplitPermission(permission)
 
 		// 记录授权上下文信息
 		log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
@@ -37,6 +51,22 @@ func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
 			return
 		}
 
+		// 代理身份不能执行修改密码、删除账号等敏感操作
+		if contextx.IsImpersonated(c.Request.Context()) && !known.ImpersonationAllowed(permission) {
+			log.W(c.Request.Context()).Warnw("Operation forbidden under impersonation", "permission", permission)
+			core.WriteResponse(c, nil, errno.ErrImpersonationForbidden)
+			c.Abort()
+			return
+		}
+
+		// 如果请求通过 API Key 认证，还需要校验 API Key 的权限范围
+		if keyID := contextx.APIKeyID(c.Request.Context()); keyID != "" && !known.HasScope(contextx.Scopes(c.Request.Context()), known.PermissionScope(permission)) {
+			log.Debugw("API key scope denied", "keyID", keyID, "permission", permission, "scope", known.PermissionScope(permission))
+			core.WriteResponse(c, nil, errno.ErrAPIKeyScopeDenied)
+			c.Abort()
+			return
+		}
+
 		// 继续后续的操作
 		c.Next()
 	}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"miniblog/internal/pkg/contextx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIPMiddleware 是一个 Gin 中间件，用于将客户端 IP 注入到请求上下文中.
+func ClientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := contextx.WithClientIP(c.Request.Context(), c.ClientIP())
+		c.Request = c.Request.WithContext(ctx)
+
+		// 继续处理请求
+		c.Next()
+	}
+}
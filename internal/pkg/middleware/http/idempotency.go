@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/core"
+	"miniblog/pkg/idempotency"
+)
+
+// errRequestFailed 表示请求处理失败，失败的请求不保存响应.
+var errRequestFailed = errors.New("request failed")
+
+// IdempotencyGuard 用于定义幂等键守卫的实现.
+type IdempotencyGuard interface {
+	Do(ctx context.Context, key, fingerprint string, fn func() ([]byte, error)) ([]byte, bool, error)
+}
+
+// idempotentResponse 是保存的 HTTP 响应.
+type idempotentResponse struct {
+	Code        int    `json:"code"`
+	ContentType string `json:"contentType"`
+	Body        []byte `json:"body"`
+}
+
+// idempotencyWriter 在写入响应的同时保存完整的响应体.
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 写入响应，并保存响应体.
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// IdempotencyMiddleware 是一个 gin 中间件，用于支持 routes 中列出的路由的幂等键，路由的格式为 "<METHOD> <路由模板>"，例如 "POST /v1/posts".
+// 请求携带 Idempotency-Key 请求头时，同一个调用方使用相同幂等键的重试直接返回第一次请求的响应，并设置 Idempotent-Replayed 响应头；
+// 请求内容不同时返回冲突. 幂等键按用户隔离，未认证的请求按客户端 IP 隔离.
+// 需要放在授权中间件之后，使重试的请求同样经过认证和授权. 只保存成功的响应，失败的请求可以使用同一个幂等键重试.
+// 响应会被明文保存，因此 routes 中不能包含响应中有令牌或密钥的路由.
+func IdempotencyMiddleware(guard IdempotencyGuard, routes []string) gin.HandlerFunc {
+	idempotent := make(map[string]struct{}, len(routes))
+	for _, route := range routes {
+		idempotent[route] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(known.IdempotencyKey)
+		route := c.Request.Method + " " + c.FullPath()
+		if _, ok := idempotent[route]; !ok || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > known.MaxIdempotencyKeyLength {
+			core.WriteResponse(c, nil, errno.ErrIdempotencyKeyInvalid)
+			c.Abort()
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		// 读取请求体后需要放回，供后续的处理函数绑定请求参数
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			if body, err = io.ReadAll(c.Request.Body); err != nil {
+				core.WriteResponse(c, nil, errno.ErrBind.WithMessage("%s", err.Error()))
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		fingerprint := idempotency.Fingerprint([]byte(route), []byte(c.Request.URL.RequestURI()), body)
+
+		executed := false
+		data, _, err := guard.Do(ctx, idempotencyScope(ctx, key), fingerprint, func() ([]byte, error) {
+			executed = true
+			writer := &idempotencyWriter{ResponseWriter: c.Writer}
+			c.Writer = writer
+			c.Next()
+			c.Writer = writer.ResponseWriter
+
+			if writer.Status() >= http.StatusBadRequest {
+				return nil, errRequestFailed
+			}
+			return json.Marshal(&idempotentResponse{
+				Code:        writer.Status(),
+				ContentType: writer.Header().Get("Content-Type"),
+				Body:        writer.body.Bytes(),
+			})
+		})
+		if executed {
+			if err != nil && !errors.Is(err, errRequestFailed) {
+				log.W(ctx).Errorw("Failed to save idempotent response", "route", route, "err", err)
+			}
+			return
+		}
+
+		switch {
+		case errors.Is(err, idempotency.ErrKeyReused):
+			core.WriteResponse(c, nil, errno.ErrIdempotencyKeyReused)
+			c.Abort()
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			core.WriteResponse(c, nil, errno.ErrIdempotencyKeyInProgress)
+			c.Abort()
+			return
+		case err != nil:
+			log.W(ctx).Errorw("Failed to check idempotency key", "route", route, "err", err)
+			core.WriteResponse(c, nil, errno.ErrInternal.WithMessage("%s", err.Error()))
+			c.Abort()
+			return
+		}
+
+		var resp idempotentResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			core.WriteResponse(c, nil, errno.ErrInternal.WithMessage("%s", err.Error()))
+			c.Abort()
+			return
+		}
+
+		log.W(ctx).Infow("Replayed idempotent response", "route", route)
+		c.Header(known.IdempotentReplayed, "true")
+		c.Data(resp.Code, resp.ContentType, resp.Body)
+		c.Abort()
+	}
+}
+
+// idempotencyScope 返回按调用方隔离的幂等键.
+func idempotencyScope(ctx context.Context, key string) string {
+	if userID := contextx.UserID(ctx); userID != "" {
+		return "user:" + userID + ":" + key
+	}
+	return "ip:" + contextx.ClientIP(ctx) + ":" + key
+}
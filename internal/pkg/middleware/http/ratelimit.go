@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"miniblog/internal/pkg/contextx"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	"miniblog/internal/pkg/log"
+	"miniblog/pkg/core"
+)
+
+// RateLimiter 用于定义限流器接口的实现.
+type RateLimiter interface {
+	Allow(ctx context.Context, method string, keys ...string) (time.Duration, error)
+}
+
+// RateLimitMiddleware 是一个 gin 中间件，按客户端 IP 限制请求频率，需要注册为全局中间件.
+// 方法的格式为 "<METHOD> <路由模板>"，例如 "POST /login".
+// 被限流的请求返回 429，并通过 Retry-After 响应头返回需要等待的秒数. 限流器出错时放行请求，避免存储故障导致服务不可用.
+func RateLimitMiddleware(limiter RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rateLimit(c, limiter, "ip:"+contextx.ClientIP(c.Request.Context()))
+	}
+}
+
+// UserRateLimitMiddleware 是一个 gin 中间件，按用户 ID 限制请求频率，需要放在认证中间件之后.
+func UserRateLimitMiddleware(limiter RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rateLimit(c, limiter, "user:"+contextx.UserID(c.Request.Context()))
+	}
+}
+
+// rateLimit 检查 key 调用当前路由是否超过限流规则，超过时中止请求.
+func rateLimit(c *gin.Context, limiter RateLimiter, key string) {
+	ctx := c.Request.Context()
+	route := c.Request.Method + " " + c.FullPath()
+
+	wait, err := limiter.Allow(ctx, route, key)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check rate limit", "route", route, "err", err)
+		c.Next()
+		return
+	}
+	if wait > 0 {
+		retryAfter := strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10)
+		log.W(ctx).Warnw("Request rejected due to rate limit", "route", route, "retry-after", retryAfter)
+		c.Header(known.RetryAfter, retryAfter)
+		core.WriteResponse(c, nil, errno.RateLimitExceeded(retryAfter))
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
@@ -1,20 +1,29 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
+	"io"
+	"miniblog/internal/pkg/conversion"
+	"miniblog/internal/pkg/known"
 	"miniblog/internal/pkg/log"
 	genericoptions "miniblog/pkg/options"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // GRPCGatewayServer 代表一个 GRPC 网关服务器.
@@ -58,7 +67,9 @@ func NewGRPCGatewayServer(
 			// 否则，默认会以字符串格式输出，跟枚举类型定义不一致，带来理解成本.
 			UseEnumNumbers: true,
 		},
-	}))
+	}), runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher), runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(errorHandler), runtime.WithForwardResponseOption(notModifiedResponse),
+		runtime.WithMiddlewares(patchUpdateMask, discardNotModifiedBody))
 	if err := registerHandler(gwmux, conn); err != nil {
 		log.Errorw("Failed to register handler", "err", err)
 		return nil, err
@@ -73,6 +84,128 @@ func NewGRPCGatewayServer(
 	}, nil
 }
 
+// incomingHeaderMatcher 在默认规则之外，将请求访问的组织 ID、幂等键和条件请求头转发到 gRPC 元数据中.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, known.XOrganizationID) {
+		return known.XOrganizationID, true
+	}
+	if strings.EqualFold(key, known.IdempotencyKey) {
+		return known.IdempotencyKey, true
+	}
+	for _, header := range []string{known.IfMatch, known.IfNoneMatch, known.IfModifiedSince} {
+		if strings.EqualFold(key, header) {
+			return header, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher 在默认规则之外，将限流返回的 retry-after 元数据、幂等重放标记以及资源的 etag 和最后修改时间作为标准的响应头返回.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, known.RetryAfter) {
+		return "Retry-After", true
+	}
+	if strings.EqualFold(key, known.IdempotentReplayed) {
+		return "Idempotent-Replayed", true
+	}
+	if strings.EqualFold(key, known.ETag) {
+		return "ETag", true
+	}
+	if strings.EqualFold(key, known.LastModified) {
+		return "Last-Modified", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// errorHandler 将 FailedPrecondition 错误（例如 If-Match 与资源版本不一致）以 412 状态码返回，
+// 其余错误交给默认的错误处理函数.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if status.Code(err) == codes.FailedPrecondition {
+		err = &runtime.HTTPStatusError{HTTPStatus: http.StatusPreconditionFailed, Err: err}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// notModifiedResponse 在响应的 notModified 为 true 时返回 304.
+// 网关在之后仍然会序列化并写入响应体，304 响应不允许有响应体，由 discardNotModifiedBody 丢弃.
+func notModifiedResponse(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if r, ok := resp.(interface{ GetNotModified() bool }); ok && r.GetNotModified() {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+	}
+	return nil
+}
+
+// discardNotModifiedBody 在响应状态码为 304 时丢弃网关写入的响应体，参见 notModifiedResponse.
+func discardNotModifiedBody(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if r.Method != http.MethodGet {
+			next(w, r, pathParams)
+			return
+		}
+		next(&notModifiedWriter{ResponseWriter: w}, r, pathParams)
+	}
+}
+
+// notModifiedWriter 记录响应状态码，状态码为 304 时丢弃写入的响应体.
+type notModifiedWriter struct {
+	http.ResponseWriter
+	notModified bool
+}
+
+// WriteHeader 写入响应状态码.
+func (w *notModifiedWriter) WriteHeader(code int) {
+	w.notModified = code == http.StatusNotModified
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write 在响应状态码为 304 时丢弃响应体，否则写入响应体.
+func (w *notModifiedWriter) Write(b []byte) (int, error) {
+	if w.notModified {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap 返回原始的 http.ResponseWriter，供 http.ResponseController 使用.
+func (w *notModifiedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// patchUpdateMask 在 PATCH 请求体中没有 updateMask 时，使用请求体中出现的字段作为 updateMask，
+// 使 PATCH 请求只更新请求体中传入的字段，参见 conversion.PatchUpdateMask.
+func patchUpdateMask(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if r.Method != http.MethodPatch || r.Body == nil {
+			next(w, r, pathParams)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// 请求体不是 JSON 对象时保持不变，由网关返回解析错误
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(body, &fields) == nil {
+			params := make([]string, 0, len(pathParams))
+			for key := range pathParams {
+				params = append(params, key)
+			}
+			if mask := conversion.PatchUpdateMask(fields, params...); mask != nil {
+				fields["updateMask"], _ = protojson.Marshal(mask)
+				body, _ = json.Marshal(fields)
+			}
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next(w, r, pathParams)
+	}
+}
+
 // RunOrDie 启动 GRPC 网关服务器并在出错时记录致命错误.
 func (s *GRPCGatewayServer) RunOrDie() {
 	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.srv.Addr)
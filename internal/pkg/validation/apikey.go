@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+	"slices"
+	"time"
+)
+
+func (v *Validator) ValidateAPIKeyRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"Name": func(value any) error {
+			if name := value.(string); name == "" || len(name) > 64 {
+				return errno.ErrInvalidArgument.WithMessage("name must be between 1 and 64 characters long")
+			}
+			return nil
+		},
+		"Scopes": func(value any) error {
+			for _, scope := range value.([]string) {
+				if !slices.Contains(known.AvailableScopes, scope) {
+					return errno.ErrInvalidArgument.WithMessage("invalid scope %q, available scopes: %v", scope, known.AvailableScopes)
+				}
+			}
+			return nil
+		},
+		"KeyID": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("keyID cannot be empty")
+			}
+			return nil
+		},
+		"Offset": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("offset cannot be negative")
+			}
+			return nil
+		},
+		"Limit": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.ErrInvalidArgument.WithMessage("limit must be greater than 0")
+			}
+			return nil
+		},
+	}
+}
+
+func (v *Validator) ValidateCreateAPIKeyRequest(ctx context.Context, rq *apiv1.CreateAPIKeyRequest) error {
+	if rq.ExpiresAt != nil && !rq.GetExpiresAt().AsTime().After(time.Now()) {
+		return errno.ErrInvalidArgument.WithMessage("expiresAt must be in the future")
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidateAPIKeyRules())
+}
+
+func (v *Validator) ValidateDeleteAPIKeyRequest(ctx context.Context, rq *apiv1.DeleteAPIKeyRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateAPIKeyRules())
+}
+
+func (v *Validator) ValidateListAPIKeyRequest(ctx context.Context, rq *apiv1.ListAPIKeyRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateAPIKeyRules())
+}
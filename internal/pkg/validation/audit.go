@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+)
+
+func (v *Validator) ValidateAuditRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"Offset": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("offset cannot be negative")
+			}
+			return nil
+		},
+		"Limit": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.ErrInvalidArgument.WithMessage("limit must be greater than 0")
+			}
+			return nil
+		},
+		"StartTime": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("startTime cannot be negative")
+			}
+			return nil
+		},
+		"EndTime": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("endTime cannot be negative")
+			}
+			return nil
+		},
+	}
+}
+
+func (v *Validator) ValidateListAuditEventsRequest(ctx context.Context, rq *apiv1.ListAuditEventsRequest) error {
+	if rq.GetStartTime() > 0 && rq.GetEndTime() > 0 && rq.GetEndTime() <= rq.GetStartTime() {
+		return errno.ErrInvalidArgument.WithMessage("endTime must be after startTime")
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidateAuditRules())
+}
@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+)
+
+// ValidateIdentityRules 返回外部身份相关请求的校验规则.
+// 外部认证方式的用户名和密码格式由认证方式决定，这里只校验非空.
+func (v *Validator) ValidateIdentityRules() genericvalidation.Rules {
+	notEmpty := func(field string) genericvalidation.ValidatorFunc {
+		return func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("%s cannot be empty", field)
+			}
+			return nil
+		}
+	}
+
+	return genericvalidation.Rules{
+		"Provider": notEmpty("provider"),
+		"Username": notEmpty("username"),
+		"Password": notEmpty("password"),
+		"Code":     notEmpty("code"),
+		"State":    notEmpty("state"),
+	}
+}
+
+func (v *Validator) ValidateOIDCLoginRequest(ctx context.Context, rq *apiv1.OIDCLoginRequest) error {
+	return nil
+}
+
+func (v *Validator) ValidateOIDCCallbackRequest(ctx context.Context, rq *apiv1.OIDCCallbackRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateIdentityRules())
+}
+
+func (v *Validator) ValidateLinkIdentityRequest(ctx context.Context, rq *apiv1.LinkIdentityRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateIdentityRules())
+}
+
+func (v *Validator) ValidateUnlinkIdentityRequest(ctx context.Context, rq *apiv1.UnlinkIdentityRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateIdentityRules())
+}
@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+	"time"
+)
+
+func (v *Validator) ValidateInvitationRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"MaxUses": func(value any) error {
+			if value.(int64) < 1 {
+				return errno.ErrInvalidArgument.WithMessage("maxUses must be greater than 0")
+			}
+			return nil
+		},
+		"Code": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("code cannot be empty")
+			}
+			return nil
+		},
+		"Offset": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("offset cannot be negative")
+			}
+			return nil
+		},
+		"Limit": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.ErrInvalidArgument.WithMessage("limit must be greater than 0")
+			}
+			return nil
+		},
+	}
+}
+
+func (v *Validator) ValidateCreateInvitationRequest(ctx context.Context, rq *apiv1.CreateInvitationRequest) error {
+	if rq.ExpiresAt != nil && !rq.GetExpiresAt().AsTime().After(time.Now()) {
+		return errno.ErrInvalidArgument.WithMessage("expiresAt must be in the future")
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidateInvitationRules())
+}
+
+func (v *Validator) ValidateDeleteInvitationRequest(ctx context.Context, rq *apiv1.DeleteInvitationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateInvitationRules())
+}
+
+func (v *Validator) ValidateListInvitationRequest(ctx context.Context, rq *apiv1.ListInvitationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateInvitationRules())
+}
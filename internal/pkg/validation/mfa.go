@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+)
+
+func (v *Validator) ValidateMFARules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"Code": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("code cannot be empty")
+			}
+			return nil
+		},
+		"ChallengeToken": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("challengeToken cannot be empty")
+			}
+			return nil
+		},
+	}
+}
+
+func (v *Validator) ValidateEnrollTOTPRequest(ctx context.Context, rq *apiv1.EnrollTOTPRequest) error {
+	return nil
+}
+
+func (v *Validator) ValidateVerifyTOTPRequest(ctx context.Context, rq *apiv1.VerifyTOTPRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateMFARules())
+}
+
+func (v *Validator) ValidateDisableTOTPRequest(ctx context.Context, rq *apiv1.DisableTOTPRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateMFARules())
+}
+
+func (v *Validator) ValidateLoginVerifyRequest(ctx context.Context, rq *apiv1.LoginVerifyRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateMFARules())
+}
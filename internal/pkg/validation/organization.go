@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+	"slices"
+)
+
+func (v *Validator) ValidateOrganizationRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"OrgID": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("orgID cannot be empty")
+			}
+			return nil
+		},
+		"Name": func(value any) error {
+			if l := len(value.(string)); l < 1 || l > 255 {
+				return errno.ErrInvalidArgument.WithMessage("name must be between 1 and 255 characters")
+			}
+			return nil
+		},
+		"Description": func(value any) error {
+			if len(value.(string)) > 1024 {
+				return errno.ErrInvalidArgument.WithMessage("description must be at most 1024 characters")
+			}
+			return nil
+		},
+		"UserID": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("userID cannot be empty")
+			}
+			return nil
+		},
+		"Role": func(value any) error {
+			if !slices.Contains(known.OrganizationRoles, value.(string)) {
+				return errno.ErrInvalidArgument.WithMessage("role must be one of %v", known.OrganizationRoles)
+			}
+			return nil
+		},
+		"Offset": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("offset cannot be negative")
+			}
+			return nil
+		},
+		"Limit": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.ErrInvalidArgument.WithMessage("limit must be greater than 0")
+			}
+			return nil
+		},
+	}
+}
+
+func (v *Validator) ValidateCreateOrganizationRequest(ctx context.Context, rq *apiv1.CreateOrganizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+func (v *Validator) ValidateDeleteOrganizationRequest(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+func (v *Validator) ValidateGetOrganizationRequest(ctx context.Context, rq *apiv1.GetOrganizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+func (v *Validator) ValidateListOrganizationRequest(ctx context.Context, rq *apiv1.ListOrganizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+func (v *Validator) ValidateAddOrganizationMemberRequest(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+func (v *Validator) ValidateRemoveOrganizationMemberRequest(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+func (v *Validator) ValidateListOrganizationMemberRequest(ctx context.Context, rq *apiv1.ListOrganizationMemberRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+)
+
+// ValidateTokenRules 返回使用一次性令牌或签名令牌的请求的校验规则，例如重置密码、验证邮箱.
+func (v *Validator) ValidateTokenRules() genericvalidation.Rules {
+	rules := v.ValidateUserRules()
+	rules["Token"] = func(value any) error {
+		if value.(string) == "" {
+			return errno.ErrInvalidArgument.WithMessage("token cannot be empty")
+		}
+		return nil
+	}
+	return rules
+}
+
+func (v *Validator) ValidateRequestPasswordResetRequest(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateTokenRules())
+}
+
+func (v *Validator) ValidateResetPasswordRequest(ctx context.Context, rq *apiv1.ResetPasswordRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateTokenRules())
+}
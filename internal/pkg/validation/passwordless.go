@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+	"regexp"
+)
+
+// loginCodePattern 匹配短信登录验证码.
+var loginCodePattern = regexp.MustCompile(`^\d+$`)
+
+// ValidateLoginCodeRules 返回短信验证码登录请求的校验规则.
+func (v *Validator) ValidateLoginCodeRules() genericvalidation.Rules {
+	rules := v.ValidateUserRules()
+	rules["Code"] = func(value any) error {
+		if code := value.(string); len(code) != known.LoginCodeLength || !loginCodePattern.MatchString(code) {
+			return errno.ErrInvalidArgument.WithMessage("code must be a %d-digit number", known.LoginCodeLength)
+		}
+		return nil
+	}
+	return rules
+}
+
+func (v *Validator) ValidateRequestMagicLinkRequest(ctx context.Context, rq *apiv1.RequestMagicLinkRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+func (v *Validator) ValidateMagicLinkLoginRequest(ctx context.Context, rq *apiv1.MagicLinkLoginRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateTokenRules())
+}
+
+func (v *Validator) ValidateRequestLoginCodeRequest(ctx context.Context, rq *apiv1.RequestLoginCodeRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+func (v *Validator) ValidateLoginWithCodeRequest(ctx context.Context, rq *apiv1.LoginWithCodeRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateLoginCodeRules())
+}
@@ -0,0 +1,111 @@
+package validation
+
+import (
+	"context"
+	"miniblog/internal/pkg/errno"
+	"miniblog/internal/pkg/known"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+	"strings"
+)
+
+// maxPolicyFieldLength 是授权策略各字段的最大长度，与 casbin_rule 表的列长度一致.
+const maxPolicyFieldLength = 100
+
+// ValidatePolicyRules 返回授权策略和角色分配的校验规则.
+func (v *Validator) ValidatePolicyRules() genericvalidation.Rules {
+	policyField := func(field string) genericvalidation.ValidatorFunc {
+		return func(value any) error {
+			if s := value.(string); s == "" || len(s) > maxPolicyFieldLength {
+				return errno.ErrInvalidArgument.WithMessage("%s must be between 1 and %d characters long", field, maxPolicyFieldLength)
+			}
+			return nil
+		}
+	}
+
+	rules := v.ValidateUserRules()
+	rules["Subject"] = policyField("subject")
+	rules["Object"] = policyField("object")
+	rules["Action"] = policyField("action")
+	rules["Effect"] = func(value any) error {
+		if effect := value.(string); effect != known.PolicyEffectAllow && effect != known.PolicyEffectDeny {
+			return errno.ErrInvalidArgument.WithMessage("effect must be %s or %s", known.PolicyEffectAllow, known.PolicyEffectDeny)
+		}
+		return nil
+	}
+	rules["Role"] = func(value any) error {
+		if role := value.(string); !strings.HasPrefix(role, known.RolePrefix) || len(role) <= len(known.RolePrefix) || len(role) > maxPolicyFieldLength {
+			return errno.ErrInvalidArgument.WithMessage("role must start with %q and be at most %d characters long", known.RolePrefix, maxPolicyFieldLength)
+		}
+		return nil
+	}
+	return rules
+}
+
+// validateListRules 返回列表请求的校验规则，列表请求中的过滤条件可以为空.
+func (v *Validator) validateListRules() genericvalidation.Rules {
+	rules := v.ValidateUserRules()
+	return genericvalidation.Rules{"Offset": rules["Offset"], "Limit": rules["Limit"]}
+}
+
+func (v *Validator) ValidateListPolicyRequest(ctx context.Context, rq *apiv1.ListPolicyRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.validateListRules())
+}
+
+func (v *Validator) ValidateAddPolicyRequest(ctx context.Context, rq *apiv1.AddPolicyRequest) error {
+	return v.validatePolicy(rq.GetPolicy())
+}
+
+func (v *Validator) ValidateRemovePolicyRequest(ctx context.Context, rq *apiv1.RemovePolicyRequest) error {
+	return v.validatePolicy(rq.GetPolicy())
+}
+
+func (v *Validator) ValidateExplainAuthorizationRequest(ctx context.Context, rq *apiv1.ExplainAuthorizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+func (v *Validator) ValidateListRoleAssignmentRequest(ctx context.Context, rq *apiv1.ListRoleAssignmentRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.validateListRules())
+}
+
+func (v *Validator) ValidateAddRoleAssignmentRequest(ctx context.Context, rq *apiv1.AddRoleAssignmentRequest) error {
+	return v.validateRoleAssignment(rq.GetRoleAssignment())
+}
+
+func (v *Validator) ValidateRemoveRoleAssignmentRequest(ctx context.Context, rq *apiv1.RemoveRoleAssignmentRequest) error {
+	return v.validateRoleAssignment(rq.GetRoleAssignment())
+}
+
+func (v *Validator) ValidateGetUserRolesRequest(ctx context.Context, rq *apiv1.GetUserRolesRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// validatePolicy 校验授权策略.
+func (v *Validator) validatePolicy(policy *apiv1.Policy) error {
+	if policy == nil {
+		return errno.ErrInvalidArgument.WithMessage("policy cannot be empty")
+	}
+	if err := genericvalidation.ValidateAllFields(policy, v.ValidatePolicyRules()); err != nil {
+		return err
+	}
+
+	// 策略的资源和动作必须对应已知的逻辑权限，可以使用 * 通配
+	for _, permission := range known.AvailablePermissions {
+		resource, action := known.SplitPermission(permission)
+		if (policy.GetObject() == "*" || policy.GetObject() == resource) && (policy.GetAction() == "*" || policy.GetAction() == action) {
+			return nil
+		}
+	}
+	return errno.ErrInvalidArgument.WithMessage("unknown permission %s:%s", policy.GetObject(), policy.GetAction())
+}
+
+// validateRoleAssignment 校验角色分配，主体不能与角色相同.
+func (v *Validator) validateRoleAssignment(ra *apiv1.RoleAssignment) error {
+	if ra == nil {
+		return errno.ErrInvalidArgument.WithMessage("roleAssignment cannot be empty")
+	}
+	if ra.GetSubject() == ra.GetRole() {
+		return errno.ErrInvalidArgument.WithMessage("a role cannot be assigned to itself")
+	}
+	return genericvalidation.ValidateAllFields(ra, v.ValidatePolicyRules())
+}
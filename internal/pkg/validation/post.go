@@ -52,7 +52,10 @@ func (v *Validator) ValidateCreatePostRequest(ctx context.Context, rq *apiv1.Cre
 }
 
 func (v *Validator) ValidateUpdatePostRequest(ctx context.Context, rq *apiv1.UpdatePostRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidatePostRules()); err != nil {
+		return err
+	}
+	return validateUpdateMask(rq, rq.GetUpdateMask(), v.ValidatePostRules())
 }
 
 func (v *Validator) ValidateDeletePostRequest(ctx context.Context, rq *apiv1.DeletePostRequest) error {
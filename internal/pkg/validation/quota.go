@@ -0,0 +1,10 @@
+package validation
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func (v *Validator) ValidateGetMyQuotaRequest(ctx context.Context, rq *apiv1.GetMyQuotaRequest) error {
+	return nil
+}
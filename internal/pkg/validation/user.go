@@ -4,6 +4,7 @@ import (
 	"context"
 	"miniblog/internal/pkg/errno"
 	apiv1 "miniblog/pkg/api/apiserver/v1"
+	"miniblog/pkg/authenticator"
 	genericvalidation "miniblog/pkg/validation"
 	"regexp"
 )
@@ -15,7 +16,7 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 	)
 
 	validatePassword = func(value any) error {
-		return isValiPassword(value.(string))
+		return v.isValiPassword(value.(string))
 	}
 
 	validateName = func(value any) error {
@@ -23,8 +24,10 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 	}
 	return genericvalidation.Rules{
 
-		"Password":    validatePassword,
-		"OldPassword": validatePassword,
+		"Password": validatePassword,
+		"OldPassword": func(value any) error {
+			return isNotEmptyPassword(value.(string))
+		},
 		"NewPassword": validatePassword,
 		"Username":    validateName,
 		"Nickname":    validateName,
@@ -65,7 +68,10 @@ func (v *Validator) ValidateCreateUserRequest(ctx context.Context, rq *apiv1.Cre
 }
 
 func (v *Validator) ValidateUpdateUserRequest(ctx context.Context, rq *apiv1.UpdateUserRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+	return validateUpdateMask(rq, rq.GetUpdateMask(), v.ValidateUserRules())
 }
 
 func (v *Validator) ValidateDeleteUserRequest(ctx context.Context, rq *apiv1.DeleteUserRequest) error {
@@ -81,9 +87,27 @@ func (v *Validator) ValidateListUserRequest(ctx context.Context, rq *apiv1.ListU
 }
 
 func (v *Validator) ValidateLoginRequest(ctx context.Context, rq *apiv1.LoginRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	// 使用外部认证方式登录时，用户名和密码不受 miniblog 本地规则约束
+	if rq.GetProvider() != "" && rq.GetProvider() != authenticator.ProviderLocal {
+		return genericvalidation.ValidateAllFields(rq, v.ValidateIdentityRules())
+	}
+
+	// 登录时只校验密码非空，已有密码不受当前密码策略约束
+	rules := v.ValidateUserRules()
+	rules["Password"] = func(value any) error {
+		return isNotEmptyPassword(value.(string))
+	}
+	return genericvalidation.ValidateAllFields(rq, rules)
 }
 
 func (v *Validator) ValidateChangePasswordRequest(ctx context.Context, rq *apiv1.ChangePasswordRequest) error {
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
+
+func (v *Validator) ValidateUnlockUserRequest(ctx context.Context, rq *apiv1.UnlockUserRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+func (v *Validator) ValidateImpersonateRequest(ctx context.Context, rq *apiv1.ImpersonateRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
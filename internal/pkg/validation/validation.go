@@ -3,7 +3,15 @@ package validation
 import (
 	"miniblog/internal/apiserver/store"
 	"miniblog/internal/pkg/errno"
+	"miniblog/pkg/authn"
+	genericvalidation "miniblog/pkg/validation"
+	"reflect"
 	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // Validator 是验证逻辑的实现结构体.
@@ -12,29 +20,32 @@ type Validator struct {
 	// 这里只是一个举例，如果验证时，有其他依赖的客户端/服务/资源等，
 	// 都可以一并注入进来
 	store store.IStore
+	// passwordPolicy 定义新密码需要满足的规则
+	passwordPolicy *authn.PasswordPolicy
 }
 
-// New 创建一个新的 Validator 实例.
-func New(store store.IStore) *Validator {
-	return &Validator{store: store}
+// New 创建一个新的 Validator 实例. policy 为空时使用默认密码策略.
+func New(store store.IStore, policy *authn.PasswordPolicy) *Validator {
+	if policy == nil {
+		policy = authn.DefaultPasswordPolicy()
+	}
+	return &Validator{store: store, passwordPolicy: policy}
 }
 
-func isValiPassword(password string) error {
-	if len(password) < 8 || len(password) > 32 {
-		return errno.ErrInvalidArgument.WithMessage("password must be between 8 and 32 characters long")
+// isValiPassword 校验新密码是否满足密码策略.
+func (v *Validator) isValiPassword(password string) error {
+	if err := v.passwordPolicy.Check(password); err != nil {
+		return errno.ErrInvalidArgument.WithMessage("%s", err.Error())
 	}
+	return nil
+}
 
-	// 2. 必须包含字母
-	hasLetter := regexp.MustCompile(`[A-Za-z]`).MatchString(password)
-	// 3. 必须包含数字
-	hasNumber := regexp.MustCompile(`\d`).MatchString(password)
-	// 4. 必须包含特殊字符
-	hasSpecial := regexp.MustCompile(`[\W_]`).MatchString(password)
-
-	if !hasLetter || !hasNumber || !hasSpecial {
-		return errno.ErrInvalidArgument.WithMessage("password must contain at least one letter, one number, and one special character")
+// isNotEmptyPassword 只校验密码非空. 用于校验已有密码（例如登录、修改密码时的旧密码），
+// 避免密码策略收紧后，已有用户无法登录或修改密码.
+func isNotEmptyPassword(password string) error {
+	if password == "" {
+		return errno.ErrInvalidArgument.WithMessage("password cannot be empty")
 	}
-
 	return nil
 }
 
@@ -51,3 +62,31 @@ func isValiName(name string) error {
 
 	return nil
 }
+
+// validateUpdateMask 校验 updateMask 中的字段.
+// 只有请求中的 optional 字段可以更新，其余路径（例如 postID、etag）会被拒绝.
+// 没有设置值的字段会被更新为零值，因此零值也需要满足对应的校验规则，例如博客标题不能被清空.
+func validateUpdateMask(rq proto.Message, mask *fieldmaskpb.FieldMask, rules genericvalidation.Rules) error {
+	fields := rq.ProtoReflect().Descriptor().Fields()
+	value := reflect.Indirect(reflect.ValueOf(rq))
+	for _, path := range mask.GetPaths() {
+		if path == "" {
+			return errno.ErrInvalidArgument.WithMessage("update mask path cannot be empty")
+		}
+		if fd := fields.ByName(protoreflect.Name(path)); fd == nil || !fd.HasOptionalKeyword() {
+			return errno.ErrInvalidArgument.WithMessage("unknown update mask path: %s", path)
+		}
+
+		// 字段名为 updateMask 中的路径首字母大写，例如 title 对应 Title
+		name := strings.ToUpper(path[:1]) + path[1:]
+		rule, ok := rules[name]
+		field := value.FieldByName(name)
+		if !ok || !field.IsValid() || field.Kind() != reflect.Pointer || !field.IsNil() {
+			continue
+		}
+		if err := rule(reflect.Zero(field.Type().Elem()).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+)
+
+func TestValidateUpdateMask(t *testing.T) {
+	v := New(nil, nil)
+	rules := v.ValidatePostRules()
+
+	tests := []struct {
+		name    string
+		rq      *apiv1.UpdatePostRequest
+		paths   []string
+		wantErr bool
+	}{
+		{name: "set fields", rq: &apiv1.UpdatePostRequest{Title: proto.String("title")}, paths: []string{"title"}},
+		{name: "clear content", rq: &apiv1.UpdatePostRequest{}, paths: []string{"content"}},
+		{name: "clear title", rq: &apiv1.UpdatePostRequest{}, paths: []string{"title"}, wantErr: true},
+		{name: "unknown path", rq: &apiv1.UpdatePostRequest{}, paths: []string{"author"}, wantErr: true},
+		{name: "non-optional path", rq: &apiv1.UpdatePostRequest{}, paths: []string{"postID"}, wantErr: true},
+		{name: "empty path", rq: &apiv1.UpdatePostRequest{}, paths: []string{""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpdateMask(tt.rq, &fieldmaskpb.FieldMask{Paths: tt.paths}, rules)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
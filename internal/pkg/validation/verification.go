@@ -0,0 +1,15 @@
+package validation
+
+import (
+	"context"
+	apiv1 "miniblog/pkg/api/apiserver/v1"
+	genericvalidation "miniblog/pkg/validation"
+)
+
+func (v *Validator) ValidateVerifyEmailRequest(ctx context.Context, rq *apiv1.VerifyEmailRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateTokenRules())
+}
+
+func (v *Validator) ValidateResendVerificationRequest(ctx context.Context, rq *apiv1.ResendVerificationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
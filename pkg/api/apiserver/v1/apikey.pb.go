@@ -0,0 +1,557 @@
+// APIKey API 定义，包含个人访问令牌（API Key）的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.20.1
+// source: apiserver/v1/apikey.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// APIKey 表示用户创建的个人访问令牌
+type APIKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// keyID 表示 API Key 的唯一 ID
+	KeyID string `protobuf:"bytes,1,opt,name=keyID,proto3" json:"keyID,omitempty"`
+	// userID 表示 API Key 所属的用户 ID
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// name 表示 API Key 的名称，便于用户区分用途
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// prefix 表示 API Key 的明文前缀，便于用户识别，不能用于认证
+	Prefix string `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// scopes 表示 API Key 的权限范围，例如 posts:read、posts:write
+	Scopes []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// expiresAt 表示 API Key 的过期时间，为空表示永不过期
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// lastUsedAt 表示 API Key 最后一次被使用的时间
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=lastUsedAt,proto3" json:"lastUsedAt,omitempty"`
+	// createdAt 表示 API Key 创建时间
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_apikey_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *APIKey) GetKeyID() string {
+	if x != nil {
+		return x.KeyID
+	}
+	return ""
+}
+
+func (x *APIKey) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// CreateAPIKeyRequest 表示创建 API Key 请求
+type CreateAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name 表示 API Key 的名称
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// scopes 表示 API Key 的权限范围，为空表示不限制（仍受 casbin 授权约束）
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// expiresAt 表示可选的过期时间
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_apikey_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// CreateAPIKeyResponse 表示创建 API Key 响应
+type CreateAPIKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// keyID 表示新创建的 API Key ID
+	KeyID string `protobuf:"bytes,1,opt,name=keyID,proto3" json:"keyID,omitempty"`
+	// secret 表示 API Key 的明文，只会在创建时返回一次
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// apiKey 表示新创建的 API Key 信息
+	ApiKey        *APIKey `protobuf:"bytes,3,opt,name=apiKey,proto3" json:"apiKey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_apikey_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAPIKeyResponse) GetKeyID() string {
+	if x != nil {
+		return x.KeyID
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+// DeleteAPIKeyRequest 表示删除 API Key 请求
+type DeleteAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// keyID 表示要删除的 API Key ID
+	// @gotags: uri:"keyID"
+	KeyID         string `protobuf:"bytes,1,opt,name=keyID,proto3" json:"keyID,omitempty" uri:"keyID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAPIKeyRequest) Reset() {
+	*x = DeleteAPIKeyRequest{}
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAPIKeyRequest) ProtoMessage() {}
+
+func (x *DeleteAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_apikey_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteAPIKeyRequest) GetKeyID() string {
+	if x != nil {
+		return x.KeyID
+	}
+	return ""
+}
+
+// DeleteAPIKeyResponse 表示删除 API Key 响应
+type DeleteAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAPIKeyResponse) Reset() {
+	*x = DeleteAPIKeyResponse{}
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAPIKeyResponse) ProtoMessage() {}
+
+func (x *DeleteAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_apikey_proto_rawDescGZIP(), []int{4}
+}
+
+// ListAPIKeyRequest 表示获取 API Key 列表请求
+type ListAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	// @gotags: form:"offset"
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty" form:"offset"`
+	// limit 表示每页数量
+	// @gotags: form:"limit"
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeyRequest) Reset() {
+	*x = ListAPIKeyRequest{}
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeyRequest) ProtoMessage() {}
+
+func (x *ListAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_apikey_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAPIKeyRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAPIKeyRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListAPIKeyResponse 表示获取 API Key 列表响应
+type ListAPIKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示 API Key 总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// apiKeys 表示 API Key 列表
+	ApiKeys       []*APIKey `protobuf:"bytes,2,rep,name=apiKeys,proto3" json:"apiKeys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeyResponse) Reset() {
+	*x = ListAPIKeyResponse{}
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeyResponse) ProtoMessage() {}
+
+func (x *ListAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_apikey_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_apikey_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAPIKeyResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListAPIKeyResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+var File_apiserver_v1_apikey_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_apikey_proto_rawDesc = "" +
+	"\n" +
+	"\x19apiserver/v1/apikey.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaa\x02\n" +
+	"\x06APIKey\x12\x14\n" +
+	"\x05keyID\x18\x01 \x01(\tR\x05keyID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x04 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x128\n" +
+	"\texpiresAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12:\n" +
+	"\n" +
+	"lastUsedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x128\n" +
+	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"{\n" +
+	"\x13CreateAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x128\n" +
+	"\texpiresAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"h\n" +
+	"\x14CreateAPIKeyResponse\x12\x14\n" +
+	"\x05keyID\x18\x01 \x01(\tR\x05keyID\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\"\n" +
+	"\x06apiKey\x18\x03 \x01(\v2\n" +
+	".v1.APIKeyR\x06apiKey\"+\n" +
+	"\x13DeleteAPIKeyRequest\x12\x14\n" +
+	"\x05keyID\x18\x01 \x01(\tR\x05keyID\"\x16\n" +
+	"\x14DeleteAPIKeyResponse\"A\n" +
+	"\x11ListAPIKeyRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"Z\n" +
+	"\x12ListAPIKeyResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12$\n" +
+	"\aapiKeys\x18\x02 \x03(\v2\n" +
+	".v1.APIKeyR\aapiKeysB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+
+var (
+	file_apiserver_v1_apikey_proto_rawDescOnce sync.Once
+	file_apiserver_v1_apikey_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_apikey_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_apikey_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_apikey_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_apikey_proto_rawDesc), len(file_apiserver_v1_apikey_proto_rawDesc)))
+	})
+	return file_apiserver_v1_apikey_proto_rawDescData
+}
+
+var file_apiserver_v1_apikey_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_apiserver_v1_apikey_proto_goTypes = []any{
+	(*APIKey)(nil),                // 0: v1.APIKey
+	(*CreateAPIKeyRequest)(nil),   // 1: v1.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),  // 2: v1.CreateAPIKeyResponse
+	(*DeleteAPIKeyRequest)(nil),   // 3: v1.DeleteAPIKeyRequest
+	(*DeleteAPIKeyResponse)(nil),  // 4: v1.DeleteAPIKeyResponse
+	(*ListAPIKeyRequest)(nil),     // 5: v1.ListAPIKeyRequest
+	(*ListAPIKeyResponse)(nil),    // 6: v1.ListAPIKeyResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_apiserver_v1_apikey_proto_depIdxs = []int32{
+	7, // 0: v1.APIKey.expiresAt:type_name -> google.protobuf.Timestamp
+	7, // 1: v1.APIKey.lastUsedAt:type_name -> google.protobuf.Timestamp
+	7, // 2: v1.APIKey.createdAt:type_name -> google.protobuf.Timestamp
+	7, // 3: v1.CreateAPIKeyRequest.expiresAt:type_name -> google.protobuf.Timestamp
+	0, // 4: v1.CreateAPIKeyResponse.apiKey:type_name -> v1.APIKey
+	0, // 5: v1.ListAPIKeyResponse.apiKeys:type_name -> v1.APIKey
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_apikey_proto_init() }
+func file_apiserver_v1_apikey_proto_init() {
+	if File_apiserver_v1_apikey_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_apikey_proto_rawDesc), len(file_apiserver_v1_apikey_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_apikey_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_apikey_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_apikey_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_apikey_proto = out.File
+	file_apiserver_v1_apikey_proto_goTypes = nil
+	file_apiserver_v1_apikey_proto_depIdxs = nil
+}
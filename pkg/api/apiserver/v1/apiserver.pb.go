@@ -32,94 +32,303 @@ var File_apiserver_v1_apiserver_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\n" +
-	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/user.proto\x1a\x17apiserver/v1/post.proto2\xa9\t\n" +
+	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/user.proto\x1a\x17apiserver/v1/post.proto\x1a\x19apiserver/v1/apikey.proto\x1a\x16apiserver/v1/mfa.proto\x1a\x1bapiserver/v1/identity.proto\x1a\x1bapiserver/v1/password.proto\x1a\x1fapiserver/v1/verification.proto\x1a\x1dapiserver/v1/invitation.proto\x1a\x1fapiserver/v1/passwordless.proto\x1a\x19apiserver/v1/policy.proto\x1a\x1fapiserver/v1/organization.proto\x1a\x18apiserver/v1/audit.proto\x1a\x18apiserver/v1/quota.proto2\x95+\n" +
 	"\bMiniBlog\x12H\n" +
 	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x13.v1.HealthzResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
 	"\x12\b/healthz\x12Q\n" +
 	"\n" +
-	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12Z\n" +
+	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12s\n" +
 	"\n" +
-	"UpdateUser\x12\x15.v1.UpdateUserRequest\x1a\x16.v1.UpdateUserResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/users/{userID}\x12W\n" +
+	"UpdateUser\x12\x15.v1.UpdateUserRequest\x1a\x16.v1.UpdateUserResponse\"6\x82\xd3\xe4\x93\x020:\x01*Z\x17:\x01*2\x12/v1/users/{userID}\x1a\x12/v1/users/{userID}\x12W\n" +
 	"\n" +
 	"DeleteUser\x12\x15.v1.DeleteUserRequest\x1a\x16.v1.DeleteUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userID}\x12N\n" +
 	"\aGetUser\x12\x12.v1.GetUserRequest\x1a\x13.v1.GetUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userID}\x12H\n" +
 	"\bListUser\x12\x13.v1.ListUserRequest\x1a\x14.v1.ListUserResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12?\n" +
-	"\x05Login\x12\x10.v1.LoginRequest\x1a\x11.v1.LoginResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\\\n" +
+	"\x05Login\x12\x10.v1.LoginRequest\x1a\x11.v1.LoginResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12X\n" +
+	"\vLoginVerify\x12\x16.v1.LoginVerifyRequest\x1a\x17.v1.LoginVerifyResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/login/verify\x12M\n" +
+	"\tOIDCLogin\x12\x14.v1.OIDCLoginRequest\x1a\x15.v1.OIDCLoginResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/login/oidc\x12X\n" +
+	"\fOIDCCallback\x12\x17.v1.OIDCCallbackRequest\x1a\x11.v1.LoginResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/login/oidc/callback\x12\\\n" +
 	"\fRefreshToken\x12\x17.v1.RefreshTokenRequest\x1a\x18.v1.RefreshTokenResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/refresh-token\x12v\n" +
-	"\x0eChangePassword\x12\x19.v1.ChangePasswordRequest\x1a\x1a.v1.ChangePasswordResponse\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/users/{userID}/change-password\x12Q\n" +
+	"\x0eChangePassword\x12\x19.v1.ChangePasswordRequest\x1a\x1a.v1.ChangePasswordResponse\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/users/{userID}/change-password\x12u\n" +
+	"\x14RequestPasswordReset\x12\x1f.v1.RequestPasswordResetRequest\x1a .v1.RequestPasswordResetResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/password-reset\x12h\n" +
+	"\rResetPassword\x12\x18.v1.ResetPasswordRequest\x1a\x19.v1.ResetPasswordResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/password-reset/confirm\x12k\n" +
+	"\x10RequestMagicLink\x12\x1b.v1.RequestMagicLinkRequest\x1a\x1c.v1.RequestMagicLinkResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/login/magic-link\x12d\n" +
+	"\x0eMagicLinkLogin\x12\x19.v1.MagicLinkLoginRequest\x1a\x11.v1.LoginResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/login/magic-link/confirm\x12d\n" +
+	"\x10RequestLoginCode\x12\x1b.v1.RequestLoginCodeRequest\x1a\x1c.v1.RequestLoginCodeResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/login/sms\x12Z\n" +
+	"\rLoginWithCode\x12\x18.v1.LoginWithCodeRequest\x1a\x11.v1.LoginResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/login/sms/verify\x12X\n" +
+	"\vVerifyEmail\x12\x16.v1.VerifyEmailRequest\x1a\x17.v1.VerifyEmailResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/verify-email\x12t\n" +
+	"\x12ResendVerification\x12\x1d.v1.ResendVerificationRequest\x1a\x1e.v1.ResendVerificationResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/verify-email/resend\x12\\\n" +
+	"\fLinkIdentity\x12\x17.v1.LinkIdentityRequest\x1a\x18.v1.LinkIdentityResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/identities\x12j\n" +
+	"\x0eUnlinkIdentity\x12\x19.v1.UnlinkIdentityRequest\x1a\x1a.v1.UnlinkIdentityResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/identities/{provider}\x12a\n" +
 	"\n" +
-	"CreatePost\x12\x15.v1.CreatePostRequest\x1a\x16.v1.CreatePostResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/posts\x12Z\n" +
+	"UnlockUser\x12\x15.v1.UnlockUserRequest\x1a\x16.v1.UnlockUserResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/users/{userID}/unlock\x12i\n" +
+	"\vImpersonate\x12\x16.v1.ImpersonateRequest\x1a\x17.v1.ImpersonateResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/users/{userID}/impersonate\x12Q\n" +
 	"\n" +
-	"UpdatePost\x12\x15.v1.UpdatePostRequest\x1a\x16.v1.UpdatePostResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/posts/{postID}\x12Q\n" +
+	"CreatePost\x12\x15.v1.CreatePostRequest\x1a\x16.v1.CreatePostResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/posts\x12s\n" +
+	"\n" +
+	"UpdatePost\x12\x15.v1.UpdatePostRequest\x1a\x16.v1.UpdatePostResponse\"6\x82\xd3\xe4\x93\x020:\x01*Z\x17:\x01*2\x12/v1/posts/{postID}\x1a\x12/v1/posts/{postID}\x12Q\n" +
 	"\n" +
 	"DeletePost\x12\x15.v1.DeletePostRequest\x1a\x16.v1.DeletePostResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01**\t/v1/posts\x12N\n" +
 	"\aGetPost\x12\x12.v1.GetPostRequest\x1a\x13.v1.GetPostResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/posts/{postID}\x12H\n" +
-	"\bListPost\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/postsB\"Z miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
+	"\bListPost\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/posts\x12N\n" +
+	"\n" +
+	"GetMyQuota\x12\x15.v1.GetMyQuotaRequest\x1a\x16.v1.GetMyQuotaResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/quota\x12Z\n" +
+	"\fCreateAPIKey\x12\x17.v1.CreateAPIKeyRequest\x1a\x18.v1.CreateAPIKeyResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/api-keys\x12_\n" +
+	"\fDeleteAPIKey\x12\x17.v1.DeleteAPIKeyRequest\x1a\x18.v1.DeleteAPIKeyResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/api-keys/{keyID}\x12Q\n" +
+	"\n" +
+	"ListAPIKey\x12\x15.v1.ListAPIKeyRequest\x1a\x16.v1.ListAPIKeyResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/api-keys\x12i\n" +
+	"\x10CreateInvitation\x12\x1b.v1.CreateInvitationRequest\x1a\x1c.v1.CreateInvitationResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/invitations\x12m\n" +
+	"\x10DeleteInvitation\x12\x1b.v1.DeleteInvitationRequest\x1a\x1c.v1.DeleteInvitationResponse\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/v1/invitations/{code}\x12`\n" +
+	"\x0eListInvitation\x12\x19.v1.ListInvitationRequest\x1a\x1a.v1.ListInvitationResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/invitations\x12Q\n" +
+	"\n" +
+	"ListPolicy\x12\x15.v1.ListPolicyRequest\x1a\x16.v1.ListPolicyResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/policies\x12Q\n" +
+	"\tAddPolicy\x12\x14.v1.AddPolicyRequest\x1a\x15.v1.AddPolicyResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/policies\x12Z\n" +
+	"\fRemovePolicy\x12\x17.v1.RemovePolicyRequest\x1a\x18.v1.RemovePolicyResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01**\f/v1/policies\x12q\n" +
+	"\x12ListRoleAssignment\x12\x1d.v1.ListRoleAssignmentRequest\x1a\x1e.v1.ListRoleAssignmentResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/role-assignments\x12q\n" +
+	"\x11AddRoleAssignment\x12\x1c.v1.AddRoleAssignmentRequest\x1a\x1d.v1.AddRoleAssignmentResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/role-assignments\x12z\n" +
+	"\x14RemoveRoleAssignment\x12\x1f.v1.RemoveRoleAssignmentRequest\x1a .v1.RemoveRoleAssignmentResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01**\x14/v1/role-assignments\x12c\n" +
+	"\fGetUserRoles\x12\x17.v1.GetUserRolesRequest\x1a\x18.v1.GetUserRolesResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/users/{userID}/roles\x12w\n" +
+	"\x14ExplainAuthorization\x12\x1f.v1.ExplainAuthorizationRequest\x1a .v1.ExplainAuthorizationResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/policies/explain\x12q\n" +
+	"\x12CreateOrganization\x12\x1d.v1.CreateOrganizationRequest\x1a\x1e.v1.CreateOrganizationResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/organizations\x12v\n" +
+	"\x12DeleteOrganization\x12\x1d.v1.DeleteOrganizationRequest\x1a\x1e.v1.DeleteOrganizationResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/organizations/{orgID}\x12m\n" +
+	"\x0fGetOrganization\x12\x1a.v1.GetOrganizationRequest\x1a\x1b.v1.GetOrganizationResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/organizations/{orgID}\x12h\n" +
+	"\x10ListOrganization\x12\x1b.v1.ListOrganizationRequest\x1a\x1c.v1.ListOrganizationResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/organizations\x12\x8a\x01\n" +
+	"\x15AddOrganizationMember\x12 .v1.AddOrganizationMemberRequest\x1a!.v1.AddOrganizationMemberResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/organizations/{orgID}/members\x12\x99\x01\n" +
+	"\x18RemoveOrganizationMember\x12#.v1.RemoveOrganizationMemberRequest\x1a$.v1.RemoveOrganizationMemberResponse\"2\x82\xd3\xe4\x93\x02,**/v1/organizations/{orgID}/members/{userID}\x12\x8a\x01\n" +
+	"\x16ListOrganizationMember\x12!.v1.ListOrganizationMemberRequest\x1a\".v1.ListOrganizationMemberResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/organizations/{orgID}/members\x12d\n" +
+	"\x0fListAuditEvents\x12\x1a.v1.ListAuditEventsRequest\x1a\x1b.v1.ListAuditEventsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/audit-events\x12[\n" +
+	"\n" +
+	"EnrollTOTP\x12\x15.v1.EnrollTOTPRequest\x1a\x16.v1.EnrollTOTPResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/mfa/totp/enroll\x12[\n" +
+	"\n" +
+	"VerifyTOTP\x12\x15.v1.VerifyTOTPRequest\x1a\x16.v1.VerifyTOTPResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/mfa/totp/verify\x12_\n" +
+	"\vDisableTOTP\x12\x16.v1.DisableTOTPRequest\x1a\x17.v1.DisableTOTPResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/mfa/totp/disableB\"Z miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
 
 var file_apiserver_v1_apiserver_proto_goTypes = []any{
-	(*emptypb.Empty)(nil),          // 0: google.protobuf.Empty
-	(*CreateUserRequest)(nil),      // 1: v1.CreateUserRequest
-	(*UpdateUserRequest)(nil),      // 2: v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),      // 3: v1.DeleteUserRequest
-	(*GetUserRequest)(nil),         // 4: v1.GetUserRequest
-	(*ListUserRequest)(nil),        // 5: v1.ListUserRequest
-	(*LoginRequest)(nil),           // 6: v1.LoginRequest
-	(*RefreshTokenRequest)(nil),    // 7: v1.RefreshTokenRequest
-	(*ChangePasswordRequest)(nil),  // 8: v1.ChangePasswordRequest
-	(*CreatePostRequest)(nil),      // 9: v1.CreatePostRequest
-	(*UpdatePostRequest)(nil),      // 10: v1.UpdatePostRequest
-	(*DeletePostRequest)(nil),      // 11: v1.DeletePostRequest
-	(*GetPostRequest)(nil),         // 12: v1.GetPostRequest
-	(*ListPostRequest)(nil),        // 13: v1.ListPostRequest
-	(*HealthzResponse)(nil),        // 14: v1.HealthzResponse
-	(*CreateUserResponse)(nil),     // 15: v1.CreateUserResponse
-	(*UpdateUserResponse)(nil),     // 16: v1.UpdateUserResponse
-	(*DeleteUserResponse)(nil),     // 17: v1.DeleteUserResponse
-	(*GetUserResponse)(nil),        // 18: v1.GetUserResponse
-	(*ListUserResponse)(nil),       // 19: v1.ListUserResponse
-	(*LoginResponse)(nil),          // 20: v1.LoginResponse
-	(*RefreshTokenResponse)(nil),   // 21: v1.RefreshTokenResponse
-	(*ChangePasswordResponse)(nil), // 22: v1.ChangePasswordResponse
-	(*CreatePostResponse)(nil),     // 23: v1.CreatePostResponse
-	(*UpdatePostResponse)(nil),     // 24: v1.UpdatePostResponse
-	(*DeletePostResponse)(nil),     // 25: v1.DeletePostResponse
-	(*GetPostResponse)(nil),        // 26: v1.GetPostResponse
-	(*ListPostResponse)(nil),       // 27: v1.ListPostResponse
+	(*emptypb.Empty)(nil),                    // 0: google.protobuf.Empty
+	(*CreateUserRequest)(nil),                // 1: v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),                // 2: v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),                // 3: v1.DeleteUserRequest
+	(*GetUserRequest)(nil),                   // 4: v1.GetUserRequest
+	(*ListUserRequest)(nil),                  // 5: v1.ListUserRequest
+	(*LoginRequest)(nil),                     // 6: v1.LoginRequest
+	(*LoginVerifyRequest)(nil),               // 7: v1.LoginVerifyRequest
+	(*OIDCLoginRequest)(nil),                 // 8: v1.OIDCLoginRequest
+	(*OIDCCallbackRequest)(nil),              // 9: v1.OIDCCallbackRequest
+	(*RefreshTokenRequest)(nil),              // 10: v1.RefreshTokenRequest
+	(*ChangePasswordRequest)(nil),            // 11: v1.ChangePasswordRequest
+	(*RequestPasswordResetRequest)(nil),      // 12: v1.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),             // 13: v1.ResetPasswordRequest
+	(*RequestMagicLinkRequest)(nil),          // 14: v1.RequestMagicLinkRequest
+	(*MagicLinkLoginRequest)(nil),            // 15: v1.MagicLinkLoginRequest
+	(*RequestLoginCodeRequest)(nil),          // 16: v1.RequestLoginCodeRequest
+	(*LoginWithCodeRequest)(nil),             // 17: v1.LoginWithCodeRequest
+	(*VerifyEmailRequest)(nil),               // 18: v1.VerifyEmailRequest
+	(*ResendVerificationRequest)(nil),        // 19: v1.ResendVerificationRequest
+	(*LinkIdentityRequest)(nil),              // 20: v1.LinkIdentityRequest
+	(*UnlinkIdentityRequest)(nil),            // 21: v1.UnlinkIdentityRequest
+	(*UnlockUserRequest)(nil),                // 22: v1.UnlockUserRequest
+	(*ImpersonateRequest)(nil),               // 23: v1.ImpersonateRequest
+	(*CreatePostRequest)(nil),                // 24: v1.CreatePostRequest
+	(*UpdatePostRequest)(nil),                // 25: v1.UpdatePostRequest
+	(*DeletePostRequest)(nil),                // 26: v1.DeletePostRequest
+	(*GetPostRequest)(nil),                   // 27: v1.GetPostRequest
+	(*ListPostRequest)(nil),                  // 28: v1.ListPostRequest
+	(*GetMyQuotaRequest)(nil),                // 29: v1.GetMyQuotaRequest
+	(*CreateAPIKeyRequest)(nil),              // 30: v1.CreateAPIKeyRequest
+	(*DeleteAPIKeyRequest)(nil),              // 31: v1.DeleteAPIKeyRequest
+	(*ListAPIKeyRequest)(nil),                // 32: v1.ListAPIKeyRequest
+	(*CreateInvitationRequest)(nil),          // 33: v1.CreateInvitationRequest
+	(*DeleteInvitationRequest)(nil),          // 34: v1.DeleteInvitationRequest
+	(*ListInvitationRequest)(nil),            // 35: v1.ListInvitationRequest
+	(*ListPolicyRequest)(nil),                // 36: v1.ListPolicyRequest
+	(*AddPolicyRequest)(nil),                 // 37: v1.AddPolicyRequest
+	(*RemovePolicyRequest)(nil),              // 38: v1.RemovePolicyRequest
+	(*ListRoleAssignmentRequest)(nil),        // 39: v1.ListRoleAssignmentRequest
+	(*AddRoleAssignmentRequest)(nil),         // 40: v1.AddRoleAssignmentRequest
+	(*RemoveRoleAssignmentRequest)(nil),      // 41: v1.RemoveRoleAssignmentRequest
+	(*GetUserRolesRequest)(nil),              // 42: v1.GetUserRolesRequest
+	(*ExplainAuthorizationRequest)(nil),      // 43: v1.ExplainAuthorizationRequest
+	(*CreateOrganizationRequest)(nil),        // 44: v1.CreateOrganizationRequest
+	(*DeleteOrganizationRequest)(nil),        // 45: v1.DeleteOrganizationRequest
+	(*GetOrganizationRequest)(nil),           // 46: v1.GetOrganizationRequest
+	(*ListOrganizationRequest)(nil),          // 47: v1.ListOrganizationRequest
+	(*AddOrganizationMemberRequest)(nil),     // 48: v1.AddOrganizationMemberRequest
+	(*RemoveOrganizationMemberRequest)(nil),  // 49: v1.RemoveOrganizationMemberRequest
+	(*ListOrganizationMemberRequest)(nil),    // 50: v1.ListOrganizationMemberRequest
+	(*ListAuditEventsRequest)(nil),           // 51: v1.ListAuditEventsRequest
+	(*EnrollTOTPRequest)(nil),                // 52: v1.EnrollTOTPRequest
+	(*VerifyTOTPRequest)(nil),                // 53: v1.VerifyTOTPRequest
+	(*DisableTOTPRequest)(nil),               // 54: v1.DisableTOTPRequest
+	(*HealthzResponse)(nil),                  // 55: v1.HealthzResponse
+	(*CreateUserResponse)(nil),               // 56: v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),               // 57: v1.UpdateUserResponse
+	(*DeleteUserResponse)(nil),               // 58: v1.DeleteUserResponse
+	(*GetUserResponse)(nil),                  // 59: v1.GetUserResponse
+	(*ListUserResponse)(nil),                 // 60: v1.ListUserResponse
+	(*LoginResponse)(nil),                    // 61: v1.LoginResponse
+	(*LoginVerifyResponse)(nil),              // 62: v1.LoginVerifyResponse
+	(*OIDCLoginResponse)(nil),                // 63: v1.OIDCLoginResponse
+	(*RefreshTokenResponse)(nil),             // 64: v1.RefreshTokenResponse
+	(*ChangePasswordResponse)(nil),           // 65: v1.ChangePasswordResponse
+	(*RequestPasswordResetResponse)(nil),     // 66: v1.RequestPasswordResetResponse
+	(*ResetPasswordResponse)(nil),            // 67: v1.ResetPasswordResponse
+	(*RequestMagicLinkResponse)(nil),         // 68: v1.RequestMagicLinkResponse
+	(*RequestLoginCodeResponse)(nil),         // 69: v1.RequestLoginCodeResponse
+	(*VerifyEmailResponse)(nil),              // 70: v1.VerifyEmailResponse
+	(*ResendVerificationResponse)(nil),       // 71: v1.ResendVerificationResponse
+	(*LinkIdentityResponse)(nil),             // 72: v1.LinkIdentityResponse
+	(*UnlinkIdentityResponse)(nil),           // 73: v1.UnlinkIdentityResponse
+	(*UnlockUserResponse)(nil),               // 74: v1.UnlockUserResponse
+	(*ImpersonateResponse)(nil),              // 75: v1.ImpersonateResponse
+	(*CreatePostResponse)(nil),               // 76: v1.CreatePostResponse
+	(*UpdatePostResponse)(nil),               // 77: v1.UpdatePostResponse
+	(*DeletePostResponse)(nil),               // 78: v1.DeletePostResponse
+	(*GetPostResponse)(nil),                  // 79: v1.GetPostResponse
+	(*ListPostResponse)(nil),                 // 80: v1.ListPostResponse
+	(*GetMyQuotaResponse)(nil),               // 81: v1.GetMyQuotaResponse
+	(*CreateAPIKeyResponse)(nil),             // 82: v1.CreateAPIKeyResponse
+	(*DeleteAPIKeyResponse)(nil),             // 83: v1.DeleteAPIKeyResponse
+	(*ListAPIKeyResponse)(nil),               // 84: v1.ListAPIKeyResponse
+	(*CreateInvitationResponse)(nil),         // 85: v1.CreateInvitationResponse
+	(*DeleteInvitationResponse)(nil),         // 86: v1.DeleteInvitationResponse
+	(*ListInvitationResponse)(nil),           // 87: v1.ListInvitationResponse
+	(*ListPolicyResponse)(nil),               // 88: v1.ListPolicyResponse
+	(*AddPolicyResponse)(nil),                // 89: v1.AddPolicyResponse
+	(*RemovePolicyResponse)(nil),             // 90: v1.RemovePolicyResponse
+	(*ListRoleAssignmentResponse)(nil),       // 91: v1.ListRoleAssignmentResponse
+	(*AddRoleAssignmentResponse)(nil),        // 92: v1.AddRoleAssignmentResponse
+	(*RemoveRoleAssignmentResponse)(nil),     // 93: v1.RemoveRoleAssignmentResponse
+	(*GetUserRolesResponse)(nil),             // 94: v1.GetUserRolesResponse
+	(*ExplainAuthorizationResponse)(nil),     // 95: v1.ExplainAuthorizationResponse
+	(*CreateOrganizationResponse)(nil),       // 96: v1.CreateOrganizationResponse
+	(*DeleteOrganizationResponse)(nil),       // 97: v1.DeleteOrganizationResponse
+	(*GetOrganizationResponse)(nil),          // 98: v1.GetOrganizationResponse
+	(*ListOrganizationResponse)(nil),         // 99: v1.ListOrganizationResponse
+	(*AddOrganizationMemberResponse)(nil),    // 100: v1.AddOrganizationMemberResponse
+	(*RemoveOrganizationMemberResponse)(nil), // 101: v1.RemoveOrganizationMemberResponse
+	(*ListOrganizationMemberResponse)(nil),   // 102: v1.ListOrganizationMemberResponse
+	(*ListAuditEventsResponse)(nil),          // 103: v1.ListAuditEventsResponse
+	(*EnrollTOTPResponse)(nil),               // 104: v1.EnrollTOTPResponse
+	(*VerifyTOTPResponse)(nil),               // 105: v1.VerifyTOTPResponse
+	(*DisableTOTPResponse)(nil),              // 106: v1.DisableTOTPResponse
 }
 var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
-	0,  // 0: v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
-	1,  // 1: v1.MiniBlog.CreateUser:input_type -> v1.CreateUserRequest
-	2,  // 2: v1.MiniBlog.UpdateUser:input_type -> v1.UpdateUserRequest
-	3,  // 3: v1.MiniBlog.DeleteUser:input_type -> v1.DeleteUserRequest
-	4,  // 4: v1.MiniBlog.GetUser:input_type -> v1.GetUserRequest
-	5,  // 5: v1.MiniBlog.ListUser:input_type -> v1.ListUserRequest
-	6,  // 6: v1.MiniBlog.Login:input_type -> v1.LoginRequest
-	7,  // 7: v1.MiniBlog.RefreshToken:input_type -> v1.RefreshTokenRequest
-	8,  // 8: v1.MiniBlog.ChangePassword:input_type -> v1.ChangePasswordRequest
-	9,  // 9: v1.MiniBlog.CreatePost:input_type -> v1.CreatePostRequest
-	10, // 10: v1.MiniBlog.UpdatePost:input_type -> v1.UpdatePostRequest
-	11, // 11: v1.MiniBlog.DeletePost:input_type -> v1.DeletePostRequest
-	12, // 12: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
-	13, // 13: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
-	14, // 14: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
-	15, // 15: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
-	16, // 16: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
-	17, // 17: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
-	18, // 18: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
-	19, // 19: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
-	20, // 20: v1.MiniBlog.Login:output_type -> v1.LoginResponse
-	21, // 21: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
-	22, // 22: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
-	23, // 23: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
-	24, // 24: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
-	25, // 25: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
-	26, // 26: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
-	27, // 27: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
-	14, // [14:28] is the sub-list for method output_type
-	0,  // [0:14] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	0,   // 0: v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
+	1,   // 1: v1.MiniBlog.CreateUser:input_type -> v1.CreateUserRequest
+	2,   // 2: v1.MiniBlog.UpdateUser:input_type -> v1.UpdateUserRequest
+	3,   // 3: v1.MiniBlog.DeleteUser:input_type -> v1.DeleteUserRequest
+	4,   // 4: v1.MiniBlog.GetUser:input_type -> v1.GetUserRequest
+	5,   // 5: v1.MiniBlog.ListUser:input_type -> v1.ListUserRequest
+	6,   // 6: v1.MiniBlog.Login:input_type -> v1.LoginRequest
+	7,   // 7: v1.MiniBlog.LoginVerify:input_type -> v1.LoginVerifyRequest
+	8,   // 8: v1.MiniBlog.OIDCLogin:input_type -> v1.OIDCLoginRequest
+	9,   // 9: v1.MiniBlog.OIDCCallback:input_type -> v1.OIDCCallbackRequest
+	10,  // 10: v1.MiniBlog.RefreshToken:input_type -> v1.RefreshTokenRequest
+	11,  // 11: v1.MiniBlog.ChangePassword:input_type -> v1.ChangePasswordRequest
+	12,  // 12: v1.MiniBlog.RequestPasswordReset:input_type -> v1.RequestPasswordResetRequest
+	13,  // 13: v1.MiniBlog.ResetPassword:input_type -> v1.ResetPasswordRequest
+	14,  // 14: v1.MiniBlog.RequestMagicLink:input_type -> v1.RequestMagicLinkRequest
+	15,  // 15: v1.MiniBlog.MagicLinkLogin:input_type -> v1.MagicLinkLoginRequest
+	16,  // 16: v1.MiniBlog.RequestLoginCode:input_type -> v1.RequestLoginCodeRequest
+	17,  // 17: v1.MiniBlog.LoginWithCode:input_type -> v1.LoginWithCodeRequest
+	18,  // 18: v1.MiniBlog.VerifyEmail:input_type -> v1.VerifyEmailRequest
+	19,  // 19: v1.MiniBlog.ResendVerification:input_type -> v1.ResendVerificationRequest
+	20,  // 20: v1.MiniBlog.LinkIdentity:input_type -> v1.LinkIdentityRequest
+	21,  // 21: v1.MiniBlog.UnlinkIdentity:input_type -> v1.UnlinkIdentityRequest
+	22,  // 22: v1.MiniBlog.UnlockUser:input_type -> v1.UnlockUserRequest
+	23,  // 23: v1.MiniBlog.Impersonate:input_type -> v1.ImpersonateRequest
+	24,  // 24: v1.MiniBlog.CreatePost:input_type -> v1.CreatePostRequest
+	25,  // 25: v1.MiniBlog.UpdatePost:input_type -> v1.UpdatePostRequest
+	26,  // 26: v1.MiniBlog.DeletePost:input_type -> v1.DeletePostRequest
+	27,  // 27: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
+	28,  // 28: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
+	29,  // 29: v1.MiniBlog.GetMyQuota:input_type -> v1.GetMyQuotaRequest
+	30,  // 30: v1.MiniBlog.CreateAPIKey:input_type -> v1.CreateAPIKeyRequest
+	31,  // 31: v1.MiniBlog.DeleteAPIKey:input_type -> v1.DeleteAPIKeyRequest
+	32,  // 32: v1.MiniBlog.ListAPIKey:input_type -> v1.ListAPIKeyRequest
+	33,  // 33: v1.MiniBlog.CreateInvitation:input_type -> v1.CreateInvitationRequest
+	34,  // 34: v1.MiniBlog.DeleteInvitation:input_type -> v1.DeleteInvitationRequest
+	35,  // 35: v1.MiniBlog.ListInvitation:input_type -> v1.ListInvitationRequest
+	36,  // 36: v1.MiniBlog.ListPolicy:input_type -> v1.ListPolicyRequest
+	37,  // 37: v1.MiniBlog.AddPolicy:input_type -> v1.AddPolicyRequest
+	38,  // 38: v1.MiniBlog.RemovePolicy:input_type -> v1.RemovePolicyRequest
+	39,  // 39: v1.MiniBlog.ListRoleAssignment:input_type -> v1.ListRoleAssignmentRequest
+	40,  // 40: v1.MiniBlog.AddRoleAssignment:input_type -> v1.AddRoleAssignmentRequest
+	41,  // 41: v1.MiniBlog.RemoveRoleAssignment:input_type -> v1.RemoveRoleAssignmentRequest
+	42,  // 42: v1.MiniBlog.GetUserRoles:input_type -> v1.GetUserRolesRequest
+	43,  // 43: v1.MiniBlog.ExplainAuthorization:input_type -> v1.ExplainAuthorizationRequest
+	44,  // 44: v1.MiniBlog.CreateOrganization:input_type -> v1.CreateOrganizationRequest
+	45,  // 45: v1.MiniBlog.DeleteOrganization:input_type -> v1.DeleteOrganizationRequest
+	46,  // 46: v1.MiniBlog.GetOrganization:input_type -> v1.GetOrganizationRequest
+	47,  // 47: v1.MiniBlog.ListOrganization:input_type -> v1.ListOrganizationRequest
+	48,  // 48: v1.MiniBlog.AddOrganizationMember:input_type -> v1.AddOrganizationMemberRequest
+	49,  // 49: v1.MiniBlog.RemoveOrganizationMember:input_type -> v1.RemoveOrganizationMemberRequest
+	50,  // 50: v1.MiniBlog.ListOrganizationMember:input_type -> v1.ListOrganizationMemberRequest
+	51,  // 51: v1.MiniBlog.ListAuditEvents:input_type -> v1.ListAuditEventsRequest
+	52,  // 52: v1.MiniBlog.EnrollTOTP:input_type -> v1.EnrollTOTPRequest
+	53,  // 53: v1.MiniBlog.VerifyTOTP:input_type -> v1.VerifyTOTPRequest
+	54,  // 54: v1.MiniBlog.DisableTOTP:input_type -> v1.DisableTOTPRequest
+	55,  // 55: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
+	56,  // 56: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
+	57,  // 57: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
+	58,  // 58: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
+	59,  // 59: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
+	60,  // 60: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
+	61,  // 61: v1.MiniBlog.Login:output_type -> v1.LoginResponse
+	62,  // 62: v1.MiniBlog.LoginVerify:output_type -> v1.LoginVerifyResponse
+	63,  // 63: v1.MiniBlog.OIDCLogin:output_type -> v1.OIDCLoginResponse
+	61,  // 64: v1.MiniBlog.OIDCCallback:output_type -> v1.LoginResponse
+	64,  // 65: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
+	65,  // 66: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
+	66,  // 67: v1.MiniBlog.RequestPasswordReset:output_type -> v1.RequestPasswordResetResponse
+	67,  // 68: v1.MiniBlog.ResetPassword:output_type -> v1.ResetPasswordResponse
+	68,  // 69: v1.MiniBlog.RequestMagicLink:output_type -> v1.RequestMagicLinkResponse
+	61,  // 70: v1.MiniBlog.MagicLinkLogin:output_type -> v1.LoginResponse
+	69,  // 71: v1.MiniBlog.RequestLoginCode:output_type -> v1.RequestLoginCodeResponse
+	61,  // 72: v1.MiniBlog.LoginWithCode:output_type -> v1.LoginResponse
+	70,  // 73: v1.MiniBlog.VerifyEmail:output_type -> v1.VerifyEmailResponse
+	71,  // 74: v1.MiniBlog.ResendVerification:output_type -> v1.ResendVerificationResponse
+	72,  // 75: v1.MiniBlog.LinkIdentity:output_type -> v1.LinkIdentityResponse
+	73,  // 76: v1.MiniBlog.UnlinkIdentity:output_type -> v1.UnlinkIdentityResponse
+	74,  // 77: v1.MiniBlog.UnlockUser:output_type -> v1.UnlockUserResponse
+	75,  // 78: v1.MiniBlog.Impersonate:output_type -> v1.ImpersonateResponse
+	76,  // 79: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
+	77,  // 80: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
+	78,  // 81: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
+	79,  // 82: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
+	80,  // 83: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
+	81,  // 84: v1.MiniBlog.GetMyQuota:output_type -> v1.GetMyQuotaResponse
+	82,  // 85: v1.MiniBlog.CreateAPIKey:output_type -> v1.CreateAPIKeyResponse
+	83,  // 86: v1.MiniBlog.DeleteAPIKey:output_type -> v1.DeleteAPIKeyResponse
+	84,  // 87: v1.MiniBlog.ListAPIKey:output_type -> v1.ListAPIKeyResponse
+	85,  // 88: v1.MiniBlog.CreateInvitation:output_type -> v1.CreateInvitationResponse
+	86,  // 89: v1.MiniBlog.DeleteInvitation:output_type -> v1.DeleteInvitationResponse
+	87,  // 90: v1.MiniBlog.ListInvitation:output_type -> v1.ListInvitationResponse
+	88,  // 91: v1.MiniBlog.ListPolicy:output_type -> v1.ListPolicyResponse
+	89,  // 92: v1.MiniBlog.AddPolicy:output_type -> v1.AddPolicyResponse
+	90,  // 93: v1.MiniBlog.RemovePolicy:output_type -> v1.RemovePolicyResponse
+	91,  // 94: v1.MiniBlog.ListRoleAssignment:output_type -> v1.ListRoleAssignmentResponse
+	92,  // 95: v1.MiniBlog.AddRoleAssignment:output_type -> v1.AddRoleAssignmentResponse
+	93,  // 96: v1.MiniBlog.RemoveRoleAssignment:output_type -> v1.RemoveRoleAssignmentResponse
+	94,  // 97: v1.MiniBlog.GetUserRoles:output_type -> v1.GetUserRolesResponse
+	95,  // 98: v1.MiniBlog.ExplainAuthorization:output_type -> v1.ExplainAuthorizationResponse
+	96,  // 99: v1.MiniBlog.CreateOrganization:output_type -> v1.CreateOrganizationResponse
+	97,  // 100: v1.MiniBlog.DeleteOrganization:output_type -> v1.DeleteOrganizationResponse
+	98,  // 101: v1.MiniBlog.GetOrganization:output_type -> v1.GetOrganizationResponse
+	99,  // 102: v1.MiniBlog.ListOrganization:output_type -> v1.ListOrganizationResponse
+	100, // 103: v1.MiniBlog.AddOrganizationMember:output_type -> v1.AddOrganizationMemberResponse
+	101, // 104: v1.MiniBlog.RemoveOrganizationMember:output_type -> v1.RemoveOrganizationMemberResponse
+	102, // 105: v1.MiniBlog.ListOrganizationMember:output_type -> v1.ListOrganizationMemberResponse
+	103, // 106: v1.MiniBlog.ListAuditEvents:output_type -> v1.ListAuditEventsResponse
+	104, // 107: v1.MiniBlog.EnrollTOTP:output_type -> v1.EnrollTOTPResponse
+	105, // 108: v1.MiniBlog.VerifyTOTP:output_type -> v1.VerifyTOTPResponse
+	106, // 109: v1.MiniBlog.DisableTOTP:output_type -> v1.DisableTOTPResponse
+	55,  // [55:110] is the sub-list for method output_type
+	0,   // [0:55] is the sub-list for method input_type
+	0,   // [0:0] is the sub-list for extension type_name
+	0,   // [0:0] is the sub-list for extension extendee
+	0,   // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_apiserver_proto_init() }
@@ -130,6 +339,17 @@ func file_apiserver_v1_apiserver_proto_init() {
 	file_apiserver_v1_healthz_proto_init()
 	file_apiserver_v1_user_proto_init()
 	file_apiserver_v1_post_proto_init()
+	file_apiserver_v1_apikey_proto_init()
+	file_apiserver_v1_mfa_proto_init()
+	file_apiserver_v1_identity_proto_init()
+	file_apiserver_v1_password_proto_init()
+	file_apiserver_v1_verification_proto_init()
+	file_apiserver_v1_invitation_proto_init()
+	file_apiserver_v1_passwordless_proto_init()
+	file_apiserver_v1_policy_proto_init()
+	file_apiserver_v1_organization_proto_init()
+	file_apiserver_v1_audit_proto_init()
+	file_apiserver_v1_quota_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -26,20 +26,61 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MiniBlog_Healthz_FullMethodName        = "/v1.MiniBlog/Healthz"
-	MiniBlog_CreateUser_FullMethodName     = "/v1.MiniBlog/CreateUser"
-	MiniBlog_UpdateUser_FullMethodName     = "/v1.MiniBlog/UpdateUser"
-	MiniBlog_DeleteUser_FullMethodName     = "/v1.MiniBlog/DeleteUser"
-	MiniBlog_GetUser_FullMethodName        = "/v1.MiniBlog/GetUser"
-	MiniBlog_ListUser_FullMethodName       = "/v1.MiniBlog/ListUser"
-	MiniBlog_Login_FullMethodName          = "/v1.MiniBlog/Login"
-	MiniBlog_RefreshToken_FullMethodName   = "/v1.MiniBlog/RefreshToken"
-	MiniBlog_ChangePassword_FullMethodName = "/v1.MiniBlog/ChangePassword"
-	MiniBlog_CreatePost_FullMethodName     = "/v1.MiniBlog/CreatePost"
-	MiniBlog_UpdatePost_FullMethodName     = "/v1.MiniBlog/UpdatePost"
-	MiniBlog_DeletePost_FullMethodName     = "/v1.MiniBlog/DeletePost"
-	MiniBlog_GetPost_FullMethodName        = "/v1.MiniBlog/GetPost"
-	MiniBlog_ListPost_FullMethodName       = "/v1.MiniBlog/ListPost"
+	MiniBlog_Healthz_FullMethodName                  = "/v1.MiniBlog/Healthz"
+	MiniBlog_CreateUser_FullMethodName               = "/v1.MiniBlog/CreateUser"
+	MiniBlog_UpdateUser_FullMethodName               = "/v1.MiniBlog/UpdateUser"
+	MiniBlog_DeleteUser_FullMethodName               = "/v1.MiniBlog/DeleteUser"
+	MiniBlog_GetUser_FullMethodName                  = "/v1.MiniBlog/GetUser"
+	MiniBlog_ListUser_FullMethodName                 = "/v1.MiniBlog/ListUser"
+	MiniBlog_Login_FullMethodName                    = "/v1.MiniBlog/Login"
+	MiniBlog_LoginVerify_FullMethodName              = "/v1.MiniBlog/LoginVerify"
+	MiniBlog_OIDCLogin_FullMethodName                = "/v1.MiniBlog/OIDCLogin"
+	MiniBlog_OIDCCallback_FullMethodName             = "/v1.MiniBlog/OIDCCallback"
+	MiniBlog_RefreshToken_FullMethodName             = "/v1.MiniBlog/RefreshToken"
+	MiniBlog_ChangePassword_FullMethodName           = "/v1.MiniBlog/ChangePassword"
+	MiniBlog_RequestPasswordReset_FullMethodName     = "/v1.MiniBlog/RequestPasswordReset"
+	MiniBlog_ResetPassword_FullMethodName            = "/v1.MiniBlog/ResetPassword"
+	MiniBlog_RequestMagicLink_FullMethodName         = "/v1.MiniBlog/RequestMagicLink"
+	MiniBlog_MagicLinkLogin_FullMethodName           = "/v1.MiniBlog/MagicLinkLogin"
+	MiniBlog_RequestLoginCode_FullMethodName         = "/v1.MiniBlog/RequestLoginCode"
+	MiniBlog_LoginWithCode_FullMethodName            = "/v1.MiniBlog/LoginWithCode"
+	MiniBlog_VerifyEmail_FullMethodName              = "/v1.MiniBlog/VerifyEmail"
+	MiniBlog_ResendVerification_FullMethodName       = "/v1.MiniBlog/ResendVerification"
+	MiniBlog_LinkIdentity_FullMethodName             = "/v1.MiniBlog/LinkIdentity"
+	MiniBlog_UnlinkIdentity_FullMethodName           = "/v1.MiniBlog/UnlinkIdentity"
+	MiniBlog_UnlockUser_FullMethodName               = "/v1.MiniBlog/UnlockUser"
+	MiniBlog_Impersonate_FullMethodName              = "/v1.MiniBlog/Impersonate"
+	MiniBlog_CreatePost_FullMethodName               = "/v1.MiniBlog/CreatePost"
+	MiniBlog_UpdatePost_FullMethodName               = "/v1.MiniBlog/UpdatePost"
+	MiniBlog_DeletePost_FullMethodName               = "/v1.MiniBlog/DeletePost"
+	MiniBlog_GetPost_FullMethodName                  = "/v1.MiniBlog/GetPost"
+	MiniBlog_ListPost_FullMethodName                 = "/v1.MiniBlog/ListPost"
+	MiniBlog_GetMyQuota_FullMethodName               = "/v1.MiniBlog/GetMyQuota"
+	MiniBlog_CreateAPIKey_FullMethodName             = "/v1.MiniBlog/CreateAPIKey"
+	MiniBlog_DeleteAPIKey_FullMethodName             = "/v1.MiniBlog/DeleteAPIKey"
+	MiniBlog_ListAPIKey_FullMethodName               = "/v1.MiniBlog/ListAPIKey"
+	MiniBlog_CreateInvitation_FullMethodName         = "/v1.MiniBlog/CreateInvitation"
+	MiniBlog_DeleteInvitation_FullMethodName         = "/v1.MiniBlog/DeleteInvitation"
+	MiniBlog_ListInvitation_FullMethodName           = "/v1.MiniBlog/ListInvitation"
+	MiniBlog_ListPolicy_FullMethodName               = "/v1.MiniBlog/ListPolicy"
+	MiniBlog_AddPolicy_FullMethodName                = "/v1.MiniBlog/AddPolicy"
+	MiniBlog_RemovePolicy_FullMethodName             = "/v1.MiniBlog/RemovePolicy"
+	MiniBlog_ListRoleAssignment_FullMethodName       = "/v1.MiniBlog/ListRoleAssignment"
+	MiniBlog_AddRoleAssignment_FullMethodName        = "/v1.MiniBlog/AddRoleAssignment"
+	MiniBlog_RemoveRoleAssignment_FullMethodName     = "/v1.MiniBlog/RemoveRoleAssignment"
+	MiniBlog_GetUserRoles_FullMethodName             = "/v1.MiniBlog/GetUserRoles"
+	MiniBlog_ExplainAuthorization_FullMethodName     = "/v1.MiniBlog/ExplainAuthorization"
+	MiniBlog_CreateOrganization_FullMethodName       = "/v1.MiniBlog/CreateOrganization"
+	MiniBlog_DeleteOrganization_FullMethodName       = "/v1.MiniBlog/DeleteOrganization"
+	MiniBlog_GetOrganization_FullMethodName          = "/v1.MiniBlog/GetOrganization"
+	MiniBlog_ListOrganization_FullMethodName         = "/v1.MiniBlog/ListOrganization"
+	MiniBlog_AddOrganizationMember_FullMethodName    = "/v1.MiniBlog/AddOrganizationMember"
+	MiniBlog_RemoveOrganizationMember_FullMethodName = "/v1.MiniBlog/RemoveOrganizationMember"
+	MiniBlog_ListOrganizationMember_FullMethodName   = "/v1.MiniBlog/ListOrganizationMember"
+	MiniBlog_ListAuditEvents_FullMethodName          = "/v1.MiniBlog/ListAuditEvents"
+	MiniBlog_EnrollTOTP_FullMethodName               = "/v1.MiniBlog/EnrollTOTP"
+	MiniBlog_VerifyTOTP_FullMethodName               = "/v1.MiniBlog/VerifyTOTP"
+	MiniBlog_DisableTOTP_FullMethodName              = "/v1.MiniBlog/DisableTOTP"
 )
 
 // MiniBlogClient is the client API for MiniBlog service.
@@ -62,10 +103,40 @@ type MiniBlogClient interface {
 	ListUser(ctx context.Context, in *ListUserRequest, opts ...grpc.CallOption) (*ListUserResponse, error)
 	// Login 用户登录
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// LoginVerify 使用两步验证码完成登录
+	LoginVerify(ctx context.Context, in *LoginVerifyRequest, opts ...grpc.CallOption) (*LoginVerifyResponse, error)
+	// OIDCLogin 发起 OIDC 登录，返回授权地址
+	OIDCLogin(ctx context.Context, in *OIDCLoginRequest, opts ...grpc.CallOption) (*OIDCLoginResponse, error)
+	// OIDCCallback 处理 OIDC 授权回调，完成登录
+	OIDCCallback(ctx context.Context, in *OIDCCallbackRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	// RefreshToken 刷新 Token
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
 	// ChangePassword 更改密码
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	// RequestPasswordReset 申请重置密码，向用户邮箱发送一次性重置链接
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetResponse, error)
+	// ResetPassword 使用一次性令牌重置密码
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
+	// RequestMagicLink 向邮箱发送一次性登录链接
+	RequestMagicLink(ctx context.Context, in *RequestMagicLinkRequest, opts ...grpc.CallOption) (*RequestMagicLinkResponse, error)
+	// MagicLinkLogin 使用邮件中的一次性登录链接登录
+	MagicLinkLogin(ctx context.Context, in *MagicLinkLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// RequestLoginCode 向手机号发送一次性登录验证码
+	RequestLoginCode(ctx context.Context, in *RequestLoginCodeRequest, opts ...grpc.CallOption) (*RequestLoginCodeResponse, error)
+	// LoginWithCode 使用短信中的一次性验证码登录
+	LoginWithCode(ctx context.Context, in *LoginWithCodeRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// VerifyEmail 使用验证邮件中的令牌验证邮箱
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	// ResendVerification 重新发送邮箱验证邮件
+	ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...grpc.CallOption) (*ResendVerificationResponse, error)
+	// LinkIdentity 将外部身份关联到当前用户
+	LinkIdentity(ctx context.Context, in *LinkIdentityRequest, opts ...grpc.CallOption) (*LinkIdentityResponse, error)
+	// UnlinkIdentity 解除当前用户与外部身份的关联
+	UnlinkIdentity(ctx context.Context, in *UnlinkIdentityRequest, opts ...grpc.CallOption) (*UnlinkIdentityResponse, error)
+	// UnlockUser 解除用户的登录锁定，仅管理员可调用
+	UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error)
+	// Impersonate 以指定用户的身份签发短期令牌，用于复现用户遇到的问题，仅管理员可调用
+	Impersonate(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*ImpersonateResponse, error)
 	// CreatePost 创建博客帖子
 	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*CreatePostResponse, error)
 	// UpdatePost 更新博客帖子
@@ -76,6 +147,58 @@ type MiniBlogClient interface {
 	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*GetPostResponse, error)
 	// ListPost 列出所有博客帖子
 	ListPost(ctx context.Context, in *ListPostRequest, opts ...grpc.CallOption) (*ListPostResponse, error)
+	// GetMyQuota 查询当前用户的内容配额及其用量
+	GetMyQuota(ctx context.Context, in *GetMyQuotaRequest, opts ...grpc.CallOption) (*GetMyQuotaResponse, error)
+	// CreateAPIKey 创建 API Key
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	// DeleteAPIKey 删除 API Key
+	DeleteAPIKey(ctx context.Context, in *DeleteAPIKeyRequest, opts ...grpc.CallOption) (*DeleteAPIKeyResponse, error)
+	// ListAPIKey 列出当前用户的 API Key
+	ListAPIKey(ctx context.Context, in *ListAPIKeyRequest, opts ...grpc.CallOption) (*ListAPIKeyResponse, error)
+	// CreateInvitation 创建邀请码，仅管理员可调用
+	CreateInvitation(ctx context.Context, in *CreateInvitationRequest, opts ...grpc.CallOption) (*CreateInvitationResponse, error)
+	// DeleteInvitation 删除邀请码，仅管理员可调用
+	DeleteInvitation(ctx context.Context, in *DeleteInvitationRequest, opts ...grpc.CallOption) (*DeleteInvitationResponse, error)
+	// ListInvitation 列出邀请码，仅管理员可调用
+	ListInvitation(ctx context.Context, in *ListInvitationRequest, opts ...grpc.CallOption) (*ListInvitationResponse, error)
+	// ListPolicy 查询授权策略列表
+	ListPolicy(ctx context.Context, in *ListPolicyRequest, opts ...grpc.CallOption) (*ListPolicyResponse, error)
+	// AddPolicy 添加授权策略
+	AddPolicy(ctx context.Context, in *AddPolicyRequest, opts ...grpc.CallOption) (*AddPolicyResponse, error)
+	// RemovePolicy 删除授权策略
+	RemovePolicy(ctx context.Context, in *RemovePolicyRequest, opts ...grpc.CallOption) (*RemovePolicyResponse, error)
+	// ListRoleAssignment 查询角色分配列表
+	ListRoleAssignment(ctx context.Context, in *ListRoleAssignmentRequest, opts ...grpc.CallOption) (*ListRoleAssignmentResponse, error)
+	// AddRoleAssignment 为用户或角色分配角色
+	AddRoleAssignment(ctx context.Context, in *AddRoleAssignmentRequest, opts ...grpc.CallOption) (*AddRoleAssignmentResponse, error)
+	// RemoveRoleAssignment 取消角色分配
+	RemoveRoleAssignment(ctx context.Context, in *RemoveRoleAssignmentRequest, opts ...grpc.CallOption) (*RemoveRoleAssignmentResponse, error)
+	// GetUserRoles 查询用户的直接角色和实际拥有的全部角色
+	GetUserRoles(ctx context.Context, in *GetUserRolesRequest, opts ...grpc.CallOption) (*GetUserRolesResponse, error)
+	// ExplainAuthorization 解释授权判断的过程，返回授权结果、匹配的策略和角色继承链
+	ExplainAuthorization(ctx context.Context, in *ExplainAuthorizationRequest, opts ...grpc.CallOption) (*ExplainAuthorizationResponse, error)
+	// CreateOrganization 创建组织，创建者成为组织管理员
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	// DeleteOrganization 删除组织及组织内的博客，仅组织管理员可调用
+	DeleteOrganization(ctx context.Context, in *DeleteOrganizationRequest, opts ...grpc.CallOption) (*DeleteOrganizationResponse, error)
+	// GetOrganization 获取组织详情，仅组织成员可调用
+	GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error)
+	// ListOrganization 列出当前用户所属的组织
+	ListOrganization(ctx context.Context, in *ListOrganizationRequest, opts ...grpc.CallOption) (*ListOrganizationResponse, error)
+	// AddOrganizationMember 添加组织成员或修改成员的角色，仅组织管理员可调用
+	AddOrganizationMember(ctx context.Context, in *AddOrganizationMemberRequest, opts ...grpc.CallOption) (*AddOrganizationMemberResponse, error)
+	// RemoveOrganizationMember 移除组织成员，仅组织管理员可调用
+	RemoveOrganizationMember(ctx context.Context, in *RemoveOrganizationMemberRequest, opts ...grpc.CallOption) (*RemoveOrganizationMemberResponse, error)
+	// ListOrganizationMember 列出组织成员，仅组织成员可调用
+	ListOrganizationMember(ctx context.Context, in *ListOrganizationMemberRequest, opts ...grpc.CallOption) (*ListOrganizationMemberResponse, error)
+	// ListAuditEvents 查询修改类操作的审计事件，仅管理员可调用
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// EnrollTOTP 开始绑定 TOTP 两步验证
+	EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPResponse, error)
+	// VerifyTOTP 确认绑定 TOTP 两步验证
+	VerifyTOTP(ctx context.Context, in *VerifyTOTPRequest, opts ...grpc.CallOption) (*VerifyTOTPResponse, error)
+	// DisableTOTP 关闭 TOTP 两步验证
+	DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponse, error)
 }
 
 type miniBlogClient struct {
@@ -156,6 +279,36 @@ func (c *miniBlogClient) Login(ctx context.Context, in *LoginRequest, opts ...gr
 	return out, nil
 }
 
+func (c *miniBlogClient) LoginVerify(ctx context.Context, in *LoginVerifyRequest, opts ...grpc.CallOption) (*LoginVerifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginVerifyResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_LoginVerify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) OIDCLogin(ctx context.Context, in *OIDCLoginRequest, opts ...grpc.CallOption) (*OIDCLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OIDCLoginResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_OIDCLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) OIDCCallback(ctx context.Context, in *OIDCCallbackRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_OIDCCallback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *miniBlogClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RefreshTokenResponse)
@@ -176,6 +329,126 @@ func (c *miniBlogClient) ChangePassword(ctx context.Context, in *ChangePasswordR
 	return out, nil
 }
 
+func (c *miniBlogClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPasswordResetResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RequestPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) RequestMagicLink(ctx context.Context, in *RequestMagicLinkRequest, opts ...grpc.CallOption) (*RequestMagicLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestMagicLinkResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RequestMagicLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) MagicLinkLogin(ctx context.Context, in *MagicLinkLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_MagicLinkLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) RequestLoginCode(ctx context.Context, in *RequestLoginCodeRequest, opts ...grpc.CallOption) (*RequestLoginCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestLoginCodeResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RequestLoginCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) LoginWithCode(ctx context.Context, in *LoginWithCodeRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_LoginWithCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...grpc.CallOption) (*ResendVerificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendVerificationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ResendVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) LinkIdentity(ctx context.Context, in *LinkIdentityRequest, opts ...grpc.CallOption) (*LinkIdentityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkIdentityResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_LinkIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) UnlinkIdentity(ctx context.Context, in *UnlinkIdentityRequest, opts ...grpc.CallOption) (*UnlinkIdentityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlinkIdentityResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_UnlinkIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockUserResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_UnlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) Impersonate(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*ImpersonateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpersonateResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_Impersonate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *miniBlogClient) CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*CreatePostResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreatePostResponse)
@@ -226,361 +499,1564 @@ func (c *miniBlogClient) ListPost(ctx context.Context, in *ListPostRequest, opts
 	return out, nil
 }
 
-// MiniBlogServer is the server API for MiniBlog service.
-// All implementations must embed UnimplementedMiniBlogServer
-// for forward compatibility.
-//
-// MiniBlog 定义了一个 MiniBlog RPC 服务
-type MiniBlogServer interface {
-	// Healthz 健康检查
-	Healthz(context.Context, *emptypb.Empty) (*HealthzResponse, error)
-	// CreateUser 创建新用户
-	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
-	// UpdateUser 更新用户信息
-	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
-	// DeleteUser 删除用户
-	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
-	// GetUser 获取用户信息
-	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
-	// ListUser 列出用户列表
-	ListUser(context.Context, *ListUserRequest) (*ListUserResponse, error)
-	// Login 用户登录
-	Login(context.Context, *LoginRequest) (*LoginResponse, error)
-	// RefreshToken 刷新 Token
-	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
-	// ChangePassword 更改密码
-	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
-	// CreatePost 创建博客帖子
-	CreatePost(context.Context, *CreatePostRequest) (*CreatePostResponse, error)
-	// UpdatePost 更新博客帖子
-	UpdatePost(context.Context, *UpdatePostRequest) (*UpdatePostResponse, error)
-	// DeletePost 删除博客帖子
-	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
-	// GetPost 获取博客帖子
-	GetPost(context.Context, *GetPostRequest) (*GetPostResponse, error)
-	// ListPost 列出所有博客帖子
-	ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error)
-	mustEmbedUnimplementedMiniBlogServer()
+func (c *miniBlogClient) GetMyQuota(ctx context.Context, in *GetMyQuotaRequest, opts ...grpc.CallOption) (*GetMyQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMyQuotaResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetMyQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedMiniBlogServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedMiniBlogServer struct{}
+func (c *miniBlogClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedMiniBlogServer) Healthz(context.Context, *emptypb.Empty) (*HealthzResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Healthz not implemented")
+func (c *miniBlogClient) DeleteAPIKey(ctx context.Context, in *DeleteAPIKeyRequest, opts ...grpc.CallOption) (*DeleteAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAPIKeyResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_DeleteAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+
+func (c *miniBlogClient) ListAPIKey(ctx context.Context, in *ListAPIKeyRequest, opts ...grpc.CallOption) (*ListAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeyResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+
+func (c *miniBlogClient) CreateInvitation(ctx context.Context, in *CreateInvitationRequest, opts ...grpc.CallOption) (*CreateInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateInvitationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_CreateInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+
+func (c *miniBlogClient) DeleteInvitation(ctx context.Context, in *DeleteInvitationRequest, opts ...grpc.CallOption) (*DeleteInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteInvitationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_DeleteInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+
+func (c *miniBlogClient) ListInvitation(ctx context.Context, in *ListInvitationRequest, opts ...grpc.CallOption) (*ListInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) ListUser(context.Context, *ListUserRequest) (*ListUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListUser not implemented")
+
+func (c *miniBlogClient) ListPolicy(ctx context.Context, in *ListPolicyRequest, opts ...grpc.CallOption) (*ListPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPolicyResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+
+func (c *miniBlogClient) AddPolicy(ctx context.Context, in *AddPolicyRequest, opts ...grpc.CallOption) (*AddPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddPolicyResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_AddPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+
+func (c *miniBlogClient) RemovePolicy(ctx context.Context, in *RemovePolicyRequest, opts ...grpc.CallOption) (*RemovePolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePolicyResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RemovePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+
+func (c *miniBlogClient) ListRoleAssignment(ctx context.Context, in *ListRoleAssignmentRequest, opts ...grpc.CallOption) (*ListRoleAssignmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleAssignmentResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListRoleAssignment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMiniBlogServer) CreatePost(context.Context, *CreatePostRequest) (*CreatePostResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
+
+func (c *miniBlogClient) AddRoleAssignment(ctx context.Context, in *AddRoleAssignmentRequest, opts ...grpc.CallOption) (*AddRoleAssignmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddRoleAssignmentResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_AddRoleAssignment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) RemoveRoleAssignment(ctx context.Context, in *RemoveRoleAssignmentRequest, opts ...grpc.CallOption) (*RemoveRoleAssignmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveRoleAssignmentResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RemoveRoleAssignment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) GetUserRoles(ctx context.Context, in *GetUserRolesRequest, opts ...grpc.CallOption) (*GetUserRolesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserRolesResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetUserRoles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ExplainAuthorization(ctx context.Context, in *ExplainAuthorizationRequest, opts ...grpc.CallOption) (*ExplainAuthorizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainAuthorizationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ExplainAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrganizationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) DeleteOrganization(ctx context.Context, in *DeleteOrganizationRequest, opts ...grpc.CallOption) (*DeleteOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOrganizationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_DeleteOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrganizationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ListOrganization(ctx context.Context, in *ListOrganizationRequest, opts ...grpc.CallOption) (*ListOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) AddOrganizationMember(ctx context.Context, in *AddOrganizationMemberRequest, opts ...grpc.CallOption) (*AddOrganizationMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddOrganizationMemberResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_AddOrganizationMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) RemoveOrganizationMember(ctx context.Context, in *RemoveOrganizationMemberRequest, opts ...grpc.CallOption) (*RemoveOrganizationMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveOrganizationMemberResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RemoveOrganizationMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ListOrganizationMember(ctx context.Context, in *ListOrganizationMemberRequest, opts ...grpc.CallOption) (*ListOrganizationMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationMemberResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListOrganizationMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*EnrollTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTOTPResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_EnrollTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) VerifyTOTP(ctx context.Context, in *VerifyTOTPRequest, opts ...grpc.CallOption) (*VerifyTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyTOTPResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_VerifyTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*DisableTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableTOTPResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_DisableTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MiniBlogServer is the server API for MiniBlog service.
+// All implementations must embed UnimplementedMiniBlogServer
+// for forward compatibility.
+//
+// MiniBlog 定义了一个 MiniBlog RPC 服务
+type MiniBlogServer interface {
+	// Healthz 健康检查
+	Healthz(context.Context, *emptypb.Empty) (*HealthzResponse, error)
+	// CreateUser 创建新用户
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	// UpdateUser 更新用户信息
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	// DeleteUser 删除用户
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	// GetUser 获取用户信息
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	// ListUser 列出用户列表
+	ListUser(context.Context, *ListUserRequest) (*ListUserResponse, error)
+	// Login 用户登录
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// LoginVerify 使用两步验证码完成登录
+	LoginVerify(context.Context, *LoginVerifyRequest) (*LoginVerifyResponse, error)
+	// OIDCLogin 发起 OIDC 登录，返回授权地址
+	OIDCLogin(context.Context, *OIDCLoginRequest) (*OIDCLoginResponse, error)
+	// OIDCCallback 处理 OIDC 授权回调，完成登录
+	OIDCCallback(context.Context, *OIDCCallbackRequest) (*LoginResponse, error)
+	// RefreshToken 刷新 Token
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// ChangePassword 更改密码
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	// RequestPasswordReset 申请重置密码，向用户邮箱发送一次性重置链接
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error)
+	// ResetPassword 使用一次性令牌重置密码
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	// RequestMagicLink 向邮箱发送一次性登录链接
+	RequestMagicLink(context.Context, *RequestMagicLinkRequest) (*RequestMagicLinkResponse, error)
+	// MagicLinkLogin 使用邮件中的一次性登录链接登录
+	MagicLinkLogin(context.Context, *MagicLinkLoginRequest) (*LoginResponse, error)
+	// RequestLoginCode 向手机号发送一次性登录验证码
+	RequestLoginCode(context.Context, *RequestLoginCodeRequest) (*RequestLoginCodeResponse, error)
+	// LoginWithCode 使用短信中的一次性验证码登录
+	LoginWithCode(context.Context, *LoginWithCodeRequest) (*LoginResponse, error)
+	// VerifyEmail 使用验证邮件中的令牌验证邮箱
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	// ResendVerification 重新发送邮箱验证邮件
+	ResendVerification(context.Context, *ResendVerificationRequest) (*ResendVerificationResponse, error)
+	// LinkIdentity 将外部身份关联到当前用户
+	LinkIdentity(context.Context, *LinkIdentityRequest) (*LinkIdentityResponse, error)
+	// UnlinkIdentity 解除当前用户与外部身份的关联
+	UnlinkIdentity(context.Context, *UnlinkIdentityRequest) (*UnlinkIdentityResponse, error)
+	// UnlockUser 解除用户的登录锁定，仅管理员可调用
+	UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error)
+	// Impersonate 以指定用户的身份签发短期令牌，用于复现用户遇到的问题，仅管理员可调用
+	Impersonate(context.Context, *ImpersonateRequest) (*ImpersonateResponse, error)
+	// CreatePost 创建博客帖子
+	CreatePost(context.Context, *CreatePostRequest) (*CreatePostResponse, error)
+	// UpdatePost 更新博客帖子
+	UpdatePost(context.Context, *UpdatePostRequest) (*UpdatePostResponse, error)
+	// DeletePost 删除博客帖子
+	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
+	// GetPost 获取博客帖子
+	GetPost(context.Context, *GetPostRequest) (*GetPostResponse, error)
+	// ListPost 列出所有博客帖子
+	ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error)
+	// GetMyQuota 查询当前用户的内容配额及其用量
+	GetMyQuota(context.Context, *GetMyQuotaRequest) (*GetMyQuotaResponse, error)
+	// CreateAPIKey 创建 API Key
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// DeleteAPIKey 删除 API Key
+	DeleteAPIKey(context.Context, *DeleteAPIKeyRequest) (*DeleteAPIKeyResponse, error)
+	// ListAPIKey 列出当前用户的 API Key
+	ListAPIKey(context.Context, *ListAPIKeyRequest) (*ListAPIKeyResponse, error)
+	// CreateInvitation 创建邀请码，仅管理员可调用
+	CreateInvitation(context.Context, *CreateInvitationRequest) (*CreateInvitationResponse, error)
+	// DeleteInvitation 删除邀请码，仅管理员可调用
+	DeleteInvitation(context.Context, *DeleteInvitationRequest) (*DeleteInvitationResponse, error)
+	// ListInvitation 列出邀请码，仅管理员可调用
+	ListInvitation(context.Context, *ListInvitationRequest) (*ListInvitationResponse, error)
+	// ListPolicy 查询授权策略列表
+	ListPolicy(context.Context, *ListPolicyRequest) (*ListPolicyResponse, error)
+	// AddPolicy 添加授权策略
+	AddPolicy(context.Context, *AddPolicyRequest) (*AddPolicyResponse, error)
+	// RemovePolicy 删除授权策略
+	RemovePolicy(context.Context, *RemovePolicyRequest) (*RemovePolicyResponse, error)
+	// ListRoleAssignment 查询角色分配列表
+	ListRoleAssignment(context.Context, *ListRoleAssignmentRequest) (*ListRoleAssignmentResponse, error)
+	// AddRoleAssignment 为用户或角色分配角色
+	AddRoleAssignment(context.Context, *AddRoleAssignmentRequest) (*AddRoleAssignmentResponse, error)
+	// RemoveRoleAssignment 取消角色分配
+	RemoveRoleAssignment(context.Context, *RemoveRoleAssignmentRequest) (*RemoveRoleAssignmentResponse, error)
+	// GetUserRoles 查询用户的直接角色和实际拥有的全部角色
+	GetUserRoles(context.Context, *GetUserRolesRequest) (*GetUserRolesResponse, error)
+	// ExplainAuthorization 解释授权判断的过程，返回授权结果、匹配的策略和角色继承链
+	ExplainAuthorization(context.Context, *ExplainAuthorizationRequest) (*ExplainAuthorizationResponse, error)
+	// CreateOrganization 创建组织，创建者成为组织管理员
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	// DeleteOrganization 删除组织及组织内的博客，仅组织管理员可调用
+	DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*DeleteOrganizationResponse, error)
+	// GetOrganization 获取组织详情，仅组织成员可调用
+	GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error)
+	// ListOrganization 列出当前用户所属的组织
+	ListOrganization(context.Context, *ListOrganizationRequest) (*ListOrganizationResponse, error)
+	// AddOrganizationMember 添加组织成员或修改成员的角色，仅组织管理员可调用
+	AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*AddOrganizationMemberResponse, error)
+	// RemoveOrganizationMember 移除组织成员，仅组织管理员可调用
+	RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*RemoveOrganizationMemberResponse, error)
+	// ListOrganizationMember 列出组织成员，仅组织成员可调用
+	ListOrganizationMember(context.Context, *ListOrganizationMemberRequest) (*ListOrganizationMemberResponse, error)
+	// ListAuditEvents 查询修改类操作的审计事件，仅管理员可调用
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// EnrollTOTP 开始绑定 TOTP 两步验证
+	EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error)
+	// VerifyTOTP 确认绑定 TOTP 两步验证
+	VerifyTOTP(context.Context, *VerifyTOTPRequest) (*VerifyTOTPResponse, error)
+	// DisableTOTP 关闭 TOTP 两步验证
+	DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error)
+	mustEmbedUnimplementedMiniBlogServer()
+}
+
+// UnimplementedMiniBlogServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMiniBlogServer struct{}
+
+func (UnimplementedMiniBlogServer) Healthz(context.Context, *emptypb.Empty) (*HealthzResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Healthz not implemented")
+}
+func (UnimplementedMiniBlogServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedMiniBlogServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedMiniBlogServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedMiniBlogServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedMiniBlogServer) ListUser(context.Context, *ListUserRequest) (*ListUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUser not implemented")
+}
+func (UnimplementedMiniBlogServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedMiniBlogServer) LoginVerify(context.Context, *LoginVerifyRequest) (*LoginVerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginVerify not implemented")
+}
+func (UnimplementedMiniBlogServer) OIDCLogin(context.Context, *OIDCLoginRequest) (*OIDCLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OIDCLogin not implemented")
+}
+func (UnimplementedMiniBlogServer) OIDCCallback(context.Context, *OIDCCallbackRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OIDCCallback not implemented")
+}
+func (UnimplementedMiniBlogServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedMiniBlogServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedMiniBlogServer) RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedMiniBlogServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedMiniBlogServer) RequestMagicLink(context.Context, *RequestMagicLinkRequest) (*RequestMagicLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestMagicLink not implemented")
+}
+func (UnimplementedMiniBlogServer) MagicLinkLogin(context.Context, *MagicLinkLoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MagicLinkLogin not implemented")
+}
+func (UnimplementedMiniBlogServer) RequestLoginCode(context.Context, *RequestLoginCodeRequest) (*RequestLoginCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestLoginCode not implemented")
+}
+func (UnimplementedMiniBlogServer) LoginWithCode(context.Context, *LoginWithCodeRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithCode not implemented")
+}
+func (UnimplementedMiniBlogServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedMiniBlogServer) ResendVerification(context.Context, *ResendVerificationRequest) (*ResendVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendVerification not implemented")
+}
+func (UnimplementedMiniBlogServer) LinkIdentity(context.Context, *LinkIdentityRequest) (*LinkIdentityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkIdentity not implemented")
+}
+func (UnimplementedMiniBlogServer) UnlinkIdentity(context.Context, *UnlinkIdentityRequest) (*UnlinkIdentityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkIdentity not implemented")
+}
+func (UnimplementedMiniBlogServer) UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (UnimplementedMiniBlogServer) Impersonate(context.Context, *ImpersonateRequest) (*ImpersonateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Impersonate not implemented")
+}
+func (UnimplementedMiniBlogServer) CreatePost(context.Context, *CreatePostRequest) (*CreatePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
+}
+func (UnimplementedMiniBlogServer) UpdatePost(context.Context, *UpdatePostRequest) (*UpdatePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePost not implemented")
+}
+func (UnimplementedMiniBlogServer) DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
+}
+func (UnimplementedMiniBlogServer) GetPost(context.Context, *GetPostRequest) (*GetPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPost not implemented")
+}
+func (UnimplementedMiniBlogServer) ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPost not implemented")
+}
+func (UnimplementedMiniBlogServer) GetMyQuota(context.Context, *GetMyQuotaRequest) (*GetMyQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyQuota not implemented")
+}
+func (UnimplementedMiniBlogServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedMiniBlogServer) DeleteAPIKey(context.Context, *DeleteAPIKeyRequest) (*DeleteAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAPIKey not implemented")
+}
+func (UnimplementedMiniBlogServer) ListAPIKey(context.Context, *ListAPIKeyRequest) (*ListAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKey not implemented")
+}
+func (UnimplementedMiniBlogServer) CreateInvitation(context.Context, *CreateInvitationRequest) (*CreateInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvitation not implemented")
+}
+func (UnimplementedMiniBlogServer) DeleteInvitation(context.Context, *DeleteInvitationRequest) (*DeleteInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInvitation not implemented")
+}
+func (UnimplementedMiniBlogServer) ListInvitation(context.Context, *ListInvitationRequest) (*ListInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitation not implemented")
+}
+func (UnimplementedMiniBlogServer) ListPolicy(context.Context, *ListPolicyRequest) (*ListPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPolicy not implemented")
+}
+func (UnimplementedMiniBlogServer) AddPolicy(context.Context, *AddPolicyRequest) (*AddPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPolicy not implemented")
+}
+func (UnimplementedMiniBlogServer) RemovePolicy(context.Context, *RemovePolicyRequest) (*RemovePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePolicy not implemented")
+}
+func (UnimplementedMiniBlogServer) ListRoleAssignment(context.Context, *ListRoleAssignmentRequest) (*ListRoleAssignmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleAssignment not implemented")
+}
+func (UnimplementedMiniBlogServer) AddRoleAssignment(context.Context, *AddRoleAssignmentRequest) (*AddRoleAssignmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleAssignment not implemented")
+}
+func (UnimplementedMiniBlogServer) RemoveRoleAssignment(context.Context, *RemoveRoleAssignmentRequest) (*RemoveRoleAssignmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleAssignment not implemented")
+}
+func (UnimplementedMiniBlogServer) GetUserRoles(context.Context, *GetUserRolesRequest) (*GetUserRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserRoles not implemented")
+}
+func (UnimplementedMiniBlogServer) ExplainAuthorization(context.Context, *ExplainAuthorizationRequest) (*ExplainAuthorizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainAuthorization not implemented")
+}
+func (UnimplementedMiniBlogServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedMiniBlogServer) DeleteOrganization(context.Context, *DeleteOrganizationRequest) (*DeleteOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrganization not implemented")
+}
+func (UnimplementedMiniBlogServer) GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganization not implemented")
+}
+func (UnimplementedMiniBlogServer) ListOrganization(context.Context, *ListOrganizationRequest) (*ListOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganization not implemented")
+}
+func (UnimplementedMiniBlogServer) AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*AddOrganizationMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrganizationMember not implemented")
+}
+func (UnimplementedMiniBlogServer) RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*RemoveOrganizationMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveOrganizationMember not implemented")
+}
+func (UnimplementedMiniBlogServer) ListOrganizationMember(context.Context, *ListOrganizationMemberRequest) (*ListOrganizationMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizationMember not implemented")
+}
+func (UnimplementedMiniBlogServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedMiniBlogServer) EnrollTOTP(context.Context, *EnrollTOTPRequest) (*EnrollTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedMiniBlogServer) VerifyTOTP(context.Context, *VerifyTOTPRequest) (*VerifyTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTOTP not implemented")
+}
+func (UnimplementedMiniBlogServer) DisableTOTP(context.Context, *DisableTOTPRequest) (*DisableTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (UnimplementedMiniBlogServer) mustEmbedUnimplementedMiniBlogServer() {}
+func (UnimplementedMiniBlogServer) testEmbeddedByValue()                  {}
+
+// UnsafeMiniBlogServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MiniBlogServer will
+// result in compilation errors.
+type UnsafeMiniBlogServer interface {
+	mustEmbedUnimplementedMiniBlogServer()
+}
+
+func RegisterMiniBlogServer(s grpc.ServiceRegistrar, srv MiniBlogServer) {
+	// If the following call pancis, it indicates UnimplementedMiniBlogServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MiniBlog_ServiceDesc, srv)
+}
+
+func _MiniBlog_Healthz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).Healthz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_Healthz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).Healthz(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ListUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ListUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ListUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ListUser(ctx, req.(*ListUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_LoginVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).LoginVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_LoginVerify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).LoginVerify(ctx, req.(*LoginVerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_OIDCLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OIDCLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).OIDCLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_OIDCLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).OIDCLogin(ctx, req.(*OIDCLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_OIDCCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OIDCCallbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).OIDCCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_OIDCCallback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).OIDCCallback(ctx, req.(*OIDCCallbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_RequestMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestMagicLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).RequestMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_RequestMagicLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).RequestMagicLink(ctx, req.(*RequestMagicLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_MagicLinkLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MagicLinkLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).MagicLinkLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_MagicLinkLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).MagicLinkLogin(ctx, req.(*MagicLinkLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_RequestLoginCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestLoginCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).RequestLoginCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_RequestLoginCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).RequestLoginCode(ctx, req.(*RequestLoginCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_LoginWithCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).LoginWithCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_LoginWithCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).LoginWithCode(ctx, req.(*LoginWithCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ResendVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ResendVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ResendVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ResendVerification(ctx, req.(*ResendVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_LinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).LinkIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_LinkIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).LinkIdentity(ctx, req.(*LinkIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_UnlinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).UnlinkIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_UnlinkIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).UnlinkIdentity(ctx, req.(*UnlinkIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_UnlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).UnlockUser(ctx, req.(*UnlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_Impersonate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpersonateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).Impersonate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_Impersonate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).Impersonate(ctx, req.(*ImpersonateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).CreatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_CreatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).CreatePost(ctx, req.(*CreatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_UpdatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).UpdatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_UpdatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).UpdatePost(ctx, req.(*UpdatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).DeletePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_DeletePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).DeletePost(ctx, req.(*DeletePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_GetPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetPost(ctx, req.(*GetPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ListPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ListPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ListPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ListPost(ctx, req.(*ListPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_GetMyQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetMyQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetMyQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetMyQuota(ctx, req.(*GetMyQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_DeleteAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).DeleteAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_DeleteAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).DeleteAPIKey(ctx, req.(*DeleteAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ListAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ListAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ListAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ListAPIKey(ctx, req.(*ListAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_CreateInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).CreateInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_CreateInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).CreateInvitation(ctx, req.(*CreateInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_DeleteInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).DeleteInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_DeleteInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).DeleteInvitation(ctx, req.(*DeleteInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMiniBlogServer) UpdatePost(context.Context, *UpdatePostRequest) (*UpdatePostResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdatePost not implemented")
+
+func _MiniBlog_ListInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ListInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ListInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ListInvitation(ctx, req.(*ListInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMiniBlogServer) DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
+
+func _MiniBlog_ListPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ListPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ListPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ListPolicy(ctx, req.(*ListPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMiniBlogServer) GetPost(context.Context, *GetPostRequest) (*GetPostResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPost not implemented")
+
+func _MiniBlog_AddPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).AddPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_AddPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).AddPolicy(ctx, req.(*AddPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMiniBlogServer) ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListPost not implemented")
+
+func _MiniBlog_RemovePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).RemovePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_RemovePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).RemovePolicy(ctx, req.(*RemovePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMiniBlogServer) mustEmbedUnimplementedMiniBlogServer() {}
-func (UnimplementedMiniBlogServer) testEmbeddedByValue()                  {}
 
-// UnsafeMiniBlogServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to MiniBlogServer will
-// result in compilation errors.
-type UnsafeMiniBlogServer interface {
-	mustEmbedUnimplementedMiniBlogServer()
+func _MiniBlog_ListRoleAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ListRoleAssignment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ListRoleAssignment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ListRoleAssignment(ctx, req.(*ListRoleAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterMiniBlogServer(s grpc.ServiceRegistrar, srv MiniBlogServer) {
-	// If the following call pancis, it indicates UnimplementedMiniBlogServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _MiniBlog_AddRoleAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRoleAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&MiniBlog_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(MiniBlogServer).AddRoleAssignment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_AddRoleAssignment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).AddRoleAssignment(ctx, req.(*AddRoleAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_Healthz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _MiniBlog_RemoveRoleAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRoleAssignmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).Healthz(ctx, in)
+		return srv.(MiniBlogServer).RemoveRoleAssignment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_Healthz_FullMethodName,
+		FullMethod: MiniBlog_RemoveRoleAssignment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).Healthz(ctx, req.(*emptypb.Empty))
+		return srv.(MiniBlogServer).RemoveRoleAssignment(ctx, req.(*RemoveRoleAssignmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
+func _MiniBlog_GetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRolesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).CreateUser(ctx, in)
+		return srv.(MiniBlogServer).GetUserRoles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_CreateUser_FullMethodName,
+		FullMethod: MiniBlog_GetUserRoles_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).CreateUser(ctx, req.(*CreateUserRequest))
+		return srv.(MiniBlogServer).GetUserRoles(ctx, req.(*GetUserRolesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
+func _MiniBlog_ExplainAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainAuthorizationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).UpdateUser(ctx, in)
+		return srv.(MiniBlogServer).ExplainAuthorization(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_UpdateUser_FullMethodName,
+		FullMethod: MiniBlog_ExplainAuthorization_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+		return srv.(MiniBlogServer).ExplainAuthorization(ctx, req.(*ExplainAuthorizationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteUserRequest)
+func _MiniBlog_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).DeleteUser(ctx, in)
+		return srv.(MiniBlogServer).CreateOrganization(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_DeleteUser_FullMethodName,
+		FullMethod: MiniBlog_CreateOrganization_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+		return srv.(MiniBlogServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserRequest)
+func _MiniBlog_DeleteOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrganizationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).GetUser(ctx, in)
+		return srv.(MiniBlogServer).DeleteOrganization(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_GetUser_FullMethodName,
+		FullMethod: MiniBlog_DeleteOrganization_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).GetUser(ctx, req.(*GetUserRequest))
+		return srv.(MiniBlogServer).DeleteOrganization(ctx, req.(*DeleteOrganizationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_ListUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListUserRequest)
+func _MiniBlog_GetOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).ListUser(ctx, in)
+		return srv.(MiniBlogServer).GetOrganization(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_ListUser_FullMethodName,
+		FullMethod: MiniBlog_GetOrganization_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).ListUser(ctx, req.(*ListUserRequest))
+		return srv.(MiniBlogServer).GetOrganization(ctx, req.(*GetOrganizationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _MiniBlog_ListOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).Login(ctx, in)
+		return srv.(MiniBlogServer).ListOrganization(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_Login_FullMethodName,
+		FullMethod: MiniBlog_ListOrganization_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).Login(ctx, req.(*LoginRequest))
+		return srv.(MiniBlogServer).ListOrganization(ctx, req.(*ListOrganizationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RefreshTokenRequest)
+func _MiniBlog_AddOrganizationMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrganizationMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).RefreshToken(ctx, in)
+		return srv.(MiniBlogServer).AddOrganizationMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_RefreshToken_FullMethodName,
+		FullMethod: MiniBlog_AddOrganizationMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+		return srv.(MiniBlogServer).AddOrganizationMember(ctx, req.(*AddOrganizationMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePasswordRequest)
+func _MiniBlog_RemoveOrganizationMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveOrganizationMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).ChangePassword(ctx, in)
+		return srv.(MiniBlogServer).RemoveOrganizationMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_ChangePassword_FullMethodName,
+		FullMethod: MiniBlog_RemoveOrganizationMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+		return srv.(MiniBlogServer).RemoveOrganizationMember(ctx, req.(*RemoveOrganizationMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreatePostRequest)
+func _MiniBlog_ListOrganizationMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).CreatePost(ctx, in)
+		return srv.(MiniBlogServer).ListOrganizationMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_CreatePost_FullMethodName,
+		FullMethod: MiniBlog_ListOrganizationMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).CreatePost(ctx, req.(*CreatePostRequest))
+		return srv.(MiniBlogServer).ListOrganizationMember(ctx, req.(*ListOrganizationMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_UpdatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdatePostRequest)
+func _MiniBlog_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).UpdatePost(ctx, in)
+		return srv.(MiniBlogServer).ListAuditEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_UpdatePost_FullMethodName,
+		FullMethod: MiniBlog_ListAuditEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).UpdatePost(ctx, req.(*UpdatePostRequest))
+		return srv.(MiniBlogServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeletePostRequest)
+func _MiniBlog_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTOTPRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).DeletePost(ctx, in)
+		return srv.(MiniBlogServer).EnrollTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_DeletePost_FullMethodName,
+		FullMethod: MiniBlog_EnrollTOTP_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).DeletePost(ctx, req.(*DeletePostRequest))
+		return srv.(MiniBlogServer).EnrollTOTP(ctx, req.(*EnrollTOTPRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_GetPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPostRequest)
+func _MiniBlog_VerifyTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTOTPRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).GetPost(ctx, in)
+		return srv.(MiniBlogServer).VerifyTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_GetPost_FullMethodName,
+		FullMethod: MiniBlog_VerifyTOTP_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).GetPost(ctx, req.(*GetPostRequest))
+		return srv.(MiniBlogServer).VerifyTOTP(ctx, req.(*VerifyTOTPRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MiniBlog_ListPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPostRequest)
+func _MiniBlog_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTOTPRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MiniBlogServer).ListPost(ctx, in)
+		return srv.(MiniBlogServer).DisableTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MiniBlog_ListPost_FullMethodName,
+		FullMethod: MiniBlog_DisableTOTP_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MiniBlogServer).ListPost(ctx, req.(*ListPostRequest))
+		return srv.(MiniBlogServer).DisableTOTP(ctx, req.(*DisableTOTPRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -620,6 +2096,18 @@ var MiniBlog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _MiniBlog_Login_Handler,
 		},
+		{
+			MethodName: "LoginVerify",
+			Handler:    _MiniBlog_LoginVerify_Handler,
+		},
+		{
+			MethodName: "OIDCLogin",
+			Handler:    _MiniBlog_OIDCLogin_Handler,
+		},
+		{
+			MethodName: "OIDCCallback",
+			Handler:    _MiniBlog_OIDCCallback_Handler,
+		},
 		{
 			MethodName: "RefreshToken",
 			Handler:    _MiniBlog_RefreshToken_Handler,
@@ -628,6 +2116,54 @@ var MiniBlog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePassword",
 			Handler:    _MiniBlog_ChangePassword_Handler,
 		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _MiniBlog_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _MiniBlog_ResetPassword_Handler,
+		},
+		{
+			MethodName: "RequestMagicLink",
+			Handler:    _MiniBlog_RequestMagicLink_Handler,
+		},
+		{
+			MethodName: "MagicLinkLogin",
+			Handler:    _MiniBlog_MagicLinkLogin_Handler,
+		},
+		{
+			MethodName: "RequestLoginCode",
+			Handler:    _MiniBlog_RequestLoginCode_Handler,
+		},
+		{
+			MethodName: "LoginWithCode",
+			Handler:    _MiniBlog_LoginWithCode_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _MiniBlog_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "ResendVerification",
+			Handler:    _MiniBlog_ResendVerification_Handler,
+		},
+		{
+			MethodName: "LinkIdentity",
+			Handler:    _MiniBlog_LinkIdentity_Handler,
+		},
+		{
+			MethodName: "UnlinkIdentity",
+			Handler:    _MiniBlog_UnlinkIdentity_Handler,
+		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _MiniBlog_UnlockUser_Handler,
+		},
+		{
+			MethodName: "Impersonate",
+			Handler:    _MiniBlog_Impersonate_Handler,
+		},
 		{
 			MethodName: "CreatePost",
 			Handler:    _MiniBlog_CreatePost_Handler,
@@ -648,6 +2184,110 @@ var MiniBlog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListPost",
 			Handler:    _MiniBlog_ListPost_Handler,
 		},
+		{
+			MethodName: "GetMyQuota",
+			Handler:    _MiniBlog_GetMyQuota_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _MiniBlog_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "DeleteAPIKey",
+			Handler:    _MiniBlog_DeleteAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKey",
+			Handler:    _MiniBlog_ListAPIKey_Handler,
+		},
+		{
+			MethodName: "CreateInvitation",
+			Handler:    _MiniBlog_CreateInvitation_Handler,
+		},
+		{
+			MethodName: "DeleteInvitation",
+			Handler:    _MiniBlog_DeleteInvitation_Handler,
+		},
+		{
+			MethodName: "ListInvitation",
+			Handler:    _MiniBlog_ListInvitation_Handler,
+		},
+		{
+			MethodName: "ListPolicy",
+			Handler:    _MiniBlog_ListPolicy_Handler,
+		},
+		{
+			MethodName: "AddPolicy",
+			Handler:    _MiniBlog_AddPolicy_Handler,
+		},
+		{
+			MethodName: "RemovePolicy",
+			Handler:    _MiniBlog_RemovePolicy_Handler,
+		},
+		{
+			MethodName: "ListRoleAssignment",
+			Handler:    _MiniBlog_ListRoleAssignment_Handler,
+		},
+		{
+			MethodName: "AddRoleAssignment",
+			Handler:    _MiniBlog_AddRoleAssignment_Handler,
+		},
+		{
+			MethodName: "RemoveRoleAssignment",
+			Handler:    _MiniBlog_RemoveRoleAssignment_Handler,
+		},
+		{
+			MethodName: "GetUserRoles",
+			Handler:    _MiniBlog_GetUserRoles_Handler,
+		},
+		{
+			MethodName: "ExplainAuthorization",
+			Handler:    _MiniBlog_ExplainAuthorization_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _MiniBlog_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "DeleteOrganization",
+			Handler:    _MiniBlog_DeleteOrganization_Handler,
+		},
+		{
+			MethodName: "GetOrganization",
+			Handler:    _MiniBlog_GetOrganization_Handler,
+		},
+		{
+			MethodName: "ListOrganization",
+			Handler:    _MiniBlog_ListOrganization_Handler,
+		},
+		{
+			MethodName: "AddOrganizationMember",
+			Handler:    _MiniBlog_AddOrganizationMember_Handler,
+		},
+		{
+			MethodName: "RemoveOrganizationMember",
+			Handler:    _MiniBlog_RemoveOrganizationMember_Handler,
+		},
+		{
+			MethodName: "ListOrganizationMember",
+			Handler:    _MiniBlog_ListOrganizationMember_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _MiniBlog_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _MiniBlog_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "VerifyTOTP",
+			Handler:    _MiniBlog_VerifyTOTP_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _MiniBlog_DisableTOTP_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "apiserver/v1/apiserver.proto",
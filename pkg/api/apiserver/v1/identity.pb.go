@@ -0,0 +1,433 @@
+// Identity API 定义，包含外部身份（LDAP、OIDC）登录和账号关联相关的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.20.1
+// source: apiserver/v1/identity.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OIDCLoginRequest 表示发起 OIDC 登录的请求
+type OIDCLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCLoginRequest) Reset() {
+	*x = OIDCLoginRequest{}
+	mi := &file_apiserver_v1_identity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCLoginRequest) ProtoMessage() {}
+
+func (x *OIDCLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_identity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCLoginRequest.ProtoReflect.Descriptor instead.
+func (*OIDCLoginRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_identity_proto_rawDescGZIP(), []int{0}
+}
+
+// OIDCLoginResponse 表示发起 OIDC 登录的响应
+type OIDCLoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// authURL 表示用户需要跳转的 OIDC 授权地址
+	AuthURL string `protobuf:"bytes,1,opt,name=authURL,proto3" json:"authURL,omitempty"`
+	// state 表示本次登录的 state，回调时需要原样带回
+	State         string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCLoginResponse) Reset() {
+	*x = OIDCLoginResponse{}
+	mi := &file_apiserver_v1_identity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCLoginResponse) ProtoMessage() {}
+
+func (x *OIDCLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_identity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCLoginResponse.ProtoReflect.Descriptor instead.
+func (*OIDCLoginResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_identity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OIDCLoginResponse) GetAuthURL() string {
+	if x != nil {
+		return x.AuthURL
+	}
+	return ""
+}
+
+func (x *OIDCLoginResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+// OIDCCallbackRequest 表示 OIDC 授权回调请求
+type OIDCCallbackRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code 表示 OIDC 提供方返回的授权码
+	// @gotags: form:"code"
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty" form:"code"`
+	// state 表示发起登录时返回的 state
+	// @gotags: form:"state"
+	State         string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty" form:"state"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCCallbackRequest) Reset() {
+	*x = OIDCCallbackRequest{}
+	mi := &file_apiserver_v1_identity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCCallbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCCallbackRequest) ProtoMessage() {}
+
+func (x *OIDCCallbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_identity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCCallbackRequest.ProtoReflect.Descriptor instead.
+func (*OIDCCallbackRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_identity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OIDCCallbackRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OIDCCallbackRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+// LinkIdentityRequest 表示将外部身份关联到当前用户的请求
+type LinkIdentityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// provider 表示认证方式，目前支持 ldap
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// username 表示用户在认证方式中的用户名
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// password 表示用户在认证方式中的密码
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityRequest) Reset() {
+	*x = LinkIdentityRequest{}
+	mi := &file_apiserver_v1_identity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityRequest) ProtoMessage() {}
+
+func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_identity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_identity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// LinkIdentityResponse 表示关联外部身份的响应
+type LinkIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityResponse) Reset() {
+	*x = LinkIdentityResponse{}
+	mi := &file_apiserver_v1_identity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityResponse) ProtoMessage() {}
+
+func (x *LinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_identity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*LinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_identity_proto_rawDescGZIP(), []int{4}
+}
+
+// UnlinkIdentityRequest 表示解除外部身份关联的请求
+type UnlinkIdentityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// provider 表示认证方式
+	// @gotags: uri:"provider"
+	Provider      string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty" uri:"provider"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityRequest) Reset() {
+	*x = UnlinkIdentityRequest{}
+	mi := &file_apiserver_v1_identity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_identity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_identity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UnlinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+// UnlinkIdentityResponse 表示解除外部身份关联的响应
+type UnlinkIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityResponse) Reset() {
+	*x = UnlinkIdentityResponse{}
+	mi := &file_apiserver_v1_identity_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityResponse) ProtoMessage() {}
+
+func (x *UnlinkIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_identity_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_identity_proto_rawDescGZIP(), []int{6}
+}
+
+var File_apiserver_v1_identity_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_identity_proto_rawDesc = "" +
+	"\n" +
+	"\x1bapiserver/v1/identity.proto\x12\x02v1\"\x12\n" +
+	"\x10OIDCLoginRequest\"C\n" +
+	"\x11OIDCLoginResponse\x12\x18\n" +
+	"\aauthURL\x18\x01 \x01(\tR\aauthURL\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"?\n" +
+	"\x13OIDCCallbackRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"i\n" +
+	"\x13LinkIdentityRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\x16\n" +
+	"\x14LinkIdentityResponse\"3\n" +
+	"\x15UnlinkIdentityRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\"\x18\n" +
+	"\x16UnlinkIdentityResponseB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+
+var (
+	file_apiserver_v1_identity_proto_rawDescOnce sync.Once
+	file_apiserver_v1_identity_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_identity_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_identity_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_identity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_identity_proto_rawDesc), len(file_apiserver_v1_identity_proto_rawDesc)))
+	})
+	return file_apiserver_v1_identity_proto_rawDescData
+}
+
+var file_apiserver_v1_identity_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_apiserver_v1_identity_proto_goTypes = []any{
+	(*OIDCLoginRequest)(nil),       // 0: v1.OIDCLoginRequest
+	(*OIDCLoginResponse)(nil),      // 1: v1.OIDCLoginResponse
+	(*OIDCCallbackRequest)(nil),    // 2: v1.OIDCCallbackRequest
+	(*LinkIdentityRequest)(nil),    // 3: v1.LinkIdentityRequest
+	(*LinkIdentityResponse)(nil),   // 4: v1.LinkIdentityResponse
+	(*UnlinkIdentityRequest)(nil),  // 5: v1.UnlinkIdentityRequest
+	(*UnlinkIdentityResponse)(nil), // 6: v1.UnlinkIdentityResponse
+}
+var file_apiserver_v1_identity_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_identity_proto_init() }
+func file_apiserver_v1_identity_proto_init() {
+	if File_apiserver_v1_identity_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_identity_proto_rawDesc), len(file_apiserver_v1_identity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_identity_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_identity_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_identity_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_identity_proto = out.File
+	file_apiserver_v1_identity_proto_goTypes = nil
+	file_apiserver_v1_identity_proto_depIdxs = nil
+}
@@ -0,0 +1,505 @@
+// Invitation API 定义，包含邀请码相关的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.20.1
+// source: apiserver/v1/invitation.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Invitation 表示邀请注册使用的邀请码
+type Invitation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code 表示邀请码
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// createdBy 表示创建邀请码的用户 ID
+	CreatedBy string `protobuf:"bytes,2,opt,name=createdBy,proto3" json:"createdBy,omitempty"`
+	// maxUses 表示邀请码最多可以使用的次数
+	MaxUses int64 `protobuf:"varint,3,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	// usedCount 表示邀请码已经使用的次数
+	UsedCount int64 `protobuf:"varint,4,opt,name=usedCount,proto3" json:"usedCount,omitempty"`
+	// expiresAt 表示邀请码的过期时间
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// createdAt 表示邀请码创建时间
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invitation) Reset() {
+	*x = Invitation{}
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invitation) ProtoMessage() {}
+
+func (x *Invitation) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invitation.ProtoReflect.Descriptor instead.
+func (*Invitation) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_invitation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Invitation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Invitation) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Invitation) GetMaxUses() int64 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Invitation) GetUsedCount() int64 {
+	if x != nil {
+		return x.UsedCount
+	}
+	return 0
+}
+
+func (x *Invitation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Invitation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// CreateInvitationRequest 表示创建邀请码请求
+type CreateInvitationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// maxUses 表示邀请码最多可以使用的次数
+	MaxUses int64 `protobuf:"varint,1,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	// expiresAt 表示邀请码的过期时间，为空时使用默认有效期
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvitationRequest) Reset() {
+	*x = CreateInvitationRequest{}
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvitationRequest) ProtoMessage() {}
+
+func (x *CreateInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvitationRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_invitation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateInvitationRequest) GetMaxUses() int64 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreateInvitationRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// CreateInvitationResponse 表示创建邀请码响应
+type CreateInvitationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// invitation 表示新创建的邀请码
+	Invitation    *Invitation `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvitationResponse) Reset() {
+	*x = CreateInvitationResponse{}
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvitationResponse) ProtoMessage() {}
+
+func (x *CreateInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvitationResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_invitation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateInvitationResponse) GetInvitation() *Invitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+// DeleteInvitationRequest 表示删除邀请码请求
+type DeleteInvitationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code 表示要删除的邀请码
+	// @gotags: uri:"code"
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty" uri:"code"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInvitationRequest) Reset() {
+	*x = DeleteInvitationRequest{}
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInvitationRequest) ProtoMessage() {}
+
+func (x *DeleteInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInvitationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_invitation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteInvitationRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// DeleteInvitationResponse 表示删除邀请码响应
+type DeleteInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInvitationResponse) Reset() {
+	*x = DeleteInvitationResponse{}
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInvitationResponse) ProtoMessage() {}
+
+func (x *DeleteInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInvitationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_invitation_proto_rawDescGZIP(), []int{4}
+}
+
+// ListInvitationRequest 表示获取邀请码列表请求
+type ListInvitationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	// @gotags: form:"offset"
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty" form:"offset"`
+	// limit 表示每页数量
+	// @gotags: form:"limit"
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationRequest) Reset() {
+	*x = ListInvitationRequest{}
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationRequest) ProtoMessage() {}
+
+func (x *ListInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_invitation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListInvitationRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListInvitationRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListInvitationResponse 表示获取邀请码列表响应
+type ListInvitationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示邀请码总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// invitations 表示邀请码列表
+	Invitations   []*Invitation `protobuf:"bytes,2,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationResponse) Reset() {
+	*x = ListInvitationResponse{}
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationResponse) ProtoMessage() {}
+
+func (x *ListInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_invitation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_invitation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListInvitationResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListInvitationResponse) GetInvitations() []*Invitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+var File_apiserver_v1_invitation_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_invitation_proto_rawDesc = "" +
+	"\n" +
+	"\x1dapiserver/v1/invitation.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xea\x01\n" +
+	"\n" +
+	"Invitation\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1c\n" +
+	"\tcreatedBy\x18\x02 \x01(\tR\tcreatedBy\x12\x18\n" +
+	"\amaxUses\x18\x03 \x01(\x03R\amaxUses\x12\x1c\n" +
+	"\tusedCount\x18\x04 \x01(\x03R\tusedCount\x128\n" +
+	"\texpiresAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"m\n" +
+	"\x17CreateInvitationRequest\x12\x18\n" +
+	"\amaxUses\x18\x01 \x01(\x03R\amaxUses\x128\n" +
+	"\texpiresAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"J\n" +
+	"\x18CreateInvitationResponse\x12.\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2\x0e.v1.InvitationR\n" +
+	"invitation\"-\n" +
+	"\x17DeleteInvitationRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x1a\n" +
+	"\x18DeleteInvitationResponse\"E\n" +
+	"\x15ListInvitationRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"j\n" +
+	"\x16ListInvitationResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x120\n" +
+	"\vinvitations\x18\x02 \x03(\v2\x0e.v1.InvitationR\vinvitationsB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+
+var (
+	file_apiserver_v1_invitation_proto_rawDescOnce sync.Once
+	file_apiserver_v1_invitation_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_invitation_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_invitation_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_invitation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_invitation_proto_rawDesc), len(file_apiserver_v1_invitation_proto_rawDesc)))
+	})
+	return file_apiserver_v1_invitation_proto_rawDescData
+}
+
+var file_apiserver_v1_invitation_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_apiserver_v1_invitation_proto_goTypes = []any{
+	(*Invitation)(nil),               // 0: v1.Invitation
+	(*CreateInvitationRequest)(nil),  // 1: v1.CreateInvitationRequest
+	(*CreateInvitationResponse)(nil), // 2: v1.CreateInvitationResponse
+	(*DeleteInvitationRequest)(nil),  // 3: v1.DeleteInvitationRequest
+	(*DeleteInvitationResponse)(nil), // 4: v1.DeleteInvitationResponse
+	(*ListInvitationRequest)(nil),    // 5: v1.ListInvitationRequest
+	(*ListInvitationResponse)(nil),   // 6: v1.ListInvitationResponse
+	(*timestamppb.Timestamp)(nil),    // 7: google.protobuf.Timestamp
+}
+var file_apiserver_v1_invitation_proto_depIdxs = []int32{
+	7, // 0: v1.Invitation.expiresAt:type_name -> google.protobuf.Timestamp
+	7, // 1: v1.Invitation.createdAt:type_name -> google.protobuf.Timestamp
+	7, // 2: v1.CreateInvitationRequest.expiresAt:type_name -> google.protobuf.Timestamp
+	0, // 3: v1.CreateInvitationResponse.invitation:type_name -> v1.Invitation
+	0, // 4: v1.ListInvitationResponse.invitations:type_name -> v1.Invitation
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_invitation_proto_init() }
+func file_apiserver_v1_invitation_proto_init() {
+	if File_apiserver_v1_invitation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_invitation_proto_rawDesc), len(file_apiserver_v1_invitation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_invitation_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_invitation_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_invitation_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_invitation_proto = out.File
+	file_apiserver_v1_invitation_proto_goTypes = nil
+	file_apiserver_v1_invitation_proto_depIdxs = nil
+}
@@ -0,0 +1,482 @@
+// MFA API 定义，包含基于 TOTP 的两步验证相关的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.20.1
+// source: apiserver/v1/mfa.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EnrollTOTPRequest 表示开始绑定 TOTP 两步验证的请求
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{0}
+}
+
+// EnrollTOTPResponse 表示开始绑定 TOTP 两步验证的响应
+type EnrollTOTPResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// secret 表示 TOTP 密钥，用于无法扫描二维码时手动输入
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// provisioningURI 表示身份验证器应用可识别的 otpauth URI
+	ProvisioningURI string `protobuf:"bytes,2,opt,name=provisioningURI,proto3" json:"provisioningURI,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetProvisioningURI() string {
+	if x != nil {
+		return x.ProvisioningURI
+	}
+	return ""
+}
+
+// VerifyTOTPRequest 表示确认绑定 TOTP 两步验证的请求
+type VerifyTOTPRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code 表示身份验证器应用生成的验证码
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTOTPRequest) Reset() {
+	*x = VerifyTOTPRequest{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTOTPRequest) ProtoMessage() {}
+
+func (x *VerifyTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTOTPRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VerifyTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// VerifyTOTPResponse 表示确认绑定 TOTP 两步验证的响应
+type VerifyTOTPResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// recoveryCodes 表示一次性恢复码，只会返回一次，用于无法使用身份验证器时登录
+	RecoveryCodes []string `protobuf:"bytes,1,rep,name=recoveryCodes,proto3" json:"recoveryCodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTOTPResponse) Reset() {
+	*x = VerifyTOTPResponse{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTOTPResponse) ProtoMessage() {}
+
+func (x *VerifyTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTOTPResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VerifyTOTPResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+// DisableTOTPRequest 表示关闭 TOTP 两步验证的请求
+type DisableTOTPRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code 表示身份验证器应用生成的验证码或一次性恢复码
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// DisableTOTPResponse 表示关闭 TOTP 两步验证的响应
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponse) ProtoMessage() {}
+
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{5}
+}
+
+// LoginVerifyRequest 表示两步验证登录的请求
+type LoginVerifyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// challengeToken 表示 Login 返回的挑战令牌
+	ChallengeToken string `protobuf:"bytes,1,opt,name=challengeToken,proto3" json:"challengeToken,omitempty"`
+	// code 表示身份验证器应用生成的验证码或一次性恢复码
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginVerifyRequest) Reset() {
+	*x = LoginVerifyRequest{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginVerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginVerifyRequest) ProtoMessage() {}
+
+func (x *LoginVerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginVerifyRequest.ProtoReflect.Descriptor instead.
+func (*LoginVerifyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LoginVerifyRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *LoginVerifyRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// LoginVerifyResponse 表示两步验证登录的响应
+type LoginVerifyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token 表示返回的身份验证令牌
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// expireAt 表示该 token 的过期时间
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginVerifyResponse) Reset() {
+	*x = LoginVerifyResponse{}
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginVerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginVerifyResponse) ProtoMessage() {}
+
+func (x *LoginVerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_mfa_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginVerifyResponse.ProtoReflect.Descriptor instead.
+func (*LoginVerifyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_mfa_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LoginVerifyResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginVerifyResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+var File_apiserver_v1_mfa_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_mfa_proto_rawDesc = "" +
+	"\n" +
+	"\x16apiserver/v1/mfa.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x13\n" +
+	"\x11EnrollTOTPRequest\"V\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12(\n" +
+	"\x0fprovisioningURI\x18\x02 \x01(\tR\x0fprovisioningURI\"'\n" +
+	"\x11VerifyTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\":\n" +
+	"\x12VerifyTOTPResponse\x12$\n" +
+	"\rrecoveryCodes\x18\x01 \x03(\tR\rrecoveryCodes\"(\n" +
+	"\x12DisableTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x15\n" +
+	"\x13DisableTOTPResponse\"P\n" +
+	"\x12LoginVerifyRequest\x12&\n" +
+	"\x0echallengeToken\x18\x01 \x01(\tR\x0echallengeToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"c\n" +
+	"\x13LoginVerifyResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAtB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+
+var (
+	file_apiserver_v1_mfa_proto_rawDescOnce sync.Once
+	file_apiserver_v1_mfa_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_mfa_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_mfa_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_mfa_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_mfa_proto_rawDesc), len(file_apiserver_v1_mfa_proto_rawDesc)))
+	})
+	return file_apiserver_v1_mfa_proto_rawDescData
+}
+
+var file_apiserver_v1_mfa_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_apiserver_v1_mfa_proto_goTypes = []any{
+	(*EnrollTOTPRequest)(nil),     // 0: v1.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),    // 1: v1.EnrollTOTPResponse
+	(*VerifyTOTPRequest)(nil),     // 2: v1.VerifyTOTPRequest
+	(*VerifyTOTPResponse)(nil),    // 3: v1.VerifyTOTPResponse
+	(*DisableTOTPRequest)(nil),    // 4: v1.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil),   // 5: v1.DisableTOTPResponse
+	(*LoginVerifyRequest)(nil),    // 6: v1.LoginVerifyRequest
+	(*LoginVerifyResponse)(nil),   // 7: v1.LoginVerifyResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_apiserver_v1_mfa_proto_depIdxs = []int32{
+	8, // 0: v1.LoginVerifyResponse.expireAt:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_mfa_proto_init() }
+func file_apiserver_v1_mfa_proto_init() {
+	if File_apiserver_v1_mfa_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_mfa_proto_rawDesc), len(file_apiserver_v1_mfa_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_mfa_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_mfa_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_mfa_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_mfa_proto = out.File
+	file_apiserver_v1_mfa_proto_goTypes = nil
+	file_apiserver_v1_mfa_proto_depIdxs = nil
+}
@@ -0,0 +1,65 @@
+// Organization API 定义，包含组织及组织成员相关的请求和响应消息
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *Organization) Default() {
+}
+
+func (x *OrganizationMember) Default() {
+}
+
+func (x *CreateOrganizationRequest) Default() {
+}
+
+func (x *CreateOrganizationResponse) Default() {
+}
+
+func (x *DeleteOrganizationRequest) Default() {
+}
+
+func (x *DeleteOrganizationResponse) Default() {
+}
+
+func (x *GetOrganizationRequest) Default() {
+}
+
+func (x *GetOrganizationResponse) Default() {
+}
+
+func (x *ListOrganizationRequest) Default() {
+}
+
+func (x *ListOrganizationResponse) Default() {
+}
+
+func (x *AddOrganizationMemberRequest) Default() {
+}
+
+func (x *AddOrganizationMemberResponse) Default() {
+}
+
+func (x *RemoveOrganizationMemberRequest) Default() {
+}
+
+func (x *RemoveOrganizationMemberResponse) Default() {
+}
+
+func (x *ListOrganizationMemberRequest) Default() {
+}
+
+func (x *ListOrganizationMemberResponse) Default() {
+}
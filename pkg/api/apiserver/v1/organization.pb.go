@@ -0,0 +1,968 @@
+// Organization API 定义，包含组织及组织成员相关的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.20.1
+// source: apiserver/v1/organization.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Organization 表示组织
+type Organization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orgID 表示组织 ID
+	OrgID string `protobuf:"bytes,1,opt,name=orgID,proto3" json:"orgID,omitempty"`
+	// name 表示组织名称
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// description 表示组织描述
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// ownerID 表示创建组织的用户 ID
+	OwnerID string `protobuf:"bytes,4,opt,name=ownerID,proto3" json:"ownerID,omitempty"`
+	// createdAt 表示组织创建时间
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// updatedAt 表示组织最后更新时间
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Organization) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Organization) GetOwnerID() string {
+	if x != nil {
+		return x.OwnerID
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Organization) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// OrganizationMember 表示组织成员
+type OrganizationMember struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示成员的用户 ID
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// role 表示成员在组织中的角色，可选值：role::org-admin、role::org-member
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationMember) Reset() {
+	*x = OrganizationMember{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationMember) ProtoMessage() {}
+
+func (x *OrganizationMember) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationMember.ProtoReflect.Descriptor instead.
+func (*OrganizationMember) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrganizationMember) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// CreateOrganizationRequest 表示创建组织请求
+type CreateOrganizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name 表示组织名称
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// description 表示组织描述
+	Description   string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrganizationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// CreateOrganizationResponse 表示创建组织响应
+type CreateOrganizationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orgID 表示创建的组织 ID
+	OrgID         string `protobuf:"bytes,1,opt,name=orgID,proto3" json:"orgID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationResponse) Reset() {
+	*x = CreateOrganizationResponse{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationResponse) ProtoMessage() {}
+
+func (x *CreateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateOrganizationResponse) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+// DeleteOrganizationRequest 表示删除组织请求
+type DeleteOrganizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orgID 表示要删除的组织 ID
+	// @gotags: uri:"orgID"
+	OrgID         string `protobuf:"bytes,1,opt,name=orgID,proto3" json:"orgID,omitempty" uri:"orgID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrganizationRequest) Reset() {
+	*x = DeleteOrganizationRequest{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrganizationRequest) ProtoMessage() {}
+
+func (x *DeleteOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteOrganizationRequest) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+// DeleteOrganizationResponse 表示删除组织响应
+type DeleteOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrganizationResponse) Reset() {
+	*x = DeleteOrganizationResponse{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrganizationResponse) ProtoMessage() {}
+
+func (x *DeleteOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{5}
+}
+
+// GetOrganizationRequest 表示获取组织详情请求
+type GetOrganizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orgID 表示要获取的组织 ID
+	// @gotags: uri:"orgID"
+	OrgID         string `protobuf:"bytes,1,opt,name=orgID,proto3" json:"orgID,omitempty" uri:"orgID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationRequest) ProtoMessage() {}
+
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrganizationRequest) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+// GetOrganizationResponse 表示获取组织详情响应
+type GetOrganizationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// organization 表示返回的组织信息
+	Organization  *Organization `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationResponse) Reset() {
+	*x = GetOrganizationResponse{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationResponse) ProtoMessage() {}
+
+func (x *GetOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*GetOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+// ListOrganizationRequest 表示获取当前用户所属组织列表请求
+type ListOrganizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	// @gotags: form:"offset"
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty" form:"offset"`
+	// limit 表示每页数量
+	// @gotags: form:"limit"
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationRequest) Reset() {
+	*x = ListOrganizationRequest{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationRequest) ProtoMessage() {}
+
+func (x *ListOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListOrganizationRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListOrganizationRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListOrganizationResponse 表示获取当前用户所属组织列表响应
+type ListOrganizationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// totalCount 表示组织总数
+	TotalCount int64 `protobuf:"varint,1,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	// organizations 表示组织列表
+	Organizations []*Organization `protobuf:"bytes,2,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationResponse) Reset() {
+	*x = ListOrganizationResponse{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationResponse) ProtoMessage() {}
+
+func (x *ListOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListOrganizationResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListOrganizationResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+// AddOrganizationMemberRequest 表示添加组织成员请求，成员已存在时修改其角色
+type AddOrganizationMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orgID 表示组织 ID
+	// @gotags: uri:"orgID"
+	OrgID string `protobuf:"bytes,1,opt,name=orgID,proto3" json:"orgID,omitempty" uri:"orgID"`
+	// userID 表示要添加的用户 ID
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// role 表示成员在组织中的角色，可选值：role::org-admin、role::org-member
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrganizationMemberRequest) Reset() {
+	*x = AddOrganizationMemberRequest{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *AddOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddOrganizationMemberRequest) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// AddOrganizationMemberResponse 表示添加组织成员响应
+type AddOrganizationMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrganizationMemberResponse) Reset() {
+	*x = AddOrganizationMemberResponse{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrganizationMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrganizationMemberResponse) ProtoMessage() {}
+
+func (x *AddOrganizationMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrganizationMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddOrganizationMemberResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{11}
+}
+
+// RemoveOrganizationMemberRequest 表示移除组织成员请求
+type RemoveOrganizationMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orgID 表示组织 ID
+	// @gotags: uri:"orgID"
+	OrgID string `protobuf:"bytes,1,opt,name=orgID,proto3" json:"orgID,omitempty" uri:"orgID"`
+	// userID 表示要移除的用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveOrganizationMemberRequest) Reset() {
+	*x = RemoveOrganizationMemberRequest{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *RemoveOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveOrganizationMemberRequest) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+func (x *RemoveOrganizationMemberRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// RemoveOrganizationMemberResponse 表示移除组织成员响应
+type RemoveOrganizationMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveOrganizationMemberResponse) Reset() {
+	*x = RemoveOrganizationMemberResponse{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganizationMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganizationMemberResponse) ProtoMessage() {}
+
+func (x *RemoveOrganizationMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOrganizationMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveOrganizationMemberResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{13}
+}
+
+// ListOrganizationMemberRequest 表示获取组织成员列表请求
+type ListOrganizationMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orgID 表示组织 ID
+	// @gotags: uri:"orgID"
+	OrgID         string `protobuf:"bytes,1,opt,name=orgID,proto3" json:"orgID,omitempty" uri:"orgID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMemberRequest) Reset() {
+	*x = ListOrganizationMemberRequest{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *ListOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListOrganizationMemberRequest) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+// ListOrganizationMemberResponse 表示获取组织成员列表响应
+type ListOrganizationMemberResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// members 表示组织成员列表
+	Members       []*OrganizationMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMemberResponse) Reset() {
+	*x = ListOrganizationMemberResponse{}
+	mi := &file_apiserver_v1_organization_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMemberResponse) ProtoMessage() {}
+
+func (x *ListOrganizationMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_organization_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMemberResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMemberResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_organization_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListOrganizationMemberResponse) GetMembers() []*OrganizationMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+var File_apiserver_v1_organization_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_organization_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapiserver/v1/organization.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe8\x01\n" +
+	"\fOrganization\x12\x14\n" +
+	"\x05orgID\x18\x01 \x01(\tR\x05orgID\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aownerID\x18\x04 \x01(\tR\aownerID\x128\n" +
+	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"@\n" +
+	"\x12OrganizationMember\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"Q\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"2\n" +
+	"\x1aCreateOrganizationResponse\x12\x14\n" +
+	"\x05orgID\x18\x01 \x01(\tR\x05orgID\"1\n" +
+	"\x19DeleteOrganizationRequest\x12\x14\n" +
+	"\x05orgID\x18\x01 \x01(\tR\x05orgID\"\x1c\n" +
+	"\x1aDeleteOrganizationResponse\".\n" +
+	"\x16GetOrganizationRequest\x12\x14\n" +
+	"\x05orgID\x18\x01 \x01(\tR\x05orgID\"O\n" +
+	"\x17GetOrganizationResponse\x124\n" +
+	"\forganization\x18\x01 \x01(\v2\x10.v1.OrganizationR\forganization\"G\n" +
+	"\x17ListOrganizationRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"r\n" +
+	"\x18ListOrganizationResponse\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x01 \x01(\x03R\n" +
+	"totalCount\x126\n" +
+	"\rorganizations\x18\x02 \x03(\v2\x10.v1.OrganizationR\rorganizations\"`\n" +
+	"\x1cAddOrganizationMemberRequest\x12\x14\n" +
+	"\x05orgID\x18\x01 \x01(\tR\x05orgID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"\x1f\n" +
+	"\x1dAddOrganizationMemberResponse\"O\n" +
+	"\x1fRemoveOrganizationMemberRequest\x12\x14\n" +
+	"\x05orgID\x18\x01 \x01(\tR\x05orgID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\"\"\n" +
+	" RemoveOrganizationMemberResponse\"5\n" +
+	"\x1dListOrganizationMemberRequest\x12\x14\n" +
+	"\x05orgID\x18\x01 \x01(\tR\x05orgID\"R\n" +
+	"\x1eListOrganizationMemberResponse\x120\n" +
+	"\amembers\x18\x01 \x03(\v2\x16.v1.OrganizationMemberR\amembersB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+
+var (
+	file_apiserver_v1_organization_proto_rawDescOnce sync.Once
+	file_apiserver_v1_organization_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_organization_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_organization_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_organization_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_organization_proto_rawDesc), len(file_apiserver_v1_organization_proto_rawDesc)))
+	})
+	return file_apiserver_v1_organization_proto_rawDescData
+}
+
+var file_apiserver_v1_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_apiserver_v1_organization_proto_goTypes = []any{
+	(*Organization)(nil),                     // 0: v1.Organization
+	(*OrganizationMember)(nil),               // 1: v1.OrganizationMember
+	(*CreateOrganizationRequest)(nil),        // 2: v1.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil),       // 3: v1.CreateOrganizationResponse
+	(*DeleteOrganizationRequest)(nil),        // 4: v1.DeleteOrganizationRequest
+	(*DeleteOrganizationResponse)(nil),       // 5: v1.DeleteOrganizationResponse
+	(*GetOrganizationRequest)(nil),           // 6: v1.GetOrganizationRequest
+	(*GetOrganizationResponse)(nil),          // 7: v1.GetOrganizationResponse
+	(*ListOrganizationRequest)(nil),          // 8: v1.ListOrganizationRequest
+	(*ListOrganizationResponse)(nil),         // 9: v1.ListOrganizationResponse
+	(*AddOrganizationMemberRequest)(nil),     // 10: v1.AddOrganizationMemberRequest
+	(*AddOrganizationMemberResponse)(nil),    // 11: v1.AddOrganizationMemberResponse
+	(*RemoveOrganizationMemberRequest)(nil),  // 12: v1.RemoveOrganizationMemberRequest
+	(*RemoveOrganizationMemberResponse)(nil), // 13: v1.RemoveOrganizationMemberResponse
+	(*ListOrganizationMemberRequest)(nil),    // 14: v1.ListOrganizationMemberRequest
+	(*ListOrganizationMemberResponse)(nil),   // 15: v1.ListOrganizationMemberResponse
+	(*timestamppb.Timestamp)(nil),            // 16: google.protobuf.Timestamp
+}
+var file_apiserver_v1_organization_proto_depIdxs = []int32{
+	16, // 0: v1.Organization.createdAt:type_name -> google.protobuf.Timestamp
+	16, // 1: v1.Organization.updatedAt:type_name -> google.protobuf.Timestamp
+	0,  // 2: v1.GetOrganizationResponse.organization:type_name -> v1.Organization
+	0,  // 3: v1.ListOrganizationResponse.organizations:type_name -> v1.Organization
+	1,  // 4: v1.ListOrganizationMemberResponse.members:type_name -> v1.OrganizationMember
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_organization_proto_init() }
+func file_apiserver_v1_organization_proto_init() {
+	if File_apiserver_v1_organization_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_organization_proto_rawDesc), len(file_apiserver_v1_organization_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_organization_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_organization_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_organization_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_organization_proto = out.File
+	file_apiserver_v1_organization_proto_goTypes = nil
+	file_apiserver_v1_organization_proto_depIdxs = nil
+}
@@ -0,0 +1,363 @@
+// Passwordless API 定义，包含邮件登录链接和短信验证码登录相关的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.20.1
+// source: apiserver/v1/passwordless.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RequestMagicLinkRequest 表示申请邮件登录链接的请求
+type RequestMagicLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// email 表示接收登录链接的电子邮箱地址
+	Email         string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMagicLinkRequest) Reset() {
+	*x = RequestMagicLinkRequest{}
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMagicLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMagicLinkRequest) ProtoMessage() {}
+
+func (x *RequestMagicLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_passwordless_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestMagicLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// RequestMagicLinkResponse 表示申请邮件登录链接的响应
+// 无论邮箱是否存在都返回成功，避免通过该接口判断邮箱是否已注册
+type RequestMagicLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMagicLinkResponse) Reset() {
+	*x = RequestMagicLinkResponse{}
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMagicLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMagicLinkResponse) ProtoMessage() {}
+
+func (x *RequestMagicLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMagicLinkResponse.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_passwordless_proto_rawDescGZIP(), []int{1}
+}
+
+// MagicLinkLoginRequest 表示使用邮件登录链接登录的请求
+type MagicLinkLoginRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token 表示登录链接中的一次性令牌
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MagicLinkLoginRequest) Reset() {
+	*x = MagicLinkLoginRequest{}
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MagicLinkLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MagicLinkLoginRequest) ProtoMessage() {}
+
+func (x *MagicLinkLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MagicLinkLoginRequest.ProtoReflect.Descriptor instead.
+func (*MagicLinkLoginRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_passwordless_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MagicLinkLoginRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// RequestLoginCodeRequest 表示申请短信登录验证码的请求
+type RequestLoginCodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// phone 表示接收验证码的手机号
+	Phone         string `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestLoginCodeRequest) Reset() {
+	*x = RequestLoginCodeRequest{}
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLoginCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginCodeRequest) ProtoMessage() {}
+
+func (x *RequestLoginCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginCodeRequest.ProtoReflect.Descriptor instead.
+func (*RequestLoginCodeRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_passwordless_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RequestLoginCodeRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+// RequestLoginCodeResponse 表示申请短信登录验证码的响应
+// 无论手机号是否存在都返回成功，避免通过该接口判断手机号是否已注册
+type RequestLoginCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestLoginCodeResponse) Reset() {
+	*x = RequestLoginCodeResponse{}
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLoginCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginCodeResponse) ProtoMessage() {}
+
+func (x *RequestLoginCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginCodeResponse.ProtoReflect.Descriptor instead.
+func (*RequestLoginCodeResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_passwordless_proto_rawDescGZIP(), []int{4}
+}
+
+// LoginWithCodeRequest 表示使用短信验证码登录的请求
+type LoginWithCodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// phone 表示用户手机号
+	Phone string `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	// code 表示短信中的一次性验证码
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithCodeRequest) Reset() {
+	*x = LoginWithCodeRequest{}
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithCodeRequest) ProtoMessage() {}
+
+func (x *LoginWithCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_passwordless_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithCodeRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithCodeRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_passwordless_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LoginWithCodeRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *LoginWithCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+var File_apiserver_v1_passwordless_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_passwordless_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapiserver/v1/passwordless.proto\x12\x02v1\"/\n" +
+	"\x17RequestMagicLinkRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x1a\n" +
+	"\x18RequestMagicLinkResponse\"-\n" +
+	"\x15MagicLinkLoginRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"/\n" +
+	"\x17RequestLoginCodeRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\"\x1a\n" +
+	"\x18RequestLoginCodeResponse\"@\n" +
+	"\x14LoginWithCodeRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04codeB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+
+var (
+	file_apiserver_v1_passwordless_proto_rawDescOnce sync.Once
+	file_apiserver_v1_passwordless_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_passwordless_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_passwordless_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_passwordless_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_passwordless_proto_rawDesc), len(file_apiserver_v1_passwordless_proto_rawDesc)))
+	})
+	return file_apiserver_v1_passwordless_proto_rawDescData
+}
+
+var file_apiserver_v1_passwordless_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_apiserver_v1_passwordless_proto_goTypes = []any{
+	(*RequestMagicLinkRequest)(nil),  // 0: v1.RequestMagicLinkRequest
+	(*RequestMagicLinkResponse)(nil), // 1: v1.RequestMagicLinkResponse
+	(*MagicLinkLoginRequest)(nil),    // 2: v1.MagicLinkLoginRequest
+	(*RequestLoginCodeRequest)(nil),  // 3: v1.RequestLoginCodeRequest
+	(*RequestLoginCodeResponse)(nil), // 4: v1.RequestLoginCodeResponse
+	(*LoginWithCodeRequest)(nil),     // 5: v1.LoginWithCodeRequest
+}
+var file_apiserver_v1_passwordless_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_passwordless_proto_init() }
+func file_apiserver_v1_passwordless_proto_init() {
+	if File_apiserver_v1_passwordless_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_passwordless_proto_rawDesc), len(file_apiserver_v1_passwordless_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_passwordless_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_passwordless_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_passwordless_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_passwordless_proto = out.File
+	file_apiserver_v1_passwordless_proto_goTypes = nil
+	file_apiserver_v1_passwordless_proto_depIdxs = nil
+}
@@ -11,6 +11,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -38,7 +39,11 @@ type Post struct {
 	// createdAt 表示博客创建时间
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	// updatedAt 表示博客最后更新时间
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// orgID 表示博客所属的组织 ID，为空表示不属于任何组织
+	OrgID string `protobuf:"bytes,7,opt,name=orgID,proto3" json:"orgID,omitempty"`
+	// etag 表示博客的当前版本，更新博客时传入以避免覆盖其他人的修改
+	Etag          string `protobuf:"bytes,8,opt,name=etag,proto3" json:"etag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -115,6 +120,20 @@ func (x *Post) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Post) GetOrgID() string {
+	if x != nil {
+		return x.OrgID
+	}
+	return ""
+}
+
+func (x *Post) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
 // CreatePostRequest 表示创建文章请求
 type CreatePostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -224,7 +243,12 @@ type UpdatePostRequest struct {
 	// title 表示更新后的博客标题
 	Title *string `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
 	// content 表示更新后的博客内容
-	Content       *string `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	Content *string `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	// etag 表示期望的博客版本，不为空时仅当博客的当前版本与之一致才会更新，也可以通过 If-Match 请求头传入
+	Etag string `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	// updateMask 表示要更新的字段，可选值：title、content. 其中未设置值的字段会被清空.
+	// 为空时只更新请求中设置了值的字段
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -280,6 +304,20 @@ func (x *UpdatePostRequest) GetContent() string {
 	return ""
 }
 
+func (x *UpdatePostRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UpdatePostRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 // UpdatePostResponse 表示更新文章响应
 type UpdatePostResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -405,9 +443,14 @@ type GetPostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// postID 表示要获取的文章 ID
 	// @gotags: uri:"postID"
-	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
+	// ifNoneMatch 表示调用方缓存的 etag，与博客当前的 etag 一致时不返回博客，也可以通过 If-None-Match 请求头传入
+	IfNoneMatch string `protobuf:"bytes,2,opt,name=ifNoneMatch,proto3" json:"ifNoneMatch,omitempty"`
+	// ifModifiedSince 表示调用方缓存的时间，博客在此之后没有修改时不返回博客，也可以通过 If-Modified-Since 请求头传入.
+	// 传入 ifNoneMatch 时忽略该字段
+	IfModifiedSince *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ifModifiedSince,proto3" json:"ifModifiedSince,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetPostRequest) Reset() {
@@ -447,11 +490,31 @@ func (x *GetPostRequest) GetPostID() string {
 	return ""
 }
 
+func (x *GetPostRequest) GetIfNoneMatch() string {
+	if x != nil {
+		return x.IfNoneMatch
+	}
+	return ""
+}
+
+func (x *GetPostRequest) GetIfModifiedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IfModifiedSince
+	}
+	return nil
+}
+
 // GetPostResponse 表示获取文章响应
 type GetPostResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// post 表示返回的文章信息
-	Post          *Post `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	// post 表示返回的文章信息，notModified 为 true 时为空
+	Post *Post `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	// notModified 表示博客自调用方缓存后没有修改，调用方可以继续使用缓存
+	NotModified bool `protobuf:"varint,2,opt,name=notModified,proto3" json:"notModified,omitempty"`
+	// etag 表示博客当前的 etag，通过 HTTP 访问时同时作为 ETag 响应头返回
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// lastModified 表示博客的最后修改时间，通过 HTTP 访问时同时作为 Last-Modified 响应头返回
+	LastModified  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -493,6 +556,27 @@ func (x *GetPostResponse) GetPost() *Post {
 	return nil
 }
 
+func (x *GetPostResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+func (x *GetPostResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *GetPostResponse) GetLastModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastModified
+	}
+	return nil
+}
+
 // ListPostRequest 表示获取文章列表请求
 type ListPostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -503,7 +587,9 @@ type ListPostRequest struct {
 	// @gotags: form:"limit"
 	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
 	// title 表示可选的标题过滤
-	Title         *string `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Title *string `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// ifNoneMatch 表示调用方缓存的 etag，与文章列表当前的 etag 一致时不返回文章列表，也可以通过 If-None-Match 请求头传入
+	IfNoneMatch   string `protobuf:"bytes,4,opt,name=ifNoneMatch,proto3" json:"ifNoneMatch,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -559,13 +645,24 @@ func (x *ListPostRequest) GetTitle() string {
 	return ""
 }
 
+func (x *ListPostRequest) GetIfNoneMatch() string {
+	if x != nil {
+		return x.IfNoneMatch
+	}
+	return ""
+}
+
 // ListPostResponse 表示获取文章列表响应
 type ListPostResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// total_count 表示总文章数
 	TotalCount int64 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	// posts 表示文章列表
-	Posts         []*Post `protobuf:"bytes,2,rep,name=posts,proto3" json:"posts,omitempty"`
+	// posts 表示文章列表，notModified 为 true 时为空
+	Posts []*Post `protobuf:"bytes,2,rep,name=posts,proto3" json:"posts,omitempty"`
+	// notModified 表示文章列表自调用方缓存后没有变化，调用方可以继续使用缓存
+	NotModified bool `protobuf:"varint,3,opt,name=notModified,proto3" json:"notModified,omitempty"`
+	// etag 表示文章列表当前的 etag，通过 HTTP 访问时同时作为 ETag 响应头返回
+	Etag          string `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -614,47 +711,75 @@ func (x *ListPostResponse) GetPosts() []*Post {
 	return nil
 }
 
+func (x *ListPostResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+func (x *ListPostResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
 var File_apiserver_v1_post_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_post_proto_rawDesc = "" +
 	"\n" +
-	"\x17apiserver/v1/post.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xda\x01\n" +
+	"\x17apiserver/v1/post.proto\x12\x02v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\x02\n" +
 	"\x04Post\x12\x16\n" +
 	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x16\n" +
 	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x04 \x01(\tR\acontent\x128\n" +
 	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
-	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"C\n" +
+	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05orgID\x18\a \x01(\tR\x05orgID\x12\x12\n" +
+	"\x04etag\x18\b \x01(\tR\x04etag\"C\n" +
 	"\x11CreatePostRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\",\n" +
 	"\x12CreatePostResponse\x12\x16\n" +
-	"\x06postID\x18\x01 \x01(\tR\x06postID\"{\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\xcb\x01\n" +
 	"\x11UpdatePostRequest\x12\x16\n" +
 	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
-	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01B\b\n" +
+	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x12\x12\n" +
+	"\x04etag\x18\x04 \x01(\tR\x04etag\x12:\n" +
+	"\n" +
+	"updateMask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\b\n" +
 	"\x06_titleB\n" +
 	"\n" +
 	"\b_content\"\x14\n" +
 	"\x12UpdatePostResponse\"-\n" +
 	"\x11DeletePostRequest\x12\x18\n" +
 	"\apostIDs\x18\x01 \x03(\tR\apostIDs\"\x14\n" +
-	"\x12DeletePostResponse\"(\n" +
+	"\x12DeletePostResponse\"\x90\x01\n" +
 	"\x0eGetPostRequest\x12\x16\n" +
-	"\x06postID\x18\x01 \x01(\tR\x06postID\"/\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12 \n" +
+	"\vifNoneMatch\x18\x02 \x01(\tR\vifNoneMatch\x12D\n" +
+	"\x0fifModifiedSince\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0fifModifiedSince\"\xa5\x01\n" +
 	"\x0fGetPostResponse\x12\x1c\n" +
-	"\x04post\x18\x01 \x01(\v2\b.v1.PostR\x04post\"d\n" +
+	"\x04post\x18\x01 \x01(\v2\b.v1.PostR\x04post\x12 \n" +
+	"\vnotModified\x18\x02 \x01(\bR\vnotModified\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\x12>\n" +
+	"\flastModified\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastModified\"\x86\x01\n" +
 	"\x0fListPostRequest\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x19\n" +
-	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01B\b\n" +
-	"\x06_title\"S\n" +
+	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01\x12 \n" +
+	"\vifNoneMatch\x18\x04 \x01(\tR\vifNoneMatchB\b\n" +
+	"\x06_title\"\x89\x01\n" +
 	"\x10ListPostResponse\x12\x1f\n" +
 	"\vtotal_count\x18\x01 \x01(\x03R\n" +
 	"totalCount\x12\x1e\n" +
-	"\x05posts\x18\x02 \x03(\v2\b.v1.PostR\x05postsB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+	"\x05posts\x18\x02 \x03(\v2\b.v1.PostR\x05posts\x12 \n" +
+	"\vnotModified\x18\x03 \x01(\bR\vnotModified\x12\x12\n" +
+	"\x04etag\x18\x04 \x01(\tR\x04etagB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
 
 var (
 	file_apiserver_v1_post_proto_rawDescOnce sync.Once
@@ -682,17 +807,21 @@ var file_apiserver_v1_post_proto_goTypes = []any{
 	(*ListPostRequest)(nil),       // 9: v1.ListPostRequest
 	(*ListPostResponse)(nil),      // 10: v1.ListPostResponse
 	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 12: google.protobuf.FieldMask
 }
 var file_apiserver_v1_post_proto_depIdxs = []int32{
 	11, // 0: v1.Post.createdAt:type_name -> google.protobuf.Timestamp
 	11, // 1: v1.Post.updatedAt:type_name -> google.protobuf.Timestamp
-	0,  // 2: v1.GetPostResponse.post:type_name -> v1.Post
-	0,  // 3: v1.ListPostResponse.posts:type_name -> v1.Post
-	4,  // [4:4] is the sub-list for method output_type
-	4,  // [4:4] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	12, // 2: v1.UpdatePostRequest.updateMask:type_name -> google.protobuf.FieldMask
+	11, // 3: v1.GetPostRequest.ifModifiedSince:type_name -> google.protobuf.Timestamp
+	0,  // 4: v1.GetPostResponse.post:type_name -> v1.Post
+	11, // 5: v1.GetPostResponse.lastModified:type_name -> google.protobuf.Timestamp
+	0,  // 6: v1.ListPostResponse.posts:type_name -> v1.Post
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_post_proto_init() }
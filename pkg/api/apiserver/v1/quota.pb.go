@@ -0,0 +1,264 @@
+// Quota API 定义，包含内容配额相关的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.20.1
+// source: apiserver/v1/quota.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Quota 表示用户的内容配额及其用量，上限为 0 表示不限制
+type Quota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// dailyPosts 表示每天最多创建的博客数
+	DailyPosts int64 `protobuf:"varint,1,opt,name=dailyPosts,proto3" json:"dailyPosts,omitempty"`
+	// postsToday 表示当天已创建的博客数
+	PostsToday int64 `protobuf:"varint,2,opt,name=postsToday,proto3" json:"postsToday,omitempty"`
+	// maxStorage 表示博客标题和内容最多占用的字节数
+	MaxStorage int64 `protobuf:"varint,3,opt,name=maxStorage,proto3" json:"maxStorage,omitempty"`
+	// storageBytes 表示博客标题和内容已占用的字节数
+	StorageBytes int64 `protobuf:"varint,4,opt,name=storageBytes,proto3" json:"storageBytes,omitempty"`
+	// resetAt 表示每日配额下一次重置的时间
+	ResetAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=resetAt,proto3" json:"resetAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_apiserver_v1_quota_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_quota_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_quota_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quota) GetDailyPosts() int64 {
+	if x != nil {
+		return x.DailyPosts
+	}
+	return 0
+}
+
+func (x *Quota) GetPostsToday() int64 {
+	if x != nil {
+		return x.PostsToday
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxStorage() int64 {
+	if x != nil {
+		return x.MaxStorage
+	}
+	return 0
+}
+
+func (x *Quota) GetStorageBytes() int64 {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return 0
+}
+
+func (x *Quota) GetResetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResetAt
+	}
+	return nil
+}
+
+// GetMyQuotaRequest 表示查询当前用户配额请求
+type GetMyQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyQuotaRequest) Reset() {
+	*x = GetMyQuotaRequest{}
+	mi := &file_apiserver_v1_quota_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyQuotaRequest) ProtoMessage() {}
+
+func (x *GetMyQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_quota_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetMyQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_quota_proto_rawDescGZIP(), []int{1}
+}
+
+// GetMyQuotaResponse 表示查询当前用户配额响应
+type GetMyQuotaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// quota 表示当前用户的配额及其用量
+	Quota         *Quota `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyQuotaResponse) Reset() {
+	*x = GetMyQuotaResponse{}
+	mi := &file_apiserver_v1_quota_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyQuotaResponse) ProtoMessage() {}
+
+func (x *GetMyQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_quota_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetMyQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_quota_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetMyQuotaResponse) GetQuota() *Quota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+var File_apiserver_v1_quota_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_quota_proto_rawDesc = "" +
+	"\n" +
+	"\x18apiserver/v1/quota.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc1\x01\n" +
+	"\x05Quota\x12\x1e\n" +
+	"\n" +
+	"dailyPosts\x18\x01 \x01(\x03R\n" +
+	"dailyPosts\x12\x1e\n" +
+	"\n" +
+	"postsToday\x18\x02 \x01(\x03R\n" +
+	"postsToday\x12\x1e\n" +
+	"\n" +
+	"maxStorage\x18\x03 \x01(\x03R\n" +
+	"maxStorage\x12\"\n" +
+	"\fstorageBytes\x18\x04 \x01(\x03R\fstorageBytes\x124\n" +
+	"\aresetAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aresetAt\"\x13\n" +
+	"\x11GetMyQuotaRequest\"5\n" +
+	"\x12GetMyQuotaResponse\x12\x1f\n" +
+	"\x05quota\x18\x01 \x01(\v2\t.v1.QuotaR\x05quotaB\x1fZ\x1dminiblog/pkg/api/apiserver/v1b\x06proto3"
+
+var (
+	file_apiserver_v1_quota_proto_rawDescOnce sync.Once
+	file_apiserver_v1_quota_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_quota_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_quota_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_quota_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_quota_proto_rawDesc), len(file_apiserver_v1_quota_proto_rawDesc)))
+	})
+	return file_apiserver_v1_quota_proto_rawDescData
+}
+
+var file_apiserver_v1_quota_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_apiserver_v1_quota_proto_goTypes = []any{
+	(*Quota)(nil),                 // 0: v1.Quota
+	(*GetMyQuotaRequest)(nil),     // 1: v1.GetMyQuotaRequest
+	(*GetMyQuotaResponse)(nil),    // 2: v1.GetMyQuotaResponse
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_apiserver_v1_quota_proto_depIdxs = []int32{
+	3, // 0: v1.Quota.resetAt:type_name -> google.protobuf.Timestamp
+	0, // 1: v1.GetMyQuotaResponse.quota:type_name -> v1.Quota
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_quota_proto_init() }
+func file_apiserver_v1_quota_proto_init() {
+	if File_apiserver_v1_quota_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_quota_proto_rawDesc), len(file_apiserver_v1_quota_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_quota_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_quota_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_quota_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_quota_proto = out.File
+	file_apiserver_v1_quota_proto_goTypes = nil
+	file_apiserver_v1_quota_proto_depIdxs = nil
+}
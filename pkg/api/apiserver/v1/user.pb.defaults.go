@@ -69,3 +69,15 @@ func (x *ListUserRequest) Default() {
 
 func (x *ListUserResponse) Default() {
 }
+
+func (x *UnlockUserRequest) Default() {
+}
+
+func (x *UnlockUserResponse) Default() {
+}
+
+func (x *ImpersonateRequest) Default() {
+}
+
+func (x *ImpersonateResponse) Default() {
+}
@@ -12,6 +12,7 @@ import (
 	_ "github.com/onexstack/protoc-gen-defaults/defaults"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -43,7 +44,11 @@ type User struct {
 	// createdAt 表示用户注册时间
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	// updatedAt 表示用户最后更新时间
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// emailVerified 表示用户电子邮箱是否已验证
+	EmailVerified bool `protobuf:"varint,9,opt,name=emailVerified,proto3" json:"emailVerified,omitempty"`
+	// etag 表示用户的当前版本，更新用户时传入以避免覆盖其他人的修改
+	Etag          string `protobuf:"bytes,10,opt,name=etag,proto3" json:"etag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -134,13 +139,29 @@ func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
 // LoginRequest 表示登录请求
 type LoginRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// username 表示用户名称
 	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	// password 表示用户密码
-	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// provider 表示认证方式，可选值：local、ldap，为空时使用 local
+	Provider      string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -189,15 +210,26 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
 // LoginResponse 表示登录响应
 type LoginResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// token 表示返回的身份验证令牌
+	// token 表示返回的身份验证令牌，开启两步验证时为空
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	// expireAt 表示该 token 的过期时间
-	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// expireAt 表示该 token（或挑战令牌）的过期时间
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	// mfaRequired 表示用户开启了两步验证，需要调用 LoginVerify 完成登录
+	MfaRequired bool `protobuf:"varint,3,opt,name=mfaRequired,proto3" json:"mfaRequired,omitempty"`
+	// challengeToken 表示两步验证的短期挑战令牌，仅在 mfaRequired 为 true 时返回
+	ChallengeToken string `protobuf:"bytes,4,opt,name=challengeToken,proto3" json:"challengeToken,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
@@ -244,6 +276,20 @@ func (x *LoginResponse) GetExpireAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *LoginResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
 // RefreshTokenRequest 表示刷新令牌的请求
 type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -451,7 +497,9 @@ type CreateUserRequest struct {
 	// phone 表示用户手机号
 	Phone string `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
 	// repassword 表示注册是需要的重复密码
-	Repassword    string `protobuf:"bytes,6,opt,name=repassword,proto3" json:"repassword,omitempty"`
+	Repassword string `protobuf:"bytes,6,opt,name=repassword,proto3" json:"repassword,omitempty"`
+	// inviteCode 表示邀请码，仅邀请注册模式下需要
+	InviteCode    string `protobuf:"bytes,7,opt,name=inviteCode,proto3" json:"inviteCode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -528,6 +576,13 @@ func (x *CreateUserRequest) GetRepassword() string {
 	return ""
 }
 
+func (x *CreateUserRequest) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
 // CreateUserResponse 表示创建用户响应
 type CreateUserResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -586,7 +641,12 @@ type UpdateUserRequest struct {
 	// email 表示可选的用户电子邮箱
 	Email *string `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
 	// phone 表示可选的用户手机号
-	Phone         *string `protobuf:"bytes,5,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Phone *string `protobuf:"bytes,5,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	// etag 表示期望的用户版本，不为空时仅当用户的当前版本与之一致才会更新，也可以通过 If-Match 请求头传入
+	Etag string `protobuf:"bytes,6,opt,name=etag,proto3" json:"etag,omitempty"`
+	// updateMask 表示要更新的字段，可选值：username、nickname、email、phone. 其中未设置值的字段会被清空.
+	// 为空时只更新请求中设置了值的字段
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -656,6 +716,20 @@ func (x *UpdateUserRequest) GetPhone() string {
 	return ""
 }
 
+func (x *UpdateUserRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 // UpdateUserResponse 表示更新用户响应
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -982,11 +1056,197 @@ func (x *ListUserResponse) GetUsers() []*User {
 	return nil
 }
 
+// UnlockUserRequest 表示解除用户登录锁定请求
+type UnlockUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserRequest) Reset() {
+	*x = UnlockUserRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserRequest) ProtoMessage() {}
+
+func (x *UnlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnlockUserRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// UnlockUserResponse 表示解除用户登录锁定响应
+type UnlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserResponse) Reset() {
+	*x = UnlockUserResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserResponse) ProtoMessage() {}
+
+func (x *UnlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+// ImpersonateRequest 表示代理用户身份请求
+type ImpersonateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// userID 表示被代理的用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateRequest) Reset() {
+	*x = ImpersonateRequest{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateRequest) ProtoMessage() {}
+
+func (x *ImpersonateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImpersonateRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// ImpersonateResponse 表示代理用户身份响应
+type ImpersonateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token 表示代理身份的短期令牌，令牌中同时包含被代理的用户和真实操作者
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// expireAt 表示该 token 的过期时间
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateResponse) Reset() {
+	*x = ImpersonateResponse{}
+	mi := &file_apiserver_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateResponse) ProtoMessage() {}
+
+func (x *ImpersonateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImpersonateResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImpersonateResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
 var File_apiserver_v1_user_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x17apiserver/v1/user.proto\x12\x02v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a,github.com/onexstack/defaults/defaults.proto\"\xa4\x02\n" +
+	"\x17apiserver/v1/user.proto\x12\x02v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a,github.com/onexstack/defaults/defaults.proto\"\xde\x02\n" +
 	"\x04User\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12*\n" +
@@ -995,13 +1255,19 @@ const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\x05phone\x18\x05 \x01(\tR\x05phone\x12\x1c\n" +
 	"\tpostCount\x18\x06 \x01(\x03R\tpostCount\x128\n" +
 	"\tcreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
-	"\tupdatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"F\n" +
+	"\tupdatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12$\n" +
+	"\remailVerified\x18\t \x01(\bR\remailVerified\x12\x12\n" +
+	"\x04etag\x18\n" +
+	" \x01(\tR\x04etag\"b\n" +
 	"\fLoginRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"]\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\"\xa7\x01\n" +
 	"\rLoginResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
-	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\"\x15\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12 \n" +
+	"\vmfaRequired\x18\x03 \x01(\bR\vmfaRequired\x12&\n" +
+	"\x0echallengeToken\x18\x04 \x01(\tR\x0echallengeToken\"\x15\n" +
 	"\x13RefreshTokenRequest\"d\n" +
 	"\x14RefreshTokenResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
@@ -1010,7 +1276,7 @@ const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12 \n" +
 	"\voldPassword\x18\x02 \x01(\tR\voldPassword\x12 \n" +
 	"\vnewPassword\x18\x03 \x01(\tR\vnewPassword\"\x18\n" +
-	"\x16ChangePasswordResponse\"\xc5\x01\n" +
+	"\x16ChangePasswordResponse\"\xe5\x01\n" +
 	"\x11CreateUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1f\n" +
@@ -1019,16 +1285,23 @@ const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\x05phone\x18\x05 \x01(\tR\x05phone\x12\x1e\n" +
 	"\n" +
 	"repassword\x18\x06 \x01(\tR\n" +
-	"repasswordB\v\n" +
+	"repassword\x12\x1e\n" +
+	"\n" +
+	"inviteCode\x18\a \x01(\tR\n" +
+	"inviteCodeB\v\n" +
 	"\t_nickname\",\n" +
 	"\x12CreateUserResponse\x12\x16\n" +
-	"\x06userID\x18\x01 \x01(\tR\x06userID\"\xd1\x01\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\xa1\x02\n" +
 	"\x11UpdateUserRequest\x12\x16\n" +
 	"\x06userID\x18\x01 \x01(\tR\x06userID\x12\x1f\n" +
 	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12\x1f\n" +
 	"\bnickname\x18\x03 \x01(\tH\x01R\bnickname\x88\x01\x01\x12\x19\n" +
 	"\x05email\x18\x04 \x01(\tH\x02R\x05email\x88\x01\x01\x12\x19\n" +
-	"\x05phone\x18\x05 \x01(\tH\x03R\x05phone\x88\x01\x01B\v\n" +
+	"\x05phone\x18\x05 \x01(\tH\x03R\x05phone\x88\x01\x01\x12\x12\n" +
+	"\x04etag\x18\x06 \x01(\tR\x04etag\x12:\n" +
+	"\n" +
+	"updateMask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\v\n" +
 	"\t_usernameB\v\n" +
 	"\t_nicknameB\b\n" +
 	"\x06_emailB\b\n" +
@@ -1048,7 +1321,15 @@ const file_apiserver_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"totalCount\x18\x01 \x01(\x03R\n" +
 	"totalCount\x12\x1e\n" +
-	"\x05users\x18\x02 \x03(\v2\b.v1.UserR\x05usersB\"Z miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
+	"\x05users\x18\x02 \x03(\v2\b.v1.UserR\x05users\"+\n" +
+	"\x11UnlockUserRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\x14\n" +
+	"\x12UnlockUserResponse\",\n" +
+	"\x12ImpersonateRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"c\n" +
+	"\x13ImpersonateResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAtB\"Z miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
 
 var (
 	file_apiserver_v1_user_proto_rawDescOnce sync.Once
@@ -1062,7 +1343,7 @@ func file_apiserver_v1_user_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_user_proto_rawDescData
 }
 
-var file_apiserver_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_apiserver_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_apiserver_v1_user_proto_goTypes = []any{
 	(*User)(nil),                   // 0: v1.User
 	(*LoginRequest)(nil),           // 1: v1.LoginRequest
@@ -1081,20 +1362,27 @@ var file_apiserver_v1_user_proto_goTypes = []any{
 	(*GetUserResponse)(nil),        // 14: v1.GetUserResponse
 	(*ListUserRequest)(nil),        // 15: v1.ListUserRequest
 	(*ListUserResponse)(nil),       // 16: v1.ListUserResponse
-	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
+	(*UnlockUserRequest)(nil),      // 17: v1.UnlockUserRequest
+	(*UnlockUserResponse)(nil),     // 18: v1.UnlockUserResponse
+	(*ImpersonateRequest)(nil),     // 19: v1.ImpersonateRequest
+	(*ImpersonateResponse)(nil),    // 20: v1.ImpersonateResponse
+	(*timestamppb.Timestamp)(nil),  // 21: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),  // 22: google.protobuf.FieldMask
 }
 var file_apiserver_v1_user_proto_depIdxs = []int32{
-	17, // 0: v1.User.createdAt:type_name -> google.protobuf.Timestamp
-	17, // 1: v1.User.updatedAt:type_name -> google.protobuf.Timestamp
-	17, // 2: v1.LoginResponse.expireAt:type_name -> google.protobuf.Timestamp
-	17, // 3: v1.RefreshTokenResponse.expireAt:type_name -> google.protobuf.Timestamp
-	0,  // 4: v1.GetUserResponse.user:type_name -> v1.User
-	0,  // 5: v1.ListUserResponse.users:type_name -> v1.User
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	21, // 0: v1.User.createdAt:type_name -> google.protobuf.Timestamp
+	21, // 1: v1.User.updatedAt:type_name -> google.protobuf.Timestamp
+	21, // 2: v1.LoginResponse.expireAt:type_name -> google.protobuf.Timestamp
+	21, // 3: v1.RefreshTokenResponse.expireAt:type_name -> google.protobuf.Timestamp
+	22, // 4: v1.UpdateUserRequest.updateMask:type_name -> google.protobuf.FieldMask
+	0,  // 5: v1.GetUserResponse.user:type_name -> v1.User
+	0,  // 6: v1.ListUserResponse.users:type_name -> v1.User
+	21, // 7: v1.ImpersonateResponse.expireAt:type_name -> google.protobuf.Timestamp
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_user_proto_init() }
@@ -1110,7 +1398,7 @@ func file_apiserver_v1_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_user_proto_rawDesc), len(file_apiserver_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,160 @@
+// Package audit 提供审计日志能力：记录修改类操作的操作者、目标资源和结果，并异步写入存储.
+package audit
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBufferSize 定义默认的事件缓冲区大小.
+	defaultBufferSize = 1024
+	// maxBatchSize 定义一次写入存储的最大事件数.
+	maxBatchSize = 100
+)
+
+// Event 表示一条审计事件.
+type Event struct {
+	// Time 表示请求开始的时间.
+	Time time.Time `json:"time"`
+	// RequestID 表示请求 ID.
+	RequestID string `json:"requestID"`
+	// UserID 表示操作者的用户 ID，未认证的请求为空.
+	UserID string `json:"userID"`
+	// ImpersonatorID 表示代理身份的真实操作者的用户 ID，此时 UserID 为被代理的用户，不是代理身份时为空.
+	ImpersonatorID string `json:"impersonatorID,omitempty"`
+	// Method 表示调用的方法，gRPC 请求为方法全名，HTTP 请求为 "<METHOD> <路由模板>".
+	Method string `json:"method"`
+	// ResourceIDs 表示请求和响应中出现的资源 ID.
+	ResourceIDs []string `json:"resourceIDs"`
+	// Code 表示请求结果的 HTTP 状态码.
+	Code int `json:"code"`
+	// Reason 表示失败请求的错误原因，成功时为空.
+	Reason string `json:"reason"`
+	// Request 表示脱敏后的请求摘要.
+	Request string `json:"request"`
+	// ClientIP 表示客户端 IP.
+	ClientIP string `json:"clientIP"`
+	// Latency 表示请求耗时.
+	Latency time.Duration `json:"latency"`
+}
+
+// Sink 定义审计事件的存储接口.
+type Sink interface {
+	// Write 批量写入审计事件.
+	Write(ctx context.Context, events []*Event) error
+}
+
+// Option 定义 Recorder 的可选配置.
+type Option func(*Recorder)
+
+// WithBufferSize 设置事件缓冲区大小. 缓冲区满时丢弃新的事件，不阻塞请求.
+func WithBufferSize(size int) Option {
+	return func(r *Recorder) {
+		if size > 0 {
+			r.events = make(chan *Event, size)
+		}
+	}
+}
+
+// WithErrorHandler 设置写入存储失败时的回调，默认忽略错误.
+func WithErrorHandler(handler func(err error, events []*Event)) Option {
+	return func(r *Recorder) {
+		r.onError = handler
+	}
+}
+
+// Recorder 异步记录审计事件. 事件先进入缓冲区，由后台协程批量写入 Sink.
+type Recorder struct {
+	sink    Sink
+	events  chan *Event
+	onError func(err error, events []*Event)
+
+	// mu 保护 closed，避免关闭缓冲区后继续写入
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// NewRecorder 创建一个 *Recorder 实例，并启动后台写入协程.
+func NewRecorder(sink Sink, opts ...Option) *Recorder {
+	r := &Recorder{
+		sink:    sink,
+		events:  make(chan *Event, defaultBufferSize),
+		onError: func(error, []*Event) {},
+		done:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	go r.run()
+	return r
+}
+
+// Record 将事件放入缓冲区，缓冲区已满或 Recorder 已关闭时丢弃事件并返回 false.
+func (r *Recorder) Record(event *Event) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return false
+	}
+
+	select {
+	case r.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close 停止接收新的事件，并等待缓冲区中的事件写入完成或 ctx 结束.
+// 事件写入完成后，如果 Sink 实现了 io.Closer，会关闭 Sink.
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if closer, ok := r.sink.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// run 从缓冲区中读取事件，并批量写入 Sink.
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	batch := make([]*Event, 0, maxBatchSize)
+	for event := range r.events {
+		batch = append(batch[:0], event)
+		// 一次取出缓冲区中已有的事件，减少写入次数
+	drain:
+		for len(batch) < maxBatchSize {
+			select {
+			case event, ok := <-r.events:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, event)
+			default:
+				break drain
+			}
+		}
+
+		if err := r.sink.Write(context.Background(), batch); err != nil {
+			r.onError(err, batch)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// memorySink 将事件保存在内存中，用于测试.
+type memorySink struct {
+	mu     sync.Mutex
+	events []*Event
+}
+
+func (s *memorySink) Write(ctx context.Context, events []*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func TestSummarize(t *testing.T) {
+	summary, ids := Summarize([]byte(`{"postIDs":["post-1","post-2"],"userID":"user-1","password":"secret","nested":{"apiKey":"k","title":"t"},"keyID":"key-1"}`))
+
+	for _, s := range []string{"secret", `"k"`} {
+		if strings.Contains(summary, s) {
+			t.Errorf("Summarize() summary = %s, should not contain %s", summary, s)
+		}
+	}
+	if !strings.Contains(summary, `"title":"t"`) {
+		t.Errorf("Summarize() summary = %s, should contain the title", summary)
+	}
+
+	slices.Sort(ids)
+	if want := []string{"key-1", "post-1", "post-2", "user-1"}; !slices.Equal(ids, want) {
+		t.Errorf("Summarize() ids = %v, want %v", ids, want)
+	}
+
+	if summary, ids := Summarize([]byte("not json")); summary != "" || ids != nil {
+		t.Errorf("Summarize(invalid) = %q, %v, want empty", summary, ids)
+	}
+
+	if summary, _ := Summarize([]byte(`{"content":"` + strings.Repeat("博", maxSummaryLength) + `"}`)); len(summary) > maxSummaryLength+3 {
+		t.Errorf("Summarize() summary length = %d, want <= %d", len(summary), maxSummaryLength+3)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	sink := &memorySink{}
+	r := NewRecorder(sink)
+
+	for i := 0; i < 10; i++ {
+		if !r.Record(&Event{Method: "POST /v1/posts"}) {
+			t.Fatalf("Record() = false, want true")
+		}
+	}
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if len(sink.events) != 10 {
+		t.Errorf("got %d events, want 10", len(sink.events))
+	}
+	if r.Record(&Event{}) {
+		t.Errorf("Record() after Close = true, want false")
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(context.Background(), []*Event{{UserID: "user-1"}, {UserID: "user-2"}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"userID":"user-2"`) {
+		t.Errorf("file content = %q, want 2 JSON lines", data)
+	}
+}
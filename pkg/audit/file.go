@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileSink 将审计事件以 JSON Lines 格式追加写入文件.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// 确保 *FileSink 实现了 Sink 接口.
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink 创建一个 *FileSink 实例，文件不存在时自动创建.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Write 将事件逐行写入文件.
+func (s *FileSink) Write(ctx context.Context, events []*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := bufio.NewWriter(s.file)
+	enc := json.NewEncoder(w)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Close 关闭文件.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+const (
+	// redacted 是敏感字段脱敏后的值.
+	redacted = "***"
+	// maxSummaryLength 定义请求摘要的最大长度，超出部分被截断.
+	maxSummaryLength = 1024
+)
+
+// sensitiveKeys 定义需要脱敏的字段名关键字，字段名（不区分大小写）包含其中任意一个即视为敏感字段.
+var sensitiveKeys = []string{"password", "secret", "token", "code", "key", "otp", "credential"}
+
+// Summarize 解析 JSON 格式的请求，返回脱敏后的请求摘要以及请求中的资源 ID.
+// 资源 ID 指名称以 ID 或 IDs 结尾的字段的值，这类字段不会被脱敏. 无法解析为 JSON 时摘要为空.
+func Summarize(data []byte) (string, []string) {
+	if len(data) == 0 {
+		return "", nil
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", nil
+	}
+
+	var ids []string
+	v = redact(v, &ids)
+
+	data, _ = json.Marshal(v)
+	summary := string(data)
+	if len(summary) > maxSummaryLength {
+		// 截断时去掉被截断的不完整字符
+		summary = strings.ToValidUTF8(summary[:maxSummaryLength], "") + "..."
+	}
+	return summary, ids
+}
+
+// ResourceIDs 返回 JSON 数据中的资源 ID.
+func ResourceIDs(data []byte) []string {
+	_, ids := Summarize(data)
+	return ids
+}
+
+// MergeIDs 合并多组资源 ID，去除重复和空值并保持出现顺序.
+func MergeIDs(groups ...[]string) []string {
+	var ret []string
+	for _, ids := range groups {
+		for _, id := range ids {
+			if id != "" && !slices.Contains(ret, id) {
+				ret = append(ret, id)
+			}
+		}
+	}
+	return ret
+}
+
+// redact 递归地脱敏 v 中的敏感字段，并收集资源 ID.
+func redact(v any, ids *[]string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			switch {
+			case isIDKey(key):
+				collectIDs(value, ids)
+			case isSensitiveKey(key):
+				v[key] = redacted
+			default:
+				v[key] = redact(value, ids)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redact(value, ids)
+		}
+	}
+	return v
+}
+
+// collectIDs 收集资源 ID 字段的值，字段可以是字符串或字符串数组.
+func collectIDs(v any, ids *[]string) {
+	switch v := v.(type) {
+	case string:
+		*ids = MergeIDs(*ids, []string{v})
+	case []any:
+		for _, value := range v {
+			collectIDs(value, ids)
+		}
+	}
+}
+
+// isIDKey 判断字段是否为资源 ID 字段.
+func isIDKey(key string) bool {
+	return strings.HasSuffix(key, "ID") || strings.HasSuffix(key, "IDs")
+}
+
+// isSensitiveKey 判断字段是否为敏感字段.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(sensitiveKeys, func(s string) bool {
+		return strings.Contains(key, s)
+	})
+}
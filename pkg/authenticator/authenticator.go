@@ -0,0 +1,110 @@
+// Package authenticator 定义可插拔的用户认证方式，包括本地密码、LDAP 和 OIDC.
+package authenticator
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+const (
+	// ProviderLocal 表示使用 miniblog 本地账号密码认证.
+	ProviderLocal = "local"
+	// ProviderLDAP 表示使用 LDAP 目录服务认证.
+	ProviderLDAP = "ldap"
+	// ProviderOIDC 表示使用 OpenID Connect 授权码流程认证.
+	ProviderOIDC = "oidc"
+)
+
+var (
+	// ErrInvalidCredentials 表示用户名或密码错误.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrProviderNotFound 表示认证方式不存在或未启用.
+	ErrProviderNotFound = errors.New("authentication provider not found")
+)
+
+// Identity 表示认证方式认证成功后返回的身份信息.
+type Identity struct {
+	// Provider 表示认证方式名称.
+	Provider string
+	// Subject 表示用户在认证方式中的唯一且稳定的标识.
+	// 对于本地认证，Subject 即 miniblog 的 userID.
+	Subject string
+	// Username 表示用户在认证方式中的用户名，用于自动创建用户时生成用户名.
+	Username string
+	// Nickname 表示用户昵称.
+	Nickname string
+	// Email 表示用户邮箱.
+	Email string
+	// EmailVerified 表示邮箱是否经过认证方式验证，只有经过验证的邮箱才能用于关联已有账号.
+	EmailVerified bool
+	// Phone 表示用户手机号.
+	Phone string
+}
+
+// Authenticator 定义基于用户名和密码的认证方式.
+type Authenticator interface {
+	// Name 返回认证方式名称.
+	Name() string
+	// Authenticate 校验用户名和密码，成功时返回身份信息，失败时返回 ErrInvalidCredentials.
+	Authenticate(ctx context.Context, username, password string) (*Identity, error)
+}
+
+// Registry 保存所有启用的认证方式以及外部身份的账号策略.
+type Registry struct {
+	authenticators map[string]Authenticator
+	oidc           *OIDCProvider
+
+	// JITProvisioning 表示外部身份首次登录且无法关联已有账号时，是否自动创建用户.
+	JITProvisioning bool
+	// LinkByEmail 表示外部身份首次登录时，是否根据已验证的邮箱关联到已有用户.
+	LinkByEmail bool
+}
+
+// NewRegistry 创建一个 *Registry 实例.
+func NewRegistry() *Registry {
+	return &Registry{authenticators: make(map[string]Authenticator)}
+}
+
+// Register 注册一个基于用户名和密码的认证方式.
+func (r *Registry) Register(a Authenticator) {
+	r.authenticators[a.Name()] = a
+}
+
+// Get 根据名称返回认证方式，名称为空时返回本地认证.
+func (r *Registry) Get(name string) (Authenticator, error) {
+	if name == "" {
+		name = ProviderLocal
+	}
+	a, ok := r.authenticators[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return a, nil
+}
+
+// SetOIDC 设置 OIDC 认证方式.
+func (r *Registry) SetOIDC(p *OIDCProvider) {
+	r.oidc = p
+}
+
+// OIDC 返回 OIDC 认证方式，未启用时返回 ErrProviderNotFound.
+func (r *Registry) OIDC() (*OIDCProvider, error) {
+	if r.oidc == nil {
+		return nil, ErrProviderNotFound
+	}
+	return r.oidc, nil
+}
+
+// Names 返回所有启用的认证方式名称.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.authenticators)+1)
+	for name := range r.authenticators {
+		names = append(names, name)
+	}
+	if r.oidc != nil {
+		names = append(names, ProviderOIDC)
+	}
+	sort.Strings(names)
+	return names
+}
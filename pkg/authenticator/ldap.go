@@ -0,0 +1,150 @@
+package authenticator
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// LDAPConfig 定义 LDAP 认证方式的配置.
+type LDAPConfig struct {
+	// URL 表示 LDAP 服务地址，例如 ldap://127.0.0.1:389 或 ldaps://ldap.example.com:636.
+	URL string
+	// BindDN 和 BindPassword 表示用于搜索用户的服务账号，为空时使用匿名搜索.
+	BindDN       string
+	BindPassword string
+	// BaseDN 表示搜索用户的起始 DN.
+	BaseDN string
+	// UserFilter 表示搜索用户的过滤条件，%s 会被替换为转义后的用户名，例如 (uid=%s).
+	UserFilter string
+	// IDAttribute 表示用户唯一标识所在的属性，例如 entryUUID，为空时使用用户 DN.
+	IDAttribute string
+	// UsernameAttribute、NicknameAttribute、EmailAttribute、PhoneAttribute 表示用户信息所在的属性.
+	UsernameAttribute string
+	NicknameAttribute string
+	EmailAttribute    string
+	PhoneAttribute    string
+	// StartTLS 表示是否在 ldap:// 连接上使用 StartTLS.
+	StartTLS bool
+	// InsecureSkipVerify 表示是否跳过 TLS 证书校验，仅用于测试环境.
+	InsecureSkipVerify bool
+	// Timeout 表示连接和请求的超时时间.
+	Timeout time.Duration
+}
+
+// LDAPAuthenticator 通过 LDAP 搜索并绑定用户 DN 的方式校验用户密码.
+type LDAPAuthenticator struct {
+	cfg *LDAPConfig
+}
+
+// 确保 *LDAPAuthenticator 实现了 Authenticator 接口.
+var _ Authenticator = (*LDAPAuthenticator)(nil)
+
+// NewLDAP 创建一个 *LDAPAuthenticator 实例.
+func NewLDAP(cfg *LDAPConfig) *LDAPAuthenticator {
+	return &LDAPAuthenticator{cfg: cfg}
+}
+
+// Name 返回认证方式名称.
+func (a *LDAPAuthenticator) Name() string {
+	return ProviderLDAP
+}
+
+// Authenticate 使用服务账号搜索用户，再使用用户 DN 和密码进行绑定.
+func (a *LDAPAuthenticator) Authenticate(ctx context.Context, username, password string) (*Identity, error) {
+	// 空密码会被 LDAP 服务端视为匿名绑定并返回成功，必须提前拒绝
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := a.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if a.cfg.BindDN != "" {
+		if err := conn.Bind(a.cfg.BindDN, a.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("ldap service account bind failed: %w", err)
+		}
+	}
+
+	attributes := []string{a.cfg.UsernameAttribute, a.cfg.NicknameAttribute, a.cfg.EmailAttribute, a.cfg.PhoneAttribute}
+	if a.cfg.IDAttribute != "" {
+		attributes = append(attributes, a.cfg.IDAttribute)
+	}
+	searchRequest := ldap.NewSearchRequest(
+		a.cfg.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(a.cfg.Timeout.Seconds()), false,
+		fmt.Sprintf(a.cfg.UserFilter, ldap.EscapeFilter(username)),
+		attributes,
+		nil,
+	)
+	result, err := conn.Search(searchRequest)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap search failed: %w", err)
+	}
+	// 用户不存在或匹配到多个用户时都视为认证失败
+	if len(result.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	entry := result.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap user bind failed: %w", err)
+	}
+
+	subject := entry.DN
+	if a.cfg.IDAttribute != "" {
+		if id := entry.GetAttributeValue(a.cfg.IDAttribute); id != "" {
+			subject = id
+		}
+	}
+
+	identity := &Identity{
+		Provider: ProviderLDAP,
+		Subject:  subject,
+		Username: entry.GetAttributeValue(a.cfg.UsernameAttribute),
+		Nickname: entry.GetAttributeValue(a.cfg.NicknameAttribute),
+		Email:    entry.GetAttributeValue(a.cfg.EmailAttribute),
+		Phone:    entry.GetAttributeValue(a.cfg.PhoneAttribute),
+		// 企业目录中的邮箱由管理员维护，视为已验证
+		EmailVerified: entry.GetAttributeValue(a.cfg.EmailAttribute) != "",
+	}
+	if identity.Username == "" {
+		identity.Username = username
+	}
+	return identity, nil
+}
+
+// dial 建立 LDAP 连接，并根据配置开启 StartTLS.
+func (a *LDAPAuthenticator) dial() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: a.cfg.InsecureSkipVerify}
+
+	conn, err := ldap.DialURL(a.cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: a.cfg.Timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap server: %w", err)
+	}
+	conn.SetTimeout(a.cfg.Timeout)
+
+	if a.cfg.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap starttls failed: %w", err)
+		}
+	}
+	return conn, nil
+}
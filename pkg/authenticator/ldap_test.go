@@ -0,0 +1,167 @@
+package authenticator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// fakeLDAPEntry 是 fakeLDAPServer 中的一条用户记录.
+type fakeLDAPEntry struct {
+	dn         string
+	password   string
+	attributes map[string]string
+}
+
+// fakeLDAPServer 是一个只支持简单绑定和等值过滤搜索的 LDAP 服务端替身.
+type fakeLDAPServer struct {
+	listener net.Listener
+	entries  []fakeLDAPEntry
+}
+
+func newFakeLDAPServer(t *testing.T, entries ...fakeLDAPEntry) *fakeLDAPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &fakeLDAPServer{listener: listener, entries: entries}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeLDAPServer) url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *fakeLDAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Data.String()
+			password := op.Children[2].Data.String()
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			for _, entry := range s.entries {
+				if entry.dn == dn && entry.password == password {
+					code = ldap.LDAPResultSuccess
+				}
+			}
+			_, _ = conn.Write(ldapResponse(messageID, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationSearchRequest:
+			filter, _ := ldap.DecompileFilter(op.Children[6])
+			for _, entry := range s.entries {
+				if filter == "("+"uid="+entry.attributes["uid"]+")" {
+					_, _ = conn.Write(ldapEntry(messageID, entry).Bytes())
+				}
+			}
+			_, _ = conn.Write(ldapResponse(messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+		default:
+			return
+		}
+	}
+}
+
+func ldapEnvelope(messageID int64, op *ber.Packet) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, ""))
+	packet.AppendChild(op)
+	return packet
+}
+
+func ldapResponse(messageID int64, tag ber.Tag, code int64) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	return ldapEnvelope(messageID, op)
+}
+
+func ldapEntry(messageID int64, entry fakeLDAPEntry) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.dn, ""))
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, value := range entry.attributes {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		attribute.AppendChild(values)
+		attributes.AppendChild(attribute)
+	}
+	op.AppendChild(attributes)
+	return ldapEnvelope(messageID, op)
+}
+
+func TestLDAPAuthenticator(t *testing.T) {
+	server := newFakeLDAPServer(t,
+		fakeLDAPEntry{dn: "cn=service,dc=example,dc=com", password: "service-secret"},
+		fakeLDAPEntry{
+			dn:       "uid=alice,ou=people,dc=example,dc=com",
+			password: "alice-secret",
+			attributes: map[string]string{
+				"uid":       "alice",
+				"cn":        "Alice",
+				"mail":      "alice@example.com",
+				"entryUUID": "5f1d7c3e-0c1a-4b8e-9a55-000000000001",
+			},
+		},
+	)
+
+	a := NewLDAP(&LDAPConfig{
+		URL:               server.url(),
+		BindDN:            "cn=service,dc=example,dc=com",
+		BindPassword:      "service-secret",
+		BaseDN:            "dc=example,dc=com",
+		UserFilter:        "(uid=%s)",
+		IDAttribute:       "entryUUID",
+		UsernameAttribute: "uid",
+		NicknameAttribute: "cn",
+		EmailAttribute:    "mail",
+		PhoneAttribute:    "mobile",
+		Timeout:           5 * time.Second,
+	})
+	ctx := context.Background()
+
+	identity, err := a.Authenticate(ctx, "alice", "alice-secret")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if identity.Subject != "5f1d7c3e-0c1a-4b8e-9a55-000000000001" || identity.Email != "alice@example.com" || !identity.EmailVerified {
+		t.Errorf("Authenticate() = %+v", identity)
+	}
+
+	for _, tc := range []struct{ username, password string }{
+		{"alice", "wrong"},
+		{"alice", ""},
+		{"bob", "alice-secret"},
+	} {
+		if _, err := a.Authenticate(ctx, tc.username, tc.password); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("Authenticate(%q, %q) error = %v, want ErrInvalidCredentials", tc.username, tc.password, err)
+		}
+	}
+}
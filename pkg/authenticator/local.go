@@ -0,0 +1,63 @@
+package authenticator
+
+import (
+	"context"
+
+	"miniblog/pkg/authn"
+)
+
+// LocalUserLookup 根据用户名查询本地用户的 userID 和加密后的密码.
+// 用户不存在时应返回 found 为 false，而不是错误.
+type LocalUserLookup func(ctx context.Context, username string) (userID, hashedPassword string, found bool, err error)
+
+// LocalPasswordRehasher 保存使用当前哈希算法和参数重新加密后的密码.
+// oldHashedPassword 为认证时对比的密码，实现应只在密码没有被并发修改时保存，避免覆盖新修改的密码.
+type LocalPasswordRehasher func(ctx context.Context, userID, oldHashedPassword, hashedPassword string) error
+
+// LocalAuthenticator 使用 miniblog 本地保存的密码进行认证.
+type LocalAuthenticator struct {
+	lookup LocalUserLookup
+	rehash LocalPasswordRehasher
+	// dummyHash 是一个不对应任何用户的哈希，用户不存在时用于对比密码，使响应时间保持一致.
+	dummyHash string
+}
+
+// 确保 *LocalAuthenticator 实现了 Authenticator 接口.
+var _ Authenticator = (*LocalAuthenticator)(nil)
+
+// NewLocal 创建一个 *LocalAuthenticator 实例.
+// rehash 为可选参数，不为空时，登录成功后会把使用旧算法或旧参数加密的密码重新加密保存.
+// 需要在 authn.Init 之后调用，以便 dummyHash 与新密码使用相同的算法和参数.
+func NewLocal(lookup LocalUserLookup, rehash LocalPasswordRehasher) *LocalAuthenticator {
+	dummyHash, _ := authn.Encrypt(ProviderLocal)
+	return &LocalAuthenticator{lookup: lookup, rehash: rehash, dummyHash: dummyHash}
+}
+
+// Name 返回认证方式名称.
+func (a *LocalAuthenticator) Name() string {
+	return ProviderLocal
+}
+
+// Authenticate 对比用户密码. 用户不存在时同样进行一次对比，避免通过响应时间判断用户是否存在.
+// 对比成功后，如果密码哈希的算法或参数与当前配置不一致，会透明地重新加密保存，失败不影响登录.
+func (a *LocalAuthenticator) Authenticate(ctx context.Context, username, password string) (*Identity, error) {
+	userID, hashedPassword, found, err := a.lookup(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		hashedPassword = a.dummyHash
+	}
+
+	if err := authn.Compare(hashedPassword, password); err != nil || !found {
+		return nil, ErrInvalidCredentials
+	}
+
+	if a.rehash != nil && authn.NeedsRehash(hashedPassword) {
+		if rehashed, err := authn.Encrypt(password); err == nil {
+			_ = a.rehash(ctx, userID, hashedPassword, rehashed)
+		}
+	}
+
+	return &Identity{Provider: ProviderLocal, Subject: userID, Username: username}, nil
+}
@@ -0,0 +1,44 @@
+package authenticator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLocalAuthenticatorRehash(t *testing.T) {
+	// 使用与当前配置不同的 cost 加密的密码需要重新加密
+	legacy, err := bcrypt.GenerateFromPassword([]byte("miniblog1234"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotOld, gotNew string
+	a := NewLocal(func(ctx context.Context, username string) (string, string, bool, error) {
+		return "user-000001", string(legacy), username == "colin", nil
+	}, func(ctx context.Context, userID, oldHashedPassword, hashedPassword string) error {
+		gotOld, gotNew = oldHashedPassword, hashedPassword
+		// 模拟用户记录被并发修改导致保存失败
+		return errors.New("the resource has been modified")
+	})
+
+	identity, err := a.Authenticate(context.Background(), "colin", "miniblog1234")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v, want nil when rehash fails", err)
+	}
+	if identity.Subject != "user-000001" {
+		t.Errorf("Authenticate() subject = %s, want user-000001", identity.Subject)
+	}
+	if gotOld != string(legacy) {
+		t.Errorf("rehash old password = %s, want %s", gotOld, legacy)
+	}
+	if gotNew == "" || gotNew == string(legacy) {
+		t.Errorf("rehash new password = %s, want a new hash", gotNew)
+	}
+
+	if _, err := a.Authenticate(context.Background(), "colin", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Authenticate() error = %v, want %v", err, ErrInvalidCredentials)
+	}
+}
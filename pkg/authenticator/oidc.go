@@ -0,0 +1,98 @@
+package authenticator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// ErrOIDCExchange 表示授权码无效或 ID Token 校验失败.
+var ErrOIDCExchange = errors.New("oidc authorization code exchange failed")
+
+// OIDCConfig 定义 OIDC 认证方式的配置.
+type OIDCConfig struct {
+	// Issuer 表示 OIDC 提供方地址，用于服务发现.
+	Issuer string
+	// ClientID 和 ClientSecret 表示在 OIDC 提供方注册的客户端凭证.
+	ClientID     string
+	ClientSecret string
+	// RedirectURL 表示授权完成后的回调地址.
+	RedirectURL string
+	// Scopes 表示额外申请的权限范围，openid 会自动添加.
+	Scopes []string
+}
+
+// OIDCProvider 实现 OpenID Connect 授权码流程.
+type OIDCProvider struct {
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDC 通过服务发现创建一个 *OIDCProvider 实例.
+func NewOIDC(ctx context.Context, cfg *OIDCConfig) (*OIDCProvider, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider: %w", err)
+	}
+
+	return &OIDCProvider{
+		oauth2: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{oidc.ScopeOpenID}, cfg.Scopes...),
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+// AuthCodeURL 返回用户需要跳转的授权地址.
+func (p *OIDCProvider) AuthCodeURL(state, nonce string) string {
+	return p.oauth2.AuthCodeURL(state, oidc.Nonce(nonce))
+}
+
+// Exchange 使用授权码换取并校验 ID Token，返回其中的身份信息.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	tk, err := p.oauth2.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOIDCExchange, err)
+	}
+
+	rawIDToken, ok := tk.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: no id_token in token response", ErrOIDCExchange)
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOIDCExchange, err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCExchange)
+	}
+
+	var claims struct {
+		PreferredUsername string `json:"preferred_username"`
+		Name              string `json:"name"`
+		Email             string `json:"email"`
+		EmailVerified     bool   `json:"email_verified"`
+		PhoneNumber       string `json:"phone_number"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOIDCExchange, err)
+	}
+
+	return &Identity{
+		Provider:      ProviderOIDC,
+		Subject:       idToken.Subject,
+		Username:      claims.PreferredUsername,
+		Nickname:      claims.Name,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Phone:         claims.PhoneNumber,
+	}, nil
+}
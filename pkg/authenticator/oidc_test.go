@@ -0,0 +1,132 @@
+package authenticator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// mockIssuer 是一个用于测试的 OIDC 提供方，实现服务发现、JWKS 和令牌端点.
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// codes 保存授权码与 nonce 的对应关系
+	codes map[string]string
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	m := &mockIssuer{key: key, codes: make(map[string]string)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                m.server.URL,
+			"authorization_endpoint":                m.server.URL + "/authorize",
+			"token_endpoint":                        m.server.URL + "/token",
+			"jwks_uri":                              m.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		nonce, ok := m.codes[r.PostForm.Get("code")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":                m.server.URL,
+			"aud":                "miniblog",
+			"sub":                "oidc-user-1",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"iat":                time.Now().Unix(),
+			"nonce":              nonce,
+			"preferred_username": "alice",
+			"name":               "Alice",
+			"email":              "alice@example.com",
+			"email_verified":     true,
+		})
+		idToken.Header["kid"] = "test"
+		signed, _ := idToken.SignedString(key)
+		writeJSON(w, map[string]any{"access_token": "access", "token_type": "Bearer", "expires_in": 3600, "id_token": signed})
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestOIDCProvider(t *testing.T) {
+	issuer := newMockIssuer(t)
+	ctx := context.Background()
+
+	p, err := NewOIDC(ctx, &OIDCConfig{
+		Issuer:       issuer.server.URL,
+		ClientID:     "miniblog",
+		ClientSecret: "secret",
+		RedirectURL:  "http://127.0.0.1:5555/login/oidc/callback",
+		Scopes:       []string{"email", "profile"},
+	})
+	if err != nil {
+		t.Fatalf("NewOIDC() error = %v", err)
+	}
+
+	authURL, err := url.Parse(p.AuthCodeURL("state-1", "nonce-1"))
+	if err != nil {
+		t.Fatalf("invalid auth url: %v", err)
+	}
+	if q := authURL.Query(); q.Get("state") != "state-1" || q.Get("nonce") != "nonce-1" || q.Get("client_id") != "miniblog" {
+		t.Errorf("AuthCodeURL() = %s", authURL)
+	}
+
+	issuer.codes["code-1"] = "nonce-1"
+	identity, err := p.Exchange(ctx, "code-1", "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if identity.Subject != "oidc-user-1" || identity.Username != "alice" || !identity.EmailVerified {
+		t.Errorf("Exchange() = %+v", identity)
+	}
+
+	// nonce 不匹配时拒绝，防止 ID Token 重放
+	if _, err := p.Exchange(ctx, "code-1", "nonce-2"); !errors.Is(err, ErrOIDCExchange) {
+		t.Errorf("Exchange() with wrong nonce error = %v, want ErrOIDCExchange", err)
+	}
+	if _, err := p.Exchange(ctx, "unknown", "nonce-1"); !errors.Is(err, ErrOIDCExchange) {
+		t.Errorf("Exchange() with unknown code error = %v, want ErrOIDCExchange", err)
+	}
+}
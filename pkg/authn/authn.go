@@ -10,7 +10,6 @@ import (
 	"context"
 
 	"github.com/golang-jwt/jwt/v4"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // IToken defines methods to implement a generic token.
@@ -39,14 +38,3 @@ type Authenticator interface {
 	// Release used to release the requested resources.
 	Release() error
 }
-
-// Encrypt encrypts the plain text with bcrypt.
-func Encrypt(source string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(source), bcrypt.DefaultCost)
-	return string(hashedBytes), err
-}
-
-// Compare compares the encrypted text with the plain text if it's the same.
-func Compare(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
-}
@@ -0,0 +1,197 @@
+package authn
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// AlgorithmBcrypt uses bcrypt to hash passwords.
+	AlgorithmBcrypt = "bcrypt"
+	// AlgorithmArgon2id uses argon2id to hash passwords.
+	AlgorithmArgon2id = "argon2id"
+
+	// argon2idPrefix is the prefix of argon2id hashes in PHC string format.
+	argon2idPrefix = "$argon2id$"
+)
+
+var (
+	// ErrMismatchedHashAndPassword is returned when a password does not match the hash.
+	ErrMismatchedHashAndPassword = errors.New("hashed value is not the hash of the given password")
+	// ErrInvalidHash is returned when a hash can not be parsed.
+	ErrInvalidHash = errors.New("invalid password hash format")
+)
+
+// Argon2idParams defines the cost parameters of argon2id.
+type Argon2idParams struct {
+	// Memory is the amount of memory used in KiB.
+	Memory uint32
+	// Iterations is the number of passes over the memory.
+	Iterations uint32
+	// Parallelism is the number of threads used.
+	Parallelism uint8
+	// SaltLength is the length of the random salt in bytes.
+	SaltLength uint32
+	// KeyLength is the length of the derived key in bytes.
+	KeyLength uint32
+}
+
+// HashConfig defines which algorithm and parameters Encrypt uses for new hashes.
+// Compare always supports every algorithm, so existing hashes keep verifying after a change.
+type HashConfig struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2id   Argon2idParams
+}
+
+// DefaultHashConfig uses argon2id with the parameters recommended by RFC 9106 for memory-constrained environments.
+var DefaultHashConfig = HashConfig{
+	Algorithm:  AlgorithmArgon2id,
+	BcryptCost: bcrypt.DefaultCost,
+	Argon2id: Argon2idParams{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+	},
+}
+
+var (
+	mu         sync.RWMutex
+	hashConfig = HashConfig{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.DefaultCost, Argon2id: DefaultHashConfig.Argon2id}
+)
+
+// Init sets the algorithm and parameters used by Encrypt.
+func Init(cfg HashConfig) {
+	mu.Lock()
+	defer mu.Unlock()
+	hashConfig = cfg
+}
+
+func currentHashConfig() HashConfig {
+	mu.RLock()
+	defer mu.RUnlock()
+	return hashConfig
+}
+
+// Encrypt encrypts the plain text with the configured algorithm.
+// The result is self-describing: bcrypt hashes use the modular crypt format and
+// argon2id hashes use the PHC string format, e.g.
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>.
+func Encrypt(source string) (string, error) {
+	cfg := currentHashConfig()
+	switch cfg.Algorithm {
+	case AlgorithmArgon2id:
+		return encryptArgon2id(source, cfg.Argon2id)
+	case AlgorithmBcrypt:
+		hashedBytes, err := bcrypt.GenerateFromPassword([]byte(source), cfg.BcryptCost)
+		return string(hashedBytes), err
+	default:
+		return "", fmt.Errorf("unsupported password hash algorithm %q", cfg.Algorithm)
+	}
+}
+
+// Compare compares the encrypted text with the plain text if it's the same.
+// The algorithm is detected from the hash, so both bcrypt and argon2id hashes are supported.
+func Compare(hashedPassword, password string) error {
+	if strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		params, salt, key, err := decodeArgon2id(hashedPassword)
+		if err != nil {
+			return err
+		}
+		derived := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+		if subtle.ConstantTimeCompare(derived, key) != 1 {
+			return ErrMismatchedHashAndPassword
+		}
+		return nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrMismatchedHashAndPassword
+		}
+		return err
+	}
+	return nil
+}
+
+// NeedsRehash reports whether the hash was produced with a different algorithm or
+// parameters than the ones currently configured.
+func NeedsRehash(hashedPassword string) bool {
+	cfg := currentHashConfig()
+
+	if strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		if cfg.Algorithm != AlgorithmArgon2id {
+			return true
+		}
+		params, salt, key, err := decodeArgon2id(hashedPassword)
+		if err != nil {
+			return true
+		}
+		return params.Memory != cfg.Argon2id.Memory ||
+			params.Iterations != cfg.Argon2id.Iterations ||
+			params.Parallelism != cfg.Argon2id.Parallelism ||
+			uint32(len(salt)) != cfg.Argon2id.SaltLength ||
+			uint32(len(key)) != cfg.Argon2id.KeyLength
+	}
+
+	if cfg.Algorithm != AlgorithmBcrypt {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != cfg.BcryptCost
+}
+
+func encryptArgon2id(source string, params Argon2idParams) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(source), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func decodeArgon2id(hash string) (params Argon2idParams, salt, key []byte, err error) {
+	// ["", "argon2id", "v=19", "m=65536,t=3,p=2", "<salt>", "<hash>"]
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
@@ -0,0 +1,76 @@
+package authn
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestEncryptAndCompare(t *testing.T) {
+	defer Init(currentHashConfig())
+
+	argon := DefaultHashConfig
+	argon.Argon2id.Memory = 1024
+	argon.Argon2id.Iterations = 1
+	Init(argon)
+
+	hash, err := Encrypt("miniblog@1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=2$") {
+		t.Fatalf("unexpected hash format: %s", hash)
+	}
+	if err := Compare(hash, "miniblog@1234"); err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if err := Compare(hash, "miniblog@12345"); err != ErrMismatchedHashAndPassword {
+		t.Fatalf("Compare() error = %v, want %v", err, ErrMismatchedHashAndPassword)
+	}
+	if NeedsRehash(hash) {
+		t.Fatal("NeedsRehash() = true for hash with current parameters")
+	}
+
+	// bcrypt hashes created before switching algorithms still verify, and are marked for rehash.
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("miniblog@1234"), bcrypt.MinCost)
+	if err := Compare(string(legacy), "miniblog@1234"); err != nil {
+		t.Fatalf("Compare() bcrypt error = %v", err)
+	}
+	if !NeedsRehash(string(legacy)) {
+		t.Fatal("NeedsRehash() = false for bcrypt hash")
+	}
+
+	// Changing the argon2id parameters marks existing hashes for rehash.
+	argon.Argon2id.Iterations = 2
+	Init(argon)
+	if !NeedsRehash(hash) {
+		t.Fatal("NeedsRehash() = false after parameters changed")
+	}
+
+	if err := Compare("$argon2id$v=19$m=1024$bad", "x"); err != ErrInvalidHash {
+		t.Fatalf("Compare() error = %v, want %v", err, ErrInvalidHash)
+	}
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	policy.RequireUpper = true
+
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{"Miniblog_2024", false},
+		{"Mb_1", true},
+		{"miniblog_2024", true},
+		{"Miniblog2024", true},
+		{"Miniblog@1234", true}, // deny list, case-insensitive
+		{strings.Repeat("Ab1_", 9), true},
+	}
+	for _, tt := range tests {
+		if err := policy.Check(tt.password); (err != nil) != tt.wantErr {
+			t.Errorf("Check(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package authn
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// PasswordPolicy defines the rules a new password must satisfy.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+	// MaxLength is the maximum number of characters, 0 means no limit.
+	MaxLength int
+	// RequireLetter requires at least one letter.
+	RequireLetter bool
+	// RequireUpper requires at least one upper case letter.
+	RequireUpper bool
+	// RequireLower requires at least one lower case letter.
+	RequireLower bool
+	// RequireDigit requires at least one digit.
+	RequireDigit bool
+	// RequireSpecial requires at least one character that is neither a letter nor a digit.
+	RequireSpecial bool
+	// DenyList contains passwords that are rejected regardless of the other rules, compared case-insensitively.
+	DenyList []string
+
+	once   sync.Once
+	denied map[string]struct{}
+}
+
+// CommonPasswords is a small built-in list of frequently used passwords.
+var CommonPasswords = []string{
+	"password", "password1", "password123", "password@123", "passw0rd", "p@ssw0rd", "p@ssword1",
+	"12345678", "123456789", "1234567890", "qwerty123", "qwerty@123", "1q2w3e4r", "1qaz2wsx", "1qaz@wsx",
+	"abc12345", "abc@1234", "admin123", "admin@123", "welcome1", "welcome@1", "iloveyou1", "letmein1",
+	"miniblog1", "miniblog1234", "miniblog@1234",
+}
+
+// DefaultPasswordPolicy returns the policy used when nothing is configured.
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:      8,
+		MaxLength:      32,
+		RequireLetter:  true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		DenyList:       CommonPasswords,
+	}
+}
+
+// Check returns an error describing the first rule the password violates.
+func (p *PasswordPolicy) Check(password string) error {
+	length := len([]rune(password))
+	if length < p.MinLength || (p.MaxLength > 0 && length > p.MaxLength) {
+		if p.MaxLength > 0 {
+			return fmt.Errorf("password must be between %d and %d characters long", p.MinLength, p.MaxLength)
+		}
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+
+	var hasLetter, hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+			hasUpper = hasUpper || unicode.IsUpper(r)
+			hasLower = hasLower || unicode.IsLower(r)
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSpecial = true
+		}
+	}
+
+	var missing []string
+	if p.RequireLetter && !hasLetter {
+		missing = append(missing, "one letter")
+	}
+	if p.RequireUpper && !hasUpper {
+		missing = append(missing, "one upper case letter")
+	}
+	if p.RequireLower && !hasLower {
+		missing = append(missing, "one lower case letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		missing = append(missing, "one number")
+	}
+	if p.RequireSpecial && !hasSpecial {
+		missing = append(missing, "one special character")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("password must contain at least %s", strings.Join(missing, ", "))
+	}
+
+	if p.isDenied(password) {
+		return fmt.Errorf("password is too common, please choose another one")
+	}
+
+	return nil
+}
+
+func (p *PasswordPolicy) isDenied(password string) bool {
+	p.once.Do(func() {
+		p.denied = make(map[string]struct{}, len(p.DenyList))
+		for _, item := range p.DenyList {
+			p.denied[strings.ToLower(strings.TrimSpace(item))] = struct{}{}
+		}
+	})
+	_, ok := p.denied[strings.ToLower(password)]
+	return ok
+}
@@ -0,0 +1,44 @@
+package authz
+
+import (
+	"time"
+
+	casbin "github.com/casbin/casbin/v2"
+)
+
+// Resource 描述 ABAC 授权时被访问资源的属性，在策略条件中通过 r2.res 引用.
+type Resource struct {
+	// ID 是资源的唯一 ID.
+	ID string
+	// OwnerID 是资源所有者的用户 ID，例如博客的作者.
+	OwnerID string
+	// Status 是资源的状态.
+	Status string
+	// OrgID 是资源所属组织的 ID，主体在该组织中的角色（g2 规则）据此生效. 不属于任何组织时为空.
+	OrgID string
+}
+
+// Environment 描述 ABAC 授权时请求的属性，在策略条件中通过 r2.env 引用.
+type Environment struct {
+	// Hour 是请求时间的小时，取值 0-23.
+	Hour int
+	// Weekday 是请求时间是星期几，0 表示星期日.
+	Weekday int
+	// IP 是客户端 IP，可以在策略条件中使用 ipMatch(r2.env.IP, '10.0.0.0/8').
+	IP string
+}
+
+// NewEnvironment 根据请求时间和客户端 IP 创建请求属性.
+func NewEnvironment(now time.Time, ip string) Environment {
+	return Environment{
+		Hour:    now.Hour(),
+		Weekday: int(now.Weekday()),
+		IP:      ip,
+	}
+}
+
+// AuthorizeResource 使用 ABAC 模型判断 sub 能否对资源执行动作. 业务层在加载资源后调用，
+// 从而可以根据资源的所有者、状态以及请求的时间、IP 等属性进行授权.
+func (a *Authz) AuthorizeResource(sub, obj, act string, res Resource, env Environment) (bool, error) {
+	return a.Enforce(casbin.NewEnforceContext("2"), sub, obj, act, res, env)
+}
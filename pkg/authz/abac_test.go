@@ -0,0 +1,80 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+)
+
+func TestAuthorizeResource(t *testing.T) {
+	m, err := model.NewModelFromString(defaultAclModel)
+	if err != nil {
+		t.Fatalf("NewModelFromString() error = %v", err)
+	}
+	enforcer, err := casbin.NewSyncedEnforcer(m)
+	if err != nil {
+		t.Fatalf("NewSyncedEnforcer() error = %v", err)
+	}
+	a := &Authz{enforcer}
+
+	_, _ = a.AddNamedPolicies("p2", [][]string{
+		{"role::admin", "*", "*", "true", "allow"},
+		{"role::user", "post", "update", "r2.res.OwnerID == r2.sub", "allow"},
+		{"role::moderator", "post", "update", "r2.env.Weekday >= 1 && r2.env.Weekday <= 5 && r2.env.Hour >= 9 && r2.env.Hour < 18", "allow"},
+		{"role::user", "post", "update", "r2.res.Status == 'locked'", "deny"},
+		{"role::auditor", "post", "get", "ipMatch(r2.env.IP, '10.0.0.0/8')", "allow"},
+		{"role::org-admin", "post", "*", "true", "allow"},
+	})
+	_, _ = a.AddGroupingPolicies([][]string{
+		{"admin", "role::admin"},
+		{"alice", "role::user"},
+		{"bob", "role::user"},
+		{"mod", "role::moderator"},
+		{"eve", "role::auditor"},
+	})
+	_, _ = a.AddNamedGroupingPolicy("g2", "bob", "role::org-admin", "org-1")
+
+	// 2024-12-11 是星期三
+	workday := NewEnvironment(time.Date(2024, 12, 11, 10, 0, 0, 0, time.Local), "10.1.2.3")
+	night := NewEnvironment(time.Date(2024, 12, 11, 22, 0, 0, 0, time.Local), "192.168.1.1")
+	post := Resource{ID: "post-1", OwnerID: "alice"}
+
+	tests := []struct {
+		name string
+		sub  string
+		act  string
+		res  Resource
+		env  Environment
+		want bool
+	}{
+		{name: "admin", sub: "admin", act: "update", res: post, env: night, want: true},
+		{name: "owner", sub: "alice", act: "update", res: post, env: night, want: true},
+		{name: "not owner", sub: "bob", act: "update", res: post, env: workday, want: false},
+		{name: "owner of locked post", sub: "alice", act: "update", res: Resource{OwnerID: "alice", Status: "locked"}, env: workday, want: false},
+		{name: "moderator in business hours", sub: "mod", act: "update", res: post, env: workday, want: true},
+		{name: "moderator at night", sub: "mod", act: "update", res: post, env: night, want: false},
+		{name: "auditor from internal network", sub: "eve", act: "get", res: post, env: workday, want: true},
+		{name: "auditor from external network", sub: "eve", act: "get", res: post, env: night, want: false},
+		{name: "org admin", sub: "bob", act: "delete", res: Resource{OwnerID: "alice", OrgID: "org-1"}, env: night, want: true},
+		{name: "org admin of another org", sub: "bob", act: "delete", res: Resource{OwnerID: "alice", OrgID: "org-2"}, env: night, want: false},
+		{name: "no policy", sub: "alice", act: "delete", res: post, env: workday, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.AuthorizeResource(tt.sub, "post", tt.act, tt.res, tt.env)
+			if err != nil {
+				t.Fatalf("AuthorizeResource() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AuthorizeResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// ABAC 策略不影响基于主体、资源和动作的授权
+	if allowed, _ := a.Authorize("bob", "post", "update"); !allowed {
+		t.Errorf("Authorize() = false, want true")
+	}
+}
@@ -1,31 +1,56 @@
 package authz
 
 import (
+	"strings"
 	"time"
 
 	casbin "github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/casbin/casbin/v2/util"
 	adapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/google/wire"
 	"gorm.io/gorm"
+
+	"miniblog/pkg/errorsx"
 )
 
 const (
 	// 默认的 Casbin 访问控制模型.
+	//
+	// r/p/e/m 是基于主体、资源和动作的访问控制，默认允许，只有匹配 deny 策略时拒绝.
+	// r2/p2/e2/m2 是基于属性的访问控制（ABAC），参见 Authz.AuthorizeResource. p2 中的 cond 是一个表达式，
+	// 可以引用被访问资源的属性 r2.res（参见 Resource）和请求的属性 r2.env（参见 Environment），例如：
+	//
+	//	p2, role::user, post, update, r2.res.OwnerID == r2.sub, allow
+	//	p2, role::moderator, post, update, r2.env.Hour >= 9 && r2.env.Hour < 18, allow
+	//
+	// ABAC 默认拒绝，只有匹配 allow 策略并且没有匹配 deny 策略时允许.
+	//
+	// g 是全局的角色分配. g2 是按域（例如组织）划分的角色分配，只在 ABAC 中生效，
+	// 域取自被访问资源的 r2.res.OrgID，例如组织管理员可以修改组织内的所有博客：
+	//
+	//	g2, user-000001, role::org-admin, org-000001
+	//	p2, role::org-admin, post, *, true, allow
 	defaultAclModel = `[request_definition]
 r = sub, obj, act
+r2 = sub, obj, act, res, env
 
 [policy_definition]
 p = sub, obj, act, eft
+p2 = sub, obj, act, cond, eft
 
 [role_definition]
 g = _, _
+g2 = _, _, _
 
 [policy_effect]
 e = !some(where (p.eft == deny))
+e2 = some(where (p.eft == allow)) && !some(where (p.eft == deny))
 
 [matchers]
-m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && r.act == p.act`
+m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && keyMatch(r.act, p.act)
+m2 = (g(r2.sub, p2.sub) || g2(r2.sub, p2.sub, r2.res.OrgID)) && keyMatch(r2.obj, p2.obj) && keyMatch(r2.act, p2.act) && eval(p2.cond)`
 )
 
 // Authz 定义了一个授权器，提供授权功能.
@@ -38,8 +63,9 @@ type Option func(*authzConfig)
 
 // authzConfig 是授权器的配置结构.
 type authzConfig struct {
-	aclModel           string        // Casbin 的模型字符串
-	autoLoadPolicyTime time.Duration // 自动加载策略的时间间隔
+	aclModel           string            // Casbin 的模型字符串
+	autoLoadPolicyTime time.Duration     // 自动加载策略的时间间隔
+	watcher            persist.WatcherEx // 在实例之间同步策略变更的 watcher
 }
 
 // ProviderSet 是一个 Wire 的 Provider 集合，用于声明依赖注入的规则。
@@ -80,6 +106,13 @@ func WithAutoLoadPolicyTime(interval time.Duration) Option {
 	}
 }
 
+// WithWatcher 使用 watcher 在实例之间同步策略变更. 设置 watcher 后不再定期重新加载全部策略.
+func WithWatcher(watcher persist.WatcherEx) Option {
+	return func(cfg *authzConfig) {
+		cfg.watcher = watcher
+	}
+}
+
 // NewAuthz 创建一个使用 Casbin 完成授权的授权器，通过函数选项模式支持自定义配置.
 func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 	// 初始化默认配置
@@ -110,15 +143,63 @@ func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 		return nil, err // 返回错误
 	}
 
-	// 启动自动加载策略，使用配置的时间间隔
-	enforcer.StartAutoLoadPolicy(cfg.autoLoadPolicyTime)
+	a := &Authz{enforcer}
+
+	if cfg.watcher != nil {
+		// 使用 watcher 接收其他实例的策略变更，本实例的变更也会通过 watcher 通知其他实例
+		if err := enforcer.SetWatcher(cfg.watcher); err != nil {
+			return nil, err
+		}
+		if err := cfg.watcher.SetUpdateCallback(a.applyUpdate); err != nil {
+			return nil, err
+		}
+	} else if cfg.autoLoadPolicyTime > 0 {
+		// 启动自动加载策略，使用配置的时间间隔
+		enforcer.StartAutoLoadPolicy(cfg.autoLoadPolicyTime)
+	}
 
 	// 返回新的授权器实例
-	return &Authz{enforcer}, nil
+	return a, nil
 }
 
 // Authorize 用于进行授权.
 func (a *Authz) Authorize(sub, obj, act string) (bool, error) {
 	// 调用 Enforce 方法进行授权检查
 	return a.Enforce(sub, obj, act)
-}
\ No newline at end of file
+}
+
+// Can 判断主体是否被显式授予了对资源执行动作的能力.
+// 与 Authorize 默认允许不同，Can 要求存在匹配的 allow 策略并且没有匹配的 deny 策略，
+// 适用于查看所有用户、管理授权策略等只应授予管理员的能力.
+func (a *Authz) Can(sub, obj, act string) (bool, error) {
+	allowed, err := a.Enforce(sub, obj, act)
+	if err != nil || !allowed {
+		return false, err
+	}
+
+	permissions, err := a.GetImplicitPermissionsForUser(sub)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range permissions {
+		// p 的格式为 sub, obj, act, eft
+		if len(p) == 4 && p[3] == "allow" && util.KeyMatch(obj, p[1]) && util.KeyMatch(act, p[2]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RequireCapability 校验主体被显式授予了 permission 对应的能力，permission 的格式为 obj:act.
+// 未被授予时返回 errorsx.ErrPermissionDenied，校验出错时返回 errorsx.ErrInternal.
+func (a *Authz) RequireCapability(sub, permission string) error {
+	obj, act, _ := strings.Cut(permission, ":")
+	ok, err := a.Can(sub, obj, act)
+	if err != nil {
+		return errorsx.ErrInternal.WithMessage("failed to check capability %s: %s", permission, err.Error())
+	}
+	if !ok {
+		return errorsx.ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package authz
+
+import (
+	"testing"
+
+	casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+)
+
+func TestCan(t *testing.T) {
+	m, err := model.NewModelFromString(defaultAclModel)
+	if err != nil {
+		t.Fatalf("NewModelFromString() error = %v", err)
+	}
+	enforcer, err := casbin.NewSyncedEnforcer(m)
+	if err != nil {
+		t.Fatalf("NewSyncedEnforcer() error = %v", err)
+	}
+	a := &Authz{enforcer}
+
+	_, _ = a.AddPolicies([][]string{
+		{"role::admin", "*", "*", "allow"},
+		{"role::user", "user", "list-all", "deny"},
+		{"role::auditor", "user", "list-all", "allow"},
+	})
+	_, _ = a.AddGroupingPolicies([][]string{
+		{"admin", "role::admin"},
+		{"alice", "role::user"},
+		{"bob", "role::auditor"},
+		{"carol", "role::auditor"},
+		{"carol", "role::user"},
+	})
+
+	tests := []struct {
+		sub  string
+		want bool
+	}{
+		{sub: "admin", want: true},
+		{sub: "alice", want: false}, // 被显式拒绝
+		{sub: "bob", want: true},
+		{sub: "carol", want: false}, // deny 优先于 allow
+		{sub: "dave", want: false},  // 没有显式授予
+	}
+	for _, tt := range tests {
+		got, err := a.Can(tt.sub, "user", "list-all")
+		if err != nil {
+			t.Fatalf("Can(%s) error = %v", tt.sub, err)
+		}
+		if got != tt.want {
+			t.Errorf("Can(%s) = %v, want %v", tt.sub, got, tt.want)
+		}
+		if err := a.RequireCapability(tt.sub, "user:list-all"); (err == nil) != tt.want {
+			t.Errorf("RequireCapability(%s) error = %v, want allowed %v", tt.sub, err, tt.want)
+		}
+		// Authorize 默认允许，只有显式拒绝时返回 false
+		if allowed, _ := a.Authorize(tt.sub, "user", "list-all"); tt.sub == "dave" && !allowed {
+			t.Errorf("Authorize(%s) = false, want true", tt.sub)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package authz
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	adapter "github.com/casbin/gorm-adapter/v3"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+const (
+	// SyncModeCheck 只比较策略包和数据库中的策略，不修改数据库.
+	SyncModeCheck = "check"
+	// SyncModeAdditive 只插入策略包中有而数据库中没有的规则.
+	SyncModeAdditive = "additive"
+	// SyncModeAuthoritative 以策略包为准，插入缺少的规则并删除数据库中多余的 p、p2 规则.
+	SyncModeAuthoritative = "authoritative"
+)
+
+// Rule 是一条 casbin 规则，第一个元素是策略类型（p、p2、g 或 g2），其余元素是规则的值，
+// 例如 p, role::user, user, delete, deny.
+type Rule []string
+
+// String 返回规则的 CSV 表示.
+func (r Rule) String() string {
+	return strings.Join(r, ", ")
+}
+
+// bundleFile 是 YAML 格式策略包的结构，每个键对应一种策略类型.
+type bundleFile struct {
+	P  [][]string `yaml:"p"`
+	P2 [][]string `yaml:"p2"`
+	G  [][]string `yaml:"g"`
+	G2 [][]string `yaml:"g2"`
+}
+
+// LoadBundle 从策略包文件中加载规则. 根据扩展名识别格式：
+// .yaml、.yml 文件按 p、p2、g、g2 分组列出规则，.csv 文件与 casbin 的 CSV 策略文件格式相同.
+func LoadBundle(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		return parseYAMLBundle(data)
+	case ".csv":
+		return parseCSVBundle(data)
+	default:
+		return nil, fmt.Errorf("unsupported policy bundle format %q: must be one of [.yaml .yml .csv]", ext)
+	}
+}
+
+// parseYAMLBundle 解析 YAML 格式的策略包.
+func parseYAMLBundle(data []byte) ([]Rule, error) {
+	var file bundleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	var rules []Rule
+	for _, group := range []struct {
+		ptype  string
+		values [][]string
+	}{{"p", file.P}, {"p2", file.P2}, {"g", file.G}, {"g2", file.G2}} {
+		for _, values := range group.values {
+			rule, err := newRule(append([]string{group.ptype}, values...))
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// parseCSVBundle 解析 CSV 格式的策略包，忽略空行和以 # 开头的注释行.
+func parseCSVBundle(data []byte) ([]Rule, error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]Rule, 0, len(records))
+	for _, record := range records {
+		rule, err := newRule(record)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// newRule 校验并规范化一条规则：去除值两侧的空白以及末尾的空值.
+func newRule(values []string) (Rule, error) {
+	rule := make(Rule, len(values))
+	for i, v := range values {
+		rule[i] = strings.TrimSpace(v)
+	}
+	for len(rule) > 0 && rule[len(rule)-1] == "" {
+		rule = rule[:len(rule)-1]
+	}
+
+	// 规则最多包含策略类型和 v0 ~ v5 共 7 列
+	if len(rule) < 3 || len(rule) > 7 {
+		return nil, fmt.Errorf("invalid policy rule %q: must have a policy type and 2 to 6 values", strings.Join(values, ", "))
+	}
+	switch rule[0] {
+	case "p", "p2", "g", "g2":
+	default:
+		return nil, fmt.Errorf("invalid policy rule %q: unknown policy type %q", strings.Join(values, ", "), rule[0])
+	}
+	return rule, nil
+}
+
+// SyncResult 描述一次策略同步的结果.
+type SyncResult struct {
+	// Missing 是策略包中有而数据库中没有的规则，check 以外的模式下已插入数据库.
+	Missing []Rule
+	// Extra 是数据库中有而策略包中没有的 p、p2 规则，authoritative 模式下已从数据库中删除.
+	Extra []Rule
+}
+
+// InSync 表示数据库中的策略与策略包是否一致.
+func (r *SyncResult) InSync() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+// SyncPolicies 将规则与 casbin_rule 表比较，并按 mode 在同一个事务中应用差异.
+// g、g2 规则（用户的角色分配）由接口维护，authoritative 模式也不会删除数据库中多余的 g、g2 规则.
+// 修改了数据库时会通知运行中的实例重新加载全部策略.
+func SyncPolicies(ctx context.Context, db *gorm.DB, rules []Rule, mode string) (*SyncResult, error) {
+	switch mode {
+	case SyncModeCheck, SyncModeAdditive, SyncModeAuthoritative:
+	default:
+		return nil, fmt.Errorf("invalid policy sync mode %q", mode)
+	}
+
+	// 确保 casbin_rule 表存在，使空数据库也可以直接同步策略
+	if _, err := adapter.NewAdapterByDB(db); err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []adapter.CasbinRule
+		if err := tx.Order("id").Find(&rows).Error; err != nil {
+			return err
+		}
+
+		existing := make(map[string]struct{}, len(rows))
+		for _, row := range rows {
+			existing[ruleFromRow(row).String()] = struct{}{}
+		}
+
+		desired := make(map[string]struct{}, len(rules))
+		for _, rule := range rules {
+			key := rule.String()
+			if _, ok := desired[key]; ok {
+				continue
+			}
+			desired[key] = struct{}{}
+			if _, ok := existing[key]; !ok {
+				result.Missing = append(result.Missing, rule)
+			}
+		}
+
+		var extraIDs []uint
+		for _, row := range rows {
+			rule := ruleFromRow(row)
+			if len(rule) == 0 || rule[0] == "g" || rule[0] == "g2" {
+				continue
+			}
+			if _, ok := desired[rule.String()]; !ok {
+				result.Extra = append(result.Extra, rule)
+				extraIDs = append(extraIDs, row.ID)
+			}
+		}
+
+		if mode == SyncModeCheck {
+			return nil
+		}
+
+		changed := false
+		for _, rule := range result.Missing {
+			row := rowFromRule(rule)
+			if err := tx.Create(&row).Error; err != nil {
+				return err
+			}
+			changed = true
+		}
+		if mode == SyncModeAuthoritative && len(extraIDs) > 0 {
+			if err := tx.Delete(&adapter.CasbinRule{}, extraIDs).Error; err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		// 版本行不存在时没有使用 DBWatcher 的实例，无需通知
+		if !changed || !tx.Migrator().HasTable(&casbinVersion{}) {
+			return nil
+		}
+		return NotifyReload(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ruleFromRow 将 casbin_rule 表中的一行转换为规则.
+func ruleFromRow(row adapter.CasbinRule) Rule {
+	rule := Rule{row.Ptype, row.V0, row.V1, row.V2, row.V3, row.V4, row.V5}
+	for len(rule) > 0 && rule[len(rule)-1] == "" {
+		rule = rule[:len(rule)-1]
+	}
+	return rule
+}
+
+// rowFromRule 将规则转换为 casbin_rule 表中的一行.
+func rowFromRule(rule Rule) adapter.CasbinRule {
+	values := slices.Concat(rule, make([]string, 7-len(rule)))
+	return adapter.CasbinRule{
+		Ptype: values[0],
+		V0:    values[1],
+		V1:    values[2],
+		V2:    values[3],
+		V3:    values[4],
+		V4:    values[5],
+		V5:    values[6],
+	}
+}
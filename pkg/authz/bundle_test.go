@@ -0,0 +1,139 @@
+package authz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestLoadBundle(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "policy.yaml")
+	csvFile := filepath.Join(dir, "policy.csv")
+
+	yamlData := `
+p:
+  - [role::user, user, delete, deny]
+p2:
+  - [role::user, post, update, "r2.res.OwnerID == r2.sub", allow]
+g:
+  - [bob, role::user]
+`
+	csvData := `# comment
+p, role::user, user, delete, deny
+p2, role::user, post, update, r2.res.OwnerID == r2.sub, allow
+
+g, bob, role::user
+`
+	if err := os.WriteFile(yamlFile, []byte(yamlData), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(csvFile, []byte(csvData), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Rule{
+		{"p", "role::user", "user", "delete", "deny"},
+		{"p2", "role::user", "post", "update", "r2.res.OwnerID == r2.sub", "allow"},
+		{"g", "bob", "role::user"},
+	}
+	for _, file := range []string{yamlFile, csvFile} {
+		rules, err := LoadBundle(file)
+		if err != nil {
+			t.Fatalf("LoadBundle(%s) error = %v", file, err)
+		}
+		if !slices.EqualFunc(rules, want, slices.Equal) {
+			t.Errorf("LoadBundle(%s) = %v, want %v", file, rules, want)
+		}
+	}
+
+	if err := os.WriteFile(csvFile, []byte("x, bob, role::user\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBundle(csvFile); err == nil {
+		t.Error("LoadBundle() with an unknown policy type should fail")
+	}
+}
+
+func TestSyncPolicies(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "authz.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	ctx := context.Background()
+
+	a, err := NewAuthz(db)
+	if err != nil {
+		t.Fatalf("NewAuthz() error = %v", err)
+	}
+	// 数据库中已有的策略和通过接口分配的角色
+	if _, err := a.AddPolicy("role::user", "user", "list", "deny"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.AddGroupingPolicy("alice", "role::user"); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []Rule{
+		{"p", "role::user", "user", "delete", "deny"},
+		{"g", "bob", "role::user"},
+	}
+
+	// check 模式只返回差异
+	result, err := SyncPolicies(ctx, db, rules, SyncModeCheck)
+	if err != nil {
+		t.Fatalf("SyncPolicies(check) error = %v", err)
+	}
+	if len(result.Missing) != 2 || len(result.Extra) != 1 {
+		t.Fatalf("SyncPolicies(check) = %+v, want 2 missing and 1 extra", result)
+	}
+	assertRuleCount(t, db, 2)
+
+	// additive 模式只插入缺少的规则
+	if _, err := SyncPolicies(ctx, db, rules, SyncModeAdditive); err != nil {
+		t.Fatalf("SyncPolicies(additive) error = %v", err)
+	}
+	assertRuleCount(t, db, 4)
+
+	// authoritative 模式删除多余的 p 规则，保留通过接口分配的角色
+	result, err = SyncPolicies(ctx, db, rules, SyncModeAuthoritative)
+	if err != nil {
+		t.Fatalf("SyncPolicies(authoritative) error = %v", err)
+	}
+	if len(result.Missing) != 0 || len(result.Extra) != 1 {
+		t.Fatalf("SyncPolicies(authoritative) = %+v, want 0 missing and 1 extra", result)
+	}
+	assertRuleCount(t, db, 3)
+
+	result, err = SyncPolicies(ctx, db, rules, SyncModeCheck)
+	if err != nil || !result.InSync() {
+		t.Fatalf("SyncPolicies(check) = %+v, %v, want in sync", result, err)
+	}
+
+	if err := a.LoadPolicy(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		sub, act string
+		want     bool
+	}{{"alice", "delete", false}, {"alice", "list", true}, {"bob", "delete", false}} {
+		if got, _ := a.Authorize(tt.sub, "user", tt.act); got != tt.want {
+			t.Errorf("Authorize(%s, user, %s) = %v, want %v", tt.sub, tt.act, got, tt.want)
+		}
+	}
+}
+
+func assertRuleCount(t *testing.T, db *gorm.DB, want int64) {
+	t.Helper()
+
+	var count int64
+	db.Table("casbin_rule").Count(&count)
+	if count != want {
+		t.Errorf("casbin_rule has %d rows, want %d", count, want)
+	}
+}
@@ -0,0 +1,97 @@
+package authz
+
+import "slices"
+
+// domainPtype 是按域划分的角色分配所使用的策略类型，规则格式为 sub, role, domain.
+const domainPtype = "g2"
+
+// SetDomainRole 将 sub 在域 domain 中的角色设置为 role，会替换 sub 在该域中原有的角色.
+// 替换在 enforcer 的锁中通过一次更新完成，不会出现 sub 暂时不在域中，或者新角色写入失败后丢失原有角色的情况.
+func (a *Authz) SetDomainRole(sub, role, domain string) error {
+	lock := a.GetLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	// 持有锁时只能调用未加锁的 *casbin.Enforcer 的方法
+	e := a.SyncedEnforcer.Enforcer
+	rule := []string{sub, role, domain}
+	current, err := e.GetFilteredNamedGroupingPolicy(domainPtype, 0, sub, "", domain)
+	if err != nil {
+		return err
+	}
+	if len(current) == 0 {
+		_, err := e.AddNamedGroupingPolicy(domainPtype, sub, role, domain)
+		return err
+	}
+
+	// 保留与新角色相同的规则，没有时将第一个原有角色更新为新角色，其余原有角色移除
+	if i := slices.IndexFunc(current, func(r []string) bool { return slices.Equal(r, rule) }); i > 0 {
+		current[0], current[i] = current[i], current[0]
+	}
+	if !slices.Equal(current[0], rule) {
+		if _, err := e.UpdateNamedGroupingPolicy(domainPtype, current[0], rule); err != nil {
+			return err
+		}
+	}
+	if len(current) > 1 {
+		_, err = e.RemoveNamedGroupingPolicies(domainPtype, current[1:])
+	}
+	return err
+}
+
+// RemoveDomainMember 移除 sub 在域 domain 中的全部角色，sub 不在该域中时返回 false.
+func (a *Authz) RemoveDomainMember(sub, domain string) (bool, error) {
+	return a.RemoveFilteredNamedGroupingPolicy(domainPtype, 0, sub, "", domain)
+}
+
+// RemoveDomain 移除域 domain 中的全部角色分配.
+func (a *Authz) RemoveDomain(domain string) error {
+	_, err := a.RemoveFilteredNamedGroupingPolicy(domainPtype, 2, domain)
+	return err
+}
+
+// DomainRoles 返回 sub 在域 domain 中直接拥有的角色.
+func (a *Authz) DomainRoles(sub, domain string) ([]string, error) {
+	rules, err := a.GetFilteredNamedGroupingPolicy(domainPtype, 0, sub, "", domain)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		roles = append(roles, rule[1])
+	}
+	return roles, nil
+}
+
+// DomainMembers 返回域 domain 中的全部角色分配，键为主体，值为主体在该域中的角色.
+func (a *Authz) DomainMembers(domain string) (map[string][]string, error) {
+	rules, err := a.GetFilteredNamedGroupingPolicy(domainPtype, 2, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string][]string, len(rules))
+	for _, rule := range rules {
+		members[rule[0]] = append(members[rule[0]], rule[1])
+	}
+	return members, nil
+}
+
+// Domains 返回 sub 拥有角色的全部域.
+func (a *Authz) Domains(sub string) ([]string, error) {
+	rules, err := a.GetFilteredNamedGroupingPolicy(domainPtype, 0, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(rules))
+	domains := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if _, ok := seen[rule[2]]; !ok {
+			seen[rule[2]] = struct{}{}
+			domains = append(domains, rule[2])
+		}
+	}
+	return domains, nil
+}
@@ -0,0 +1,110 @@
+package authz
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDomainRoles(t *testing.T) {
+	m, err := model.NewModelFromString(defaultAclModel)
+	if err != nil {
+		t.Fatalf("NewModelFromString() error = %v", err)
+	}
+	enforcer, err := casbin.NewSyncedEnforcer(m)
+	if err != nil {
+		t.Fatalf("NewSyncedEnforcer() error = %v", err)
+	}
+	a := &Authz{enforcer}
+
+	for _, member := range [][]string{
+		{"alice", "role::org-admin", "org-1"},
+		{"bob", "role::org-member", "org-1"},
+		{"bob", "role::org-admin", "org-2"},
+	} {
+		if err := a.SetDomainRole(member[0], member[1], member[2]); err != nil {
+			t.Fatalf("SetDomainRole() error = %v", err)
+		}
+	}
+
+	// 重新设置角色会替换原有的角色
+	if err := a.SetDomainRole("bob", "role::org-admin", "org-1"); err != nil {
+		t.Fatalf("SetDomainRole() error = %v", err)
+	}
+	if roles, _ := a.DomainRoles("bob", "org-1"); !slices.Equal(roles, []string{"role::org-admin"}) {
+		t.Errorf("DomainRoles(bob, org-1) = %v, want [role::org-admin]", roles)
+	}
+	if domains, _ := a.Domains("bob"); !slices.Equal(slices.Sorted(slices.Values(domains)), []string{"org-1", "org-2"}) {
+		t.Errorf("Domains(bob) = %v, want [org-1 org-2]", domains)
+	}
+
+	if removed, _ := a.RemoveDomainMember("alice", "org-1"); !removed {
+		t.Errorf("RemoveDomainMember(alice, org-1) = false, want true")
+	}
+	if members, _ := a.DomainMembers("org-1"); len(members) != 1 || members["bob"] == nil {
+		t.Errorf("DomainMembers(org-1) = %v, want only bob", members)
+	}
+
+	if err := a.RemoveDomain("org-1"); err != nil {
+		t.Fatalf("RemoveDomain() error = %v", err)
+	}
+	if domains, _ := a.Domains("bob"); !slices.Equal(domains, []string{"org-2"}) {
+		t.Errorf("Domains(bob) = %v, want [org-2]", domains)
+	}
+
+	// 组织中的角色不影响全局角色
+	if roles, _ := a.GetRolesForUser("bob"); len(roles) != 0 {
+		t.Errorf("GetRolesForUser(bob) = %v, want none", roles)
+	}
+}
+
+func TestSetDomainRoleAtomic(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "authz.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	a, err := NewAuthz(db)
+	if err != nil {
+		t.Fatalf("NewAuthz() error = %v", err)
+	}
+	if err := a.SetDomainRole("bob", "role::org-member", "org-1"); err != nil {
+		t.Fatalf("SetDomainRole() error = %v", err)
+	}
+
+	// 替换角色写入数据库失败时保留原有角色
+	fail := true
+	failWrite := func(tx *gorm.DB) {
+		if fail {
+			_ = tx.AddError(errors.New("write failed"))
+		}
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register("test:fail", failWrite); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("test:fail", failWrite); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.SetDomainRole("bob", "role::org-admin", "org-1"); err == nil {
+		t.Fatalf("SetDomainRole() error = nil, want write error")
+	}
+	if roles, _ := a.DomainRoles("bob", "org-1"); !slices.Equal(roles, []string{"role::org-member"}) {
+		t.Errorf("DomainRoles(bob, org-1) = %v, want [role::org-member]", roles)
+	}
+
+	fail = false
+	if err := a.SetDomainRole("bob", "role::org-admin", "org-1"); err != nil {
+		t.Fatalf("SetDomainRole() error = %v", err)
+	}
+	if err := a.LoadPolicy(); err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	if roles, _ := a.DomainRoles("bob", "org-1"); !slices.Equal(roles, []string{"role::org-admin"}) {
+		t.Errorf("DomainRoles(bob, org-1) after reload = %v, want [role::org-admin]", roles)
+	}
+}
@@ -0,0 +1,104 @@
+package authz
+
+import (
+	"slices"
+
+	casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	"github.com/casbin/casbin/v2/util"
+)
+
+// Explanation 描述一次授权判断的过程.
+type Explanation struct {
+	// Allowed 表示授权结果.
+	Allowed bool
+	// Roles 是主体通过角色继承实际拥有的全部角色.
+	Roles []string
+	// Matches 是与请求匹配的全部策略.
+	Matches []PolicyMatch
+	// DecidingPolicy 是导致拒绝的 deny 策略，格式为 sub, obj, act, eft. 允许访问时为空.
+	DecidingPolicy []string
+}
+
+// PolicyMatch 描述一条与请求匹配的策略.
+type PolicyMatch struct {
+	// Policy 是匹配的策略，格式为 sub, obj, act, eft.
+	Policy []string
+	// RoleChain 是从请求主体到策略主体的角色继承链，第一个元素是请求主体，最后一个元素是策略主体.
+	RoleChain []string
+}
+
+// NewAuthzFromFile 创建一个从 CSV 策略文件加载策略的授权器，用于离线评估策略，不会修改策略文件.
+func NewAuthzFromFile(path string) (*Authz, error) {
+	m, err := model.NewModelFromString(defaultAclModel)
+	if err != nil {
+		return nil, err
+	}
+
+	enforcer, err := casbin.NewSyncedEnforcer(m, fileadapter.NewAdapter(path))
+	if err != nil {
+		return nil, err
+	}
+	enforcer.EnableAutoSave(false)
+
+	return &Authz{enforcer}, nil
+}
+
+// Explain 与 Authorize 使用相同的规则进行授权，并返回授权结果、匹配的策略、角色继承链以及导致拒绝的 deny 策略.
+func (a *Authz) Explain(sub, obj, act string) (*Explanation, error) {
+	allowed, deciding, err := a.EnforceEx(sub, obj, act)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := a.GetImplicitRolesForUser(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := a.GetImplicitPermissionsForUser(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{Allowed: allowed, Roles: roles}
+	if !allowed {
+		explanation.DecidingPolicy = deciding
+	}
+	for _, p := range policies {
+		if len(p) == 4 && util.KeyMatch(obj, p[1]) && util.KeyMatch(act, p[2]) {
+			explanation.Matches = append(explanation.Matches, PolicyMatch{Policy: p, RoleChain: a.roleChain(sub, p[0])})
+		}
+	}
+
+	return explanation, nil
+}
+
+// roleChain 返回从 sub 到 role 的最短角色继承链，sub 没有继承 role 时返回 nil.
+func (a *Authz) roleChain(sub, role string) []string {
+	parents := map[string]string{sub: ""}
+	queue := []string{sub}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == role {
+			chain := []string{current}
+			for parent := parents[current]; parent != ""; parent = parents[parent] {
+				chain = append(chain, parent)
+			}
+			slices.Reverse(chain)
+			return chain
+		}
+
+		roles, _ := a.GetRolesForUser(current)
+		for _, r := range roles {
+			if _, ok := parents[r]; !ok {
+				parents[r] = current
+				queue = append(queue, r)
+			}
+		}
+	}
+	return nil
+}
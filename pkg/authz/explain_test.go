@@ -0,0 +1,57 @@
+package authz
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.csv")
+	policy := `p, role::admin, *, *, allow
+p, role::user, user, delete, deny
+p, role::user, user, list, deny
+p, role::editor, post, *, allow
+g, alice, role::editor
+g, role::editor, role::user
+g, root, role::admin
+`
+	if err := os.WriteFile(path, []byte(policy), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	a, err := NewAuthzFromFile(path)
+	if err != nil {
+		t.Fatalf("NewAuthzFromFile() error = %v", err)
+	}
+
+	e, err := a.Explain("alice", "user", "delete")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if e.Allowed {
+		t.Errorf("Allowed = true, want false")
+	}
+	if want := []string{"role::user", "user", "delete", "deny"}; !slices.Equal(e.DecidingPolicy, want) {
+		t.Errorf("DecidingPolicy = %v, want %v", e.DecidingPolicy, want)
+	}
+	if len(e.Matches) != 1 || !slices.Equal(e.Matches[0].RoleChain, []string{"alice", "role::editor", "role::user"}) {
+		t.Errorf("Matches = %+v, want a single match via alice -> role::editor -> role::user", e.Matches)
+	}
+	slices.Sort(e.Roles)
+	if want := []string{"role::editor", "role::user"}; !slices.Equal(e.Roles, want) {
+		t.Errorf("Roles = %v, want %v", e.Roles, want)
+	}
+
+	e, err = a.Explain("root", "user", "delete")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !e.Allowed || e.DecidingPolicy != nil {
+		t.Errorf("Explain(root) = %+v, want allowed without deciding policy", e)
+	}
+	if len(e.Matches) != 1 || !slices.Equal(e.Matches[0].RoleChain, []string{"root", "role::admin"}) {
+		t.Errorf("Matches = %+v, want a single match via root -> role::admin", e.Matches)
+	}
+}
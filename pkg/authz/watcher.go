@@ -0,0 +1,167 @@
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/google/uuid"
+)
+
+// 策略变更消息的类型.
+const (
+	// methodReload 表示需要重新加载全部策略.
+	methodReload = "reload"
+	// methodAddPolicies 表示新增了若干条策略.
+	methodAddPolicies = "add-policies"
+	// methodRemovePolicies 表示删除了若干条策略.
+	methodRemovePolicies = "remove-policies"
+	// methodRemoveFilteredPolicy 表示按条件删除了策略.
+	methodRemoveFilteredPolicy = "remove-filtered-policy"
+)
+
+// errReloadRequired 表示策略变更无法增量应用，需要重新加载全部策略.
+var errReloadRequired = errors.New("policy reload required")
+
+// message 是在实例之间广播的策略变更消息.
+type message struct {
+	// Method 是变更的类型.
+	Method string `json:"method"`
+	// Sender 是发送消息的实例 ID，实例会忽略自己发送的消息.
+	Sender      string     `json:"sender"`
+	Sec         string     `json:"sec,omitempty"`
+	Ptype       string     `json:"ptype,omitempty"`
+	Rules       [][]string `json:"rules,omitempty"`
+	FieldIndex  int        `json:"fieldIndex,omitempty"`
+	FieldValues []string   `json:"fieldValues,omitempty"`
+}
+
+// publisher 定义了广播策略变更消息的方式.
+type publisher interface {
+	publish(data []byte) error
+}
+
+// watcher 实现了 casbin 的 persist.WatcherEx 接口中除 Close 之外的方法. 它将本实例的策略变更编码为消息，交给 publisher 广播给其他实例，
+// 并在收到其他实例的消息时调用更新回调. RedisWatcher 和 DBWatcher 基于它实现不同的广播方式.
+type watcher struct {
+	id  string
+	pub publisher
+
+	mu       sync.RWMutex
+	callback func(string)
+}
+
+// newWatcher 创建一个使用 pub 广播消息的 watcher，每个 watcher 拥有唯一的实例 ID.
+func newWatcher(pub publisher) *watcher {
+	return &watcher{id: uuid.NewString(), pub: pub}
+}
+
+// SetUpdateCallback 设置收到其他实例的策略变更消息时调用的回调函数.
+func (w *watcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = callback
+	return nil
+}
+
+// Update 通知其他实例重新加载全部策略.
+func (w *watcher) Update() error {
+	return w.send(message{Method: methodReload})
+}
+
+// UpdateForAddPolicy 通知其他实例新增了一条策略.
+func (w *watcher) UpdateForAddPolicy(sec, ptype string, params ...string) error {
+	return w.send(message{Method: methodAddPolicies, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+// UpdateForRemovePolicy 通知其他实例删除了一条策略.
+func (w *watcher) UpdateForRemovePolicy(sec, ptype string, params ...string) error {
+	return w.send(message{Method: methodRemovePolicies, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+// UpdateForRemoveFilteredPolicy 通知其他实例按条件删除了策略.
+func (w *watcher) UpdateForRemoveFilteredPolicy(sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	return w.send(message{Method: methodRemoveFilteredPolicy, Sec: sec, Ptype: ptype, FieldIndex: fieldIndex, FieldValues: fieldValues})
+}
+
+// UpdateForSavePolicy 通知其他实例重新加载全部策略.
+func (w *watcher) UpdateForSavePolicy(model model.Model) error {
+	return w.send(message{Method: methodReload})
+}
+
+// UpdateForAddPolicies 通知其他实例新增了若干条策略.
+func (w *watcher) UpdateForAddPolicies(sec string, ptype string, rules ...[]string) error {
+	return w.send(message{Method: methodAddPolicies, Sec: sec, Ptype: ptype, Rules: rules})
+}
+
+// UpdateForRemovePolicies 通知其他实例删除了若干条策略.
+func (w *watcher) UpdateForRemovePolicies(sec string, ptype string, rules ...[]string) error {
+	return w.send(message{Method: methodRemovePolicies, Sec: sec, Ptype: ptype, Rules: rules})
+}
+
+// send 编码并广播策略变更消息.
+func (w *watcher) send(msg message) error {
+	msg.Sender = w.id
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return w.pub.publish(data)
+}
+
+// receive 处理收到的策略变更消息，忽略本实例发送的消息.
+func (w *watcher) receive(data []byte) {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err == nil && msg.Sender == w.id {
+		return
+	}
+
+	w.mu.RLock()
+	callback := w.callback
+	w.mu.RUnlock()
+	if callback != nil {
+		callback(string(data))
+	}
+}
+
+// reload 通知本实例重新加载全部策略，用于无法确定错过了哪些变更的场景.
+func (w *watcher) reload() {
+	data, _ := json.Marshal(message{Method: methodReload})
+	w.receive(data)
+}
+
+// applyUpdate 是 watcher 的更新回调，应用其他实例广播的策略变更. 无法增量应用时重新加载全部策略.
+func (a *Authz) applyUpdate(data string) {
+	var msg message
+	if err := json.Unmarshal([]byte(data), &msg); err == nil && a.applyIncremental(msg) == nil {
+		return
+	}
+
+	_ = a.LoadPolicy()
+}
+
+// applyIncremental 增量应用策略变更. 变更已经由发送方持久化，这里只更新内存中的策略，因此需要临时关闭自动保存.
+func (a *Authz) applyIncremental(msg message) error {
+	lock := a.GetLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	// 持有锁时只能调用未加锁的 *casbin.Enforcer 的方法
+	e := a.SyncedEnforcer.Enforcer
+	e.EnableAutoSave(false)
+	defer e.EnableAutoSave(true)
+
+	var err error
+	switch msg.Method {
+	case methodAddPolicies:
+		_, err = e.SelfAddPolicies(msg.Sec, msg.Ptype, msg.Rules)
+	case methodRemovePolicies:
+		_, err = e.SelfRemovePolicies(msg.Sec, msg.Ptype, msg.Rules)
+	case methodRemoveFilteredPolicy:
+		_, err = e.SelfRemoveFilteredPolicy(msg.Sec, msg.Ptype, msg.FieldIndex, msg.FieldValues...)
+	default:
+		err = errReloadRequired
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/persist"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxDBMessageSize 定义写入版本行的策略变更消息的最大长度，超过时通知其他实例重新加载全部策略.
+const maxDBMessageSize = 60 * 1024
+
+// casbinVersion 是 casbin_version 表中的版本行. 每次策略变更时版本号加 1，并记录最近一次变更的消息.
+type casbinVersion struct {
+	ID        int64     `gorm:"column:id;primaryKey"`
+	Version   int64     `gorm:"column:version;not null"`
+	Message   string    `gorm:"column:message;type:text"`
+	UpdatedAt time.Time `gorm:"column:updatedAt;not null;default:current_timestamp"`
+}
+
+// TableName 返回版本行所在的表名.
+func (*casbinVersion) TableName() string {
+	return "casbin_version"
+}
+
+// casbinVersionID 是版本行的主键.
+const casbinVersionID = 1
+
+// DBWatcher 通过数据库中的版本行在实例之间同步策略变更，不依赖额外的基础设施.
+// 每次策略变更时版本号加 1 并记录变更消息，其他实例定期检查版本号：只落后一个版本时增量应用变更，
+// 落后多个版本时重新加载全部策略.
+type DBWatcher struct {
+	*watcher
+
+	db     *gorm.DB
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	version int64
+}
+
+// 确保 DBWatcher 实现了 persist.WatcherEx 接口.
+var _ persist.WatcherEx = (*DBWatcher)(nil)
+
+// NewDBWatcher 创建一个 DBWatcher，每隔 interval 检查一次版本行.
+func NewDBWatcher(db *gorm.DB, interval time.Duration) (*DBWatcher, error) {
+	// 版本表不存在时创建，使未导入 configs/miniblog.sql 的空数据库也可以使用
+	if !db.Migrator().HasTable(&casbinVersion{}) {
+		if err := db.Migrator().CreateTable(&casbinVersion{}); err != nil {
+			return nil, err
+		}
+	}
+
+	// 版本行不存在时创建
+	row := casbinVersion{ID: casbinVersionID}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+		return nil, err
+	}
+	if err := db.First(&row, casbinVersionID).Error; err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &DBWatcher{
+		db:      db,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		version: row.Version,
+	}
+	w.watcher = newWatcher(w)
+
+	go w.run(ctx, interval)
+
+	return w, nil
+}
+
+// publish 实现了 publisher 接口.
+func (w *DBWatcher) publish(data []byte) error {
+	if len(data) > maxDBMessageSize {
+		data, _ = json.Marshal(message{Method: methodReload, Sender: w.id})
+	}
+
+	return bumpVersion(w.db, data)
+}
+
+// run 每隔 interval 检查一次版本行，直到 watcher 被关闭.
+func (w *DBWatcher) run(ctx context.Context, interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll 检查版本行是否发生变化. 本实例发送的消息在 receive 中被忽略.
+func (w *DBWatcher) poll(ctx context.Context) {
+	var row casbinVersion
+	if err := w.db.WithContext(ctx).First(&row, casbinVersionID).Error; err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	previous := w.version
+	w.version = row.Version
+	w.mu.Unlock()
+
+	switch {
+	case row.Version == previous:
+	case row.Version == previous+1:
+		w.receive([]byte(row.Message))
+	default:
+		// 错过了多次变更，或者版本行被重置
+		w.reload()
+	}
+}
+
+// Close 停止检查版本行.
+func (w *DBWatcher) Close() {
+	w.cancel()
+	<-w.done
+}
+
+// NotifyReload 通知使用 DBWatcher 的实例重新加载全部策略，用于绕过授权器直接修改 casbin_rule 表的场景.
+func NotifyReload(db *gorm.DB) error {
+	data, err := json.Marshal(message{Method: methodReload})
+	if err != nil {
+		return err
+	}
+	return bumpVersion(db, data)
+}
+
+// bumpVersion 将版本号加 1，并记录本次变更的消息.
+func bumpVersion(db *gorm.DB, data []byte) error {
+	return db.Model(&casbinVersion{}).Where("id = ?", casbinVersionID).Updates(map[string]any{
+		"version":   gorm.Expr("version + 1"),
+		"message":   string(data),
+		"updatedAt": time.Now(),
+	}).Error
+}
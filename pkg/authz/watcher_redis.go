@@ -0,0 +1,89 @@
+package authz
+
+import (
+	"context"
+	"time"
+
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisWatcher 通过 Redis 发布/订阅在实例之间同步策略变更.
+// 订阅连接断开重连后，期间的消息可能已经丢失，因此会重新加载一次全部策略.
+type RedisWatcher struct {
+	*watcher
+
+	client  redis.UniversalClient
+	channel string
+	pubsub  *redis.PubSub
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// 确保 RedisWatcher 实现了 persist.WatcherEx 接口.
+var _ persist.WatcherEx = (*RedisWatcher)(nil)
+
+// NewRedisWatcher 创建一个 RedisWatcher，在 channel 上发布和订阅策略变更消息.
+func NewRedisWatcher(client redis.UniversalClient, channel string) (*RedisWatcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pubsub := client.Subscribe(ctx, channel)
+	// 等待订阅成功，确保 Redis 可用
+	if _, err := pubsub.Receive(ctx); err != nil {
+		cancel()
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	w := &RedisWatcher{
+		client:  client,
+		channel: channel,
+		pubsub:  pubsub,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	w.watcher = newWatcher(w)
+
+	go w.run(ctx)
+
+	return w, nil
+}
+
+// publish 实现了 publisher 接口.
+func (w *RedisWatcher) publish(data []byte) error {
+	return w.client.Publish(context.Background(), w.channel, data).Err()
+}
+
+// run 持续接收订阅消息，直到 watcher 被关闭.
+func (w *RedisWatcher) run(ctx context.Context) {
+	defer close(w.done)
+
+	for {
+		msg, err := w.pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// 连接异常时 go-redis 会在下次 Receive 时重新连接并订阅
+			time.Sleep(time.Second)
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Message:
+			w.receive([]byte(msg.Payload))
+		case *redis.Subscription:
+			// 重新订阅成功，断开期间可能错过了策略变更
+			if msg.Kind == "subscribe" {
+				w.reload()
+			}
+		}
+	}
+}
+
+// Close 停止订阅并释放资源.
+func (w *RedisWatcher) Close() {
+	w.cancel()
+	_ = w.pubsub.Close()
+	<-w.done
+}
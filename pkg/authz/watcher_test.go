@@ -0,0 +1,68 @@
+package authz
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDBWatcher(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "authz.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	if err := db.AutoMigrate(&casbinVersion{}); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+
+	newAuthz := func() *Authz {
+		w, err := NewDBWatcher(db, 10*time.Millisecond)
+		if err != nil {
+			t.Fatalf("NewDBWatcher() error = %v", err)
+		}
+		t.Cleanup(w.Close)
+
+		a, err := NewAuthz(db, WithWatcher(w))
+		if err != nil {
+			t.Fatalf("NewAuthz() error = %v", err)
+		}
+		return a
+	}
+	a, b := newAuthz(), newAuthz()
+
+	// eventually 等待 b 中的授权结果变为 want
+	eventually := func(sub string, want bool) {
+		t.Helper()
+		for range 100 {
+			if got, _ := b.Can(sub, "user", "list-all"); got == want {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Can(%s) on the other instance never became %v", sub, want)
+	}
+
+	// 增量同步
+	if _, err := a.AddPolicy("role::auditor", "user", "list-all", "allow"); err != nil {
+		t.Fatalf("AddPolicy() error = %v", err)
+	}
+	if _, err := a.AddGroupingPolicy("bob", "role::auditor"); err != nil {
+		t.Fatalf("AddGroupingPolicy() error = %v", err)
+	}
+	eventually("bob", true)
+
+	if _, err := a.RemoveFilteredGroupingPolicy(0, "bob"); err != nil {
+		t.Fatalf("RemoveFilteredGroupingPolicy() error = %v", err)
+	}
+	eventually("bob", false)
+
+	// 应用其他实例的变更时不会重复写入数据库
+	var count int64
+	db.Table("casbin_rule").Count(&count)
+	if count != 1 {
+		t.Errorf("casbin_rule has %d rows, want 1", count)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 许铭杰 (1044011439@qq.com). All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package cipher 提供对敏感数据进行对称加密的能力，用于保存需要还原明文的机密信息，例如 TOTP 密钥.
+package cipher
+
+import (
+	"crypto/aes"
+	stdcipher "crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+// ErrCiphertextInvalid 表示密文格式错误或已被篡改.
+var ErrCiphertextInvalid = errors.New("cipher: invalid ciphertext")
+
+// Cipher 使用 AES-256-GCM 对数据进行加解密.
+type Cipher struct {
+	aead stdcipher.AEAD
+}
+
+// New 使用给定的密钥创建 Cipher. 密钥会通过 SHA-256 派生为 32 字节的 AES 密钥.
+func New(key string) (*Cipher, error) {
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := stdcipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt 加密明文，返回 base64 编码的密文. 每次加密都会使用随机的 nonce.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密 Encrypt 返回的密文.
+func (c *Cipher) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrCiphertextInvalid
+	}
+
+	nonce, data := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", ErrCiphertextInvalid
+	}
+
+	return string(plaintext), nil
+}
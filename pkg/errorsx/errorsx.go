@@ -7,6 +7,7 @@ import (
 
 	httpstatus "github.com/go-kratos/kratos/v2/transport/http/status"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -69,10 +70,18 @@ func (err *ErrorX) KV(kvs ...string) *ErrorX {
 // GRPCStatus 返回 gRPC 状态表示.
 func (err *ErrorX) GRPCStatus() *status.Status {
 	details := errdetails.ErrorInfo{Reason: err.Reason, Metadata: err.Metadata}
-	s, _ := status.New(httpstatus.ToGRPCCode(err.Code), err.Message).WithDetails(&details)
+	s, _ := status.New(toGRPCCode(err.Code), err.Message).WithDetails(&details)
 	return s
 }
 
+// toGRPCCode 将 HTTP 状态码转换为 gRPC 状态码，补充了 kratos 未覆盖的 412.
+func toGRPCCode(code int) codes.Code {
+	if code == http.StatusPreconditionFailed {
+		return codes.FailedPrecondition
+	}
+	return httpstatus.ToGRPCCode(code)
+}
+
 // WithRequestID 设置请求 ID.
 func (err *ErrorX) WithRequestID(requestID string) *ErrorX {
 	return err.KV("X-Request-ID", requestID) // 设置请求 ID
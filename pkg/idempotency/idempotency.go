@@ -0,0 +1,140 @@
+// Package idempotency 提供幂等键能力：同一个幂等键的重试请求直接返回第一次请求的响应，不会重复执行.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrKeyReused 表示幂等键已被请求内容不同的请求使用.
+	ErrKeyReused = errors.New("idempotency key reused with a different request")
+	// ErrInProgress 表示使用同一个幂等键的请求仍在处理中，等待超时.
+	ErrInProgress = errors.New("request with the same idempotency key is in progress")
+)
+
+const (
+	// defaultLockTTL 定义处理中的记录的默认保留时间，避免进程异常退出后幂等键一直处于处理中状态.
+	defaultLockTTL = time.Minute
+	// defaultWait 定义等待并发的重复请求处理完成的默认时间.
+	defaultWait = 10 * time.Second
+	// pollInterval 定义等待并发的重复请求时检查记录的间隔.
+	pollInterval = 50 * time.Millisecond
+)
+
+// Record 表示一个幂等键对应的请求和响应.
+type Record struct {
+	// Fingerprint 表示请求内容的摘要，用于识别使用同一个幂等键的不同请求.
+	Fingerprint string `json:"fingerprint"`
+	// Completed 表示请求是否已经处理完成.
+	Completed bool `json:"completed"`
+	// Response 表示序列化后的响应，格式由调用方决定.
+	Response []byte `json:"response,omitempty"`
+}
+
+// Store 定义幂等记录的存储接口. 单实例部署可以使用内存存储，多实例部署需要使用 Redis 等共享存储.
+type Store interface {
+	// Reserve 在 key 不存在时保存一条处理中的记录并返回 true，记录在 ttl 后过期；key 已存在时返回已有的记录和 false.
+	Reserve(ctx context.Context, key string, fingerprint string, ttl time.Duration) (*Record, bool, error)
+	// Complete 保存 key 的响应并将记录标记为已完成，记录在 ttl 后过期.
+	Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	// Release 删除 key 对应的记录.
+	Release(ctx context.Context, key string) error
+}
+
+// Option 定义 Guard 的可选配置.
+type Option func(*Guard)
+
+// WithLockTTL 设置处理中的记录的保留时间，应大于请求的最长处理时间.
+func WithLockTTL(ttl time.Duration) Option {
+	return func(g *Guard) {
+		g.lockTTL = ttl
+	}
+}
+
+// WithWait 设置等待并发的重复请求处理完成的最长时间.
+func WithWait(wait time.Duration) Option {
+	return func(g *Guard) {
+		g.wait = wait
+	}
+}
+
+// Guard 保证同一个幂等键的请求只执行一次.
+type Guard struct {
+	store   Store
+	ttl     time.Duration
+	lockTTL time.Duration
+	wait    time.Duration
+}
+
+// New 创建一个 *Guard 实例，已完成的响应保留 ttl.
+func New(store Store, ttl time.Duration, opts ...Option) *Guard {
+	g := &Guard{store: store, ttl: ttl, lockTTL: defaultLockTTL, wait: defaultWait}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Do 使用幂等键 key 执行 fn.
+//
+// key 第一次出现时执行 fn 并保存其响应，replayed 为 false；之后请求内容相同的重试直接返回保存的响应，replayed 为 true.
+// 请求内容不同时返回 ErrKeyReused. 同一个 key 的并发请求会等待第一个请求处理完成，等待超时返回 ErrInProgress.
+// fn 返回错误时不保存响应并删除记录，之后可以使用同一个 key 重试.
+func (g *Guard) Do(ctx context.Context, key, fingerprint string, fn func() ([]byte, error)) (response []byte, replayed bool, err error) {
+	deadline := time.Now().Add(g.wait)
+	for {
+		record, reserved, err := g.store.Reserve(ctx, key, fingerprint, g.lockTTL)
+		if err != nil {
+			return nil, false, err
+		}
+		if reserved {
+			break
+		}
+
+		if record.Fingerprint != fingerprint {
+			return nil, false, ErrKeyReused
+		}
+		if record.Completed {
+			return record.Response, true, nil
+		}
+
+		// 第一个请求仍在处理中，等待其完成后返回相同的响应
+		if time.Now().After(deadline) {
+			return nil, false, ErrInProgress
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+
+	response, err = fn()
+	if err != nil {
+		// 请求失败时不保存结果，使客户端可以使用同一个 key 重试
+		_ = g.store.Release(context.WithoutCancel(ctx), key)
+		return nil, false, err
+	}
+
+	record := &Record{Fingerprint: fingerprint, Completed: true, Response: response}
+	if err := g.store.Complete(context.WithoutCancel(ctx), key, record, g.ttl); err != nil {
+		return nil, false, err
+	}
+	return response, false, nil
+}
+
+// Fingerprint 返回请求内容的摘要，parts 依次为请求的方法、参数等.
+func Fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+	for _, part := range parts {
+		// 写入长度，避免不同的拆分方式得到相同的摘要
+		_ = binary.Write(h, binary.BigEndian, uint64(len(part)))
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGuard(t *testing.T) {
+	ctx := context.Background()
+	g := New(NewMemoryStore(), time.Hour)
+
+	var calls atomic.Int32
+	fn := func() ([]byte, error) {
+		calls.Add(1)
+		return []byte("created"), nil
+	}
+
+	if resp, replayed, err := g.Do(ctx, "user:foo:key", "a", fn); err != nil || replayed || string(resp) != "created" {
+		t.Fatalf("Do() = %s, %v, %v", resp, replayed, err)
+	}
+	// 请求内容相同的重试返回保存的响应
+	if resp, replayed, err := g.Do(ctx, "user:foo:key", "a", fn); err != nil || !replayed || string(resp) != "created" {
+		t.Fatalf("Do() retry = %s, %v, %v", resp, replayed, err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("fn called %d times, want 1", calls.Load())
+	}
+
+	// 请求内容不同时返回冲突
+	if _, _, err := g.Do(ctx, "user:foo:key", "b", fn); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("Do() with different fingerprint error = %v, want %v", err, ErrKeyReused)
+	}
+
+	// 请求失败时不保存结果，可以使用同一个 key 重试
+	failed := errors.New("failed")
+	if _, _, err := g.Do(ctx, "user:foo:retry", "a", func() ([]byte, error) { return nil, failed }); !errors.Is(err, failed) {
+		t.Fatalf("Do() error = %v, want %v", err, failed)
+	}
+	if _, replayed, err := g.Do(ctx, "user:foo:retry", "a", fn); err != nil || replayed {
+		t.Errorf("Do() after failure = %v, %v", replayed, err)
+	}
+}
+
+func TestGuardConcurrent(t *testing.T) {
+	ctx := context.Background()
+	g := New(NewMemoryStore(), time.Hour)
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, _, err := g.Do(ctx, "user:foo:key", "a", func() ([]byte, error) {
+				calls.Add(1)
+				time.Sleep(100 * time.Millisecond)
+				return []byte("created"), nil
+			})
+			if err != nil || string(resp) != "created" {
+				t.Errorf("Do() = %s, %v", resp, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("fn called %d times, want 1", calls.Load())
+	}
+
+	// 等待超时返回 ErrInProgress
+	g = New(NewMemoryStore(), time.Hour, WithWait(0))
+	done := make(chan struct{})
+	go func() {
+		_, _, _ = g.Do(ctx, "key", "a", func() ([]byte, error) {
+			<-done
+			return nil, nil
+		})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if _, _, err := g.Do(ctx, "key", "a", func() ([]byte, error) { return nil, nil }); !errors.Is(err, ErrInProgress) {
+		t.Errorf("Do() error = %v, want %v", err, ErrInProgress)
+	}
+	close(done)
+}
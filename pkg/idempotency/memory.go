@@ -0,0 +1,79 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval 表示每保存多少次记录清理一次过期记录.
+const sweepInterval = 1024
+
+// memoryEntry 是内存存储中的一条幂等记录.
+type memoryEntry struct {
+	record   Record
+	expireAt time.Time
+}
+
+// MemoryStore 是基于内存的 Store 实现，只适用于单实例部署.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+	writes  int
+}
+
+// 确保 *MemoryStore 实现了 Store 接口.
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore 创建一个 *MemoryStore 实例.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+// Reserve 在 key 不存在时保存一条处理中的记录.
+func (s *MemoryStore) Reserve(ctx context.Context, key string, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expireAt) {
+		record := entry.record
+		return &record, false, nil
+	}
+
+	s.set(key, Record{Fingerprint: fingerprint}, now.Add(ttl))
+	return nil, true, nil
+}
+
+// Complete 保存 key 的响应.
+func (s *MemoryStore) Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set(key, *record, time.Now().Add(ttl))
+	return nil
+}
+
+// Release 删除 key 对应的记录.
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// set 保存记录，并定期清理过期记录，避免大量不同的 key 导致内存持续增长.
+func (s *MemoryStore) set(key string, record Record, expireAt time.Time) {
+	s.writes++
+	if s.writes%sweepInterval == 0 {
+		now := time.Now()
+		for k, entry := range s.entries {
+			if now.After(entry.expireAt) {
+				delete(s.entries, k)
+			}
+		}
+	}
+
+	s.entries[key] = &memoryEntry{record: record, expireAt: expireAt}
+}
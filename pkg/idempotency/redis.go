@@ -0,0 +1,75 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// maxReserveAttempts 定义 Reserve 的最大尝试次数. 已有的记录可能在读取之前过期或被删除，此时需要重新尝试保存.
+const maxReserveAttempts = 3
+
+// RedisStore 是基于 Redis 的 Store 实现，多实例部署时共享幂等记录.
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// 确保 *RedisStore 实现了 Store 接口.
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore 创建一个 *RedisStore 实例，prefix 会添加到所有 key 之前.
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Reserve 使用 SET NX 原子地保存一条处理中的记录.
+func (s *RedisStore) Reserve(ctx context.Context, key string, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	data, err := json.Marshal(&Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, false, err
+	}
+
+	for range maxReserveAttempts {
+		ok, err := s.client.SetNX(ctx, s.prefix+key, data, ttl).Result()
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return nil, true, nil
+		}
+
+		value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		var record Record
+		if err := json.Unmarshal(value, &record); err != nil {
+			return nil, false, err
+		}
+		return &record, false, nil
+	}
+
+	return nil, false, ErrInProgress
+}
+
+// Complete 保存 key 的响应.
+func (s *RedisStore) Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.prefix+key, data, ttl).Err()
+}
+
+// Release 删除 key 对应的记录.
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
@@ -0,0 +1,112 @@
+// Package lockout 提供基于失败次数的退避和临时锁定能力，可用于防止登录暴力破解.
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+// State 记录某个 key 的连续失败状态.
+type State struct {
+	// Failures 表示连续失败次数.
+	Failures int64
+	// LastFailure 表示最近一次失败的时间.
+	LastFailure time.Time
+}
+
+// Store 定义失败状态的存储接口. 单实例部署可以使用内存存储，多实例部署需要使用 Redis 等共享存储.
+type Store interface {
+	// Get 返回 key 当前的失败状态，不存在时返回零值.
+	Get(ctx context.Context, key string) (State, error)
+	// RecordFailure 记录一次失败并返回更新后的状态，状态在 ttl 后过期.
+	RecordFailure(ctx context.Context, key string, now time.Time, ttl time.Duration) (State, error)
+	// Reset 清除 key 的失败状态.
+	Reset(ctx context.Context, key string) error
+}
+
+// Policy 定义退避和锁定策略.
+//
+// 前 Threshold-1 次失败后，下一次尝试需要等待 BaseDelay * 2^(失败次数-1)，最长不超过 MaxDelay；
+// 失败次数达到 Threshold 后锁定 LockoutDuration.
+type Policy struct {
+	// Threshold 表示触发锁定的连续失败次数，小于等于 0 表示不锁定.
+	Threshold int64
+	// BaseDelay 表示首次失败后的退避时间.
+	BaseDelay time.Duration
+	// MaxDelay 表示退避时间的上限.
+	MaxDelay time.Duration
+	// LockoutDuration 表示锁定时长.
+	LockoutDuration time.Duration
+}
+
+// wait 返回在最近一次失败之后需要等待的时间.
+func (p Policy) wait(failures int64) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	if p.Threshold > 0 && failures >= p.Threshold {
+		return p.LockoutDuration
+	}
+
+	delay := p.BaseDelay
+	for i := int64(1); i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// ttl 返回失败状态的保留时间，超过该时间没有新的失败则计数清零.
+func (p Policy) ttl() time.Duration {
+	return max(p.LockoutDuration, p.MaxDelay)
+}
+
+// Guard 按 key 维度跟踪失败次数，并根据策略判断是否允许继续尝试.
+type Guard struct {
+	store  Store
+	policy Policy
+	now    func() time.Time
+}
+
+// New 创建一个 *Guard 实例.
+func New(store Store, policy Policy) *Guard {
+	return &Guard{store: store, policy: policy, now: time.Now}
+}
+
+// Check 检查 keys 是否允许继续尝试. 返回值大于 0 表示还需要等待的时间.
+func (g *Guard) Check(ctx context.Context, keys ...string) (time.Duration, error) {
+	var wait time.Duration
+	for _, key := range keys {
+		state, err := g.store.Get(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		if state.Failures == 0 {
+			continue
+		}
+
+		remaining := state.LastFailure.Add(g.policy.wait(state.Failures)).Sub(g.now())
+		wait = max(wait, remaining)
+	}
+	return wait, nil
+}
+
+// Fail 为 keys 记录一次失败.
+func (g *Guard) Fail(ctx context.Context, keys ...string) error {
+	now := g.now()
+	for _, key := range keys {
+		if _, err := g.store.RecordFailure(ctx, key, now, g.policy.ttl()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset 清除 keys 的失败状态，用于尝试成功或管理员解锁.
+func (g *Guard) Reset(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := g.store.Reset(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGuard(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	g := New(NewMemoryStore(), Policy{Threshold: 4, BaseDelay: time.Second, MaxDelay: 10 * time.Second, LockoutDuration: time.Minute})
+	g.now = func() time.Time { return now }
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Minute}
+	for i, want := range expected {
+		if err := g.Fail(ctx, "user:foo", "ip:127.0.0.1"); err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+		wait, err := g.Check(ctx, "user:foo")
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if wait != want {
+			t.Errorf("after %d failures, Check() = %v, want %v", i+1, wait, want)
+		}
+	}
+
+	// 其他用户不受影响，但同一 IP 仍处于锁定状态
+	if wait, _ := g.Check(ctx, "user:bar"); wait != 0 {
+		t.Errorf("Check(user:bar) = %v, want 0", wait)
+	}
+	if wait, _ := g.Check(ctx, "user:bar", "ip:127.0.0.1"); wait != time.Minute {
+		t.Errorf("Check(user:bar, ip) = %v, want %v", wait, time.Minute)
+	}
+
+	// 锁定时间过后允许继续尝试
+	now = now.Add(time.Minute)
+	if wait, _ := g.Check(ctx, "user:foo"); wait > 0 {
+		t.Errorf("Check() after lockout = %v, want <= 0", wait)
+	}
+
+	if err := g.Reset(ctx, "user:foo"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if wait, _ := g.Check(ctx, "user:foo"); wait != 0 {
+		t.Errorf("Check() after Reset = %v, want 0", wait)
+	}
+}
@@ -0,0 +1,83 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval 表示每记录多少次失败清理一次过期状态.
+const sweepInterval = 1024
+
+// memoryEntry 是内存存储中的一条失败记录.
+type memoryEntry struct {
+	state    State
+	expireAt time.Time
+}
+
+// MemoryStore 是基于内存的 Store 实现，只适用于单实例部署.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+	writes  int
+}
+
+// 确保 *MemoryStore 实现了 Store 接口.
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore 创建一个 *MemoryStore 实例.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+// Get 返回 key 当前的失败状态.
+func (s *MemoryStore) Get(ctx context.Context, key string) (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expireAt) {
+		return State{}, nil
+	}
+	return entry.state, nil
+}
+
+// RecordFailure 记录一次失败.
+func (s *MemoryStore) RecordFailure(ctx context.Context, key string, now time.Time, ttl time.Duration) (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.writes++
+	if s.writes%sweepInterval == 0 {
+		s.sweep(now)
+	}
+
+	entry, ok := s.entries[key]
+	if !ok || now.After(entry.expireAt) {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+	entry.state.Failures++
+	entry.state.LastFailure = now
+	entry.expireAt = now.Add(ttl)
+
+	return entry.state, nil
+}
+
+// Reset 清除 key 的失败状态.
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep 清理已经过期的记录，避免大量不同的 key 导致内存持续增长.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expireAt) {
+			delete(s.entries, key)
+		}
+	}
+}
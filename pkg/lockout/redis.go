@@ -0,0 +1,70 @@
+package lockout
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// failuresField 是 Redis Hash 中保存失败次数的字段.
+	failuresField = "failures"
+	// lastFailureField 是 Redis Hash 中保存最近一次失败时间（Unix 毫秒）的字段.
+	lastFailureField = "last"
+)
+
+// RedisStore 是基于 Redis 的 Store 实现，多实例部署时共享失败状态.
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// 确保 *RedisStore 实现了 Store 接口.
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore 创建一个 *RedisStore 实例，prefix 会添加到所有 key 之前.
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Get 返回 key 当前的失败状态.
+func (s *RedisStore) Get(ctx context.Context, key string) (State, error) {
+	values, err := s.client.HGetAll(ctx, s.prefix+key).Result()
+	if err != nil {
+		return State{}, err
+	}
+	return parseState(values), nil
+}
+
+// RecordFailure 在一个事务中增加失败次数、更新失败时间并刷新过期时间.
+func (s *RedisStore) RecordFailure(ctx context.Context, key string, now time.Time, ttl time.Duration) (State, error) {
+	var incr *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.HIncrBy(ctx, s.prefix+key, failuresField, 1)
+		pipe.HSet(ctx, s.prefix+key, lastFailureField, now.UnixMilli())
+		pipe.PExpire(ctx, s.prefix+key, ttl)
+		return nil
+	})
+	if err != nil {
+		return State{}, err
+	}
+
+	return State{Failures: incr.Val(), LastFailure: now}, nil
+}
+
+// Reset 清除 key 的失败状态.
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
+
+// parseState 将 Redis Hash 转换为 State.
+func parseState(values map[string]string) State {
+	failures, _ := strconv.ParseInt(values[failuresField], 10, 64)
+	last, _ := strconv.ParseInt(values[lastFailureField], 10, 64)
+	if failures == 0 {
+		return State{}
+	}
+	return State{Failures: failures, LastFailure: time.UnixMilli(last)}
+}
@@ -0,0 +1,40 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// FileMailer 将邮件写入目录中的 .eml 文件，适用于本地开发，不会真正发送邮件.
+type FileMailer struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+// 确保 *FileMailer 实现了 Mailer 接口.
+var _ Mailer = (*FileMailer)(nil)
+
+// NewFile 创建一个 *FileMailer 实例，邮件写入 dir 目录.
+func NewFile(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+// Send 将邮件写入文件.
+func (m *FileMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%d.eml", now.Format("20060102T150405.000000000"), m.seq.Add(1))
+	return os.WriteFile(filepath.Join(m.dir, name), encode(m.from, msg, now), 0o600)
+}
@@ -0,0 +1,55 @@
+// Package mail 提供发送邮件的抽象，以及 SMTP、文件和内存三种实现.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Message 表示一封纯文本邮件.
+type Message struct {
+	// To 表示收件人地址.
+	To []string
+	// Subject 表示邮件主题.
+	Subject string
+	// Body 表示纯文本邮件正文.
+	Body string
+}
+
+// Mailer 定义发送邮件的接口.
+type Mailer interface {
+	// Send 发送一封邮件.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// encode 将邮件编码为 RFC 5322 格式.
+func encode(from string, msg *Message, now time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return buf.Bytes()
+}
+
+// validate 校验邮件地址中不包含换行符，避免邮件头注入.
+func validate(msg *Message) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("mail: no recipients")
+	}
+	for _, s := range append([]string{msg.Subject}, msg.To...) {
+		if strings.ContainsAny(s, "\r\n") {
+			return fmt.Errorf("mail: header contains line break")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFile(dir, "miniblog <no-reply@miniblog.local>")
+
+	if err := m.Send(context.Background(), &Message{To: []string{"colin@miniblog.local"}, Subject: "Hello", Body: "line1\nline2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "To: colin@miniblog.local\r\n") || !strings.HasSuffix(string(data), "line1\r\nline2") {
+		t.Fatalf("unexpected mail content: %q", data)
+	}
+}
+
+func TestMemoryMailer(t *testing.T) {
+	m := NewMemory()
+
+	if err := m.Send(context.Background(), &Message{To: []string{"a@b.c\r\nBcc: evil@b.c"}, Subject: "x"}); err == nil {
+		t.Fatal("expected header injection to be rejected")
+	}
+	if err := m.Send(context.Background(), &Message{To: []string{"a@b.c"}, Subject: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Messages(); len(got) != 1 || got[0].To[0] != "a@b.c" {
+		t.Fatalf("Messages() = %v", got)
+	}
+}
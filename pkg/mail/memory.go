@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryMailer 将邮件保存在内存中，适用于测试.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// 确保 *MemoryMailer 实现了 Mailer 接口.
+var _ Mailer = (*MemoryMailer)(nil)
+
+// NewMemory 创建一个 *MemoryMailer 实例.
+func NewMemory() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send 将邮件保存在内存中.
+func (m *MemoryMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, *msg)
+	return nil
+}
+
+// Messages 返回已发送邮件的副本.
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
@@ -0,0 +1,63 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPConfig 定义 SMTP 服务器配置.
+type SMTPConfig struct {
+	// Host 表示 SMTP 服务器地址.
+	Host string
+	// Port 表示 SMTP 服务器端口.
+	Port int
+	// Username 表示认证用户名，为空表示不认证.
+	Username string
+	// Password 表示认证密码.
+	Password string
+	// From 表示发件人地址.
+	From string
+}
+
+// SMTPMailer 通过 SMTP 服务器发送邮件. 服务器支持 STARTTLS 时会自动启用加密.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// 确保 *SMTPMailer 实现了 Mailer 接口.
+var _ Mailer = (*SMTPMailer)(nil)
+
+// NewSMTP 创建一个 *SMTPMailer 实例.
+func NewSMTP(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send 发送一封邮件.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+
+	// smtp.SendMail 不支持 context，这里在单独的 goroutine 中发送，以便调用方可以取消等待
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- smtp.SendMail(addr, auth, m.cfg.From, msg.To, encode(m.cfg.From, msg, time.Now()))
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,70 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// AuditBackendNone 表示不记录审计日志.
+	AuditBackendNone = "none"
+	// AuditBackendDB 表示将审计事件写入数据库的 audit_event 表，可以通过 ListAuditEvents 接口查询.
+	AuditBackendDB = "db"
+	// AuditBackendFile 表示将审计事件以 JSON Lines 格式写入文件.
+	AuditBackendFile = "file"
+)
+
+var _ IOptions = (*AuditOptions)(nil)
+
+// AuditOptions defines options for the audit log of mutating operations.
+type AuditOptions struct {
+	// Backend 定义审计事件的存储后端，可选值：none、db、file.
+	Backend string `json:"backend" mapstructure:"backend"`
+	// File 定义 file 后端写入的文件路径.
+	File string `json:"file" mapstructure:"file"`
+	// BufferSize 定义等待写入的审计事件缓冲区大小，缓冲区满时丢弃新的事件.
+	BufferSize int `json:"buffer-size" mapstructure:"buffer-size"`
+}
+
+// NewAuditOptions create a `zero` value instance.
+func NewAuditOptions() *AuditOptions {
+	return &AuditOptions{
+		Backend:    AuditBackendDB,
+		File:       "audit.log",
+		BufferSize: 1024,
+	}
+}
+
+// Validate verifies flags passed to AuditOptions.
+func (o *AuditOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Backend {
+	case AuditBackendNone, AuditBackendDB:
+	case AuditBackendFile:
+		if o.File == "" {
+			errs = append(errs, fmt.Errorf("--audit.file cannot be empty when audit backend is file"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid audit backend %q: must be one of [%s %s %s]", o.Backend, AuditBackendNone, AuditBackendDB, AuditBackendFile))
+	}
+
+	if o.BufferSize <= 0 {
+		errs = append(errs, fmt.Errorf("--audit.buffer-size must be greater than 0"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to the audit log for a specific APIServer to the specified FlagSet.
+func (o *AuditOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Backend, fullPrefix+".backend", o.Backend, "Storage backend for audit events of mutating operations, available options: [none db file].")
+	fs.StringVar(&o.File, fullPrefix+".file", o.File, "Path of the JSON Lines file audit events are appended to when audit backend is file.")
+	fs.IntVar(&o.BufferSize, fullPrefix+".buffer-size", o.BufferSize, "Number of audit events buffered before they are written. Events are dropped when the buffer is full.")
+}
+
+// Enabled 表示是否记录审计日志.
+func (o *AuditOptions) Enabled() bool {
+	return o.Backend != AuditBackendNone
+}
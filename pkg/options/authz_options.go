@@ -0,0 +1,86 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/authz"
+)
+
+const (
+	// AuthzWatcherNone 表示不同步策略变更，定期重新加载全部策略.
+	AuthzWatcherNone = "none"
+	// AuthzWatcherDB 表示通过数据库中的版本行同步策略变更，不依赖额外的基础设施.
+	AuthzWatcherDB = "db"
+	// AuthzWatcherRedis 表示通过 Redis 发布/订阅同步策略变更.
+	AuthzWatcherRedis = "redis"
+)
+
+// AuthzSyncModeNone 表示启动时不同步策略包.
+const AuthzSyncModeNone = "none"
+
+var _ IOptions = (*AuthzOptions)(nil)
+
+// AuthzOptions defines options for synchronizing casbin policies across instances.
+type AuthzOptions struct {
+	// Watcher 定义策略变更的同步方式，可选值：none、db、redis.
+	Watcher string `json:"watcher" mapstructure:"watcher"`
+	// PollInterval 定义 db 方式下检查版本行的间隔，以及 none 方式下重新加载全部策略的间隔.
+	PollInterval time.Duration `json:"poll-interval" mapstructure:"poll-interval"`
+	// Channel 定义 redis 方式下发布策略变更的频道.
+	Channel string `json:"channel" mapstructure:"channel"`
+	// PolicyFile 定义启动时同步的 YAML 或 CSV 策略包路径，为空时只同步内置策略.
+	PolicyFile string `json:"policy-file" mapstructure:"policy-file"`
+	// SyncMode 定义启动时同步策略的方式，可选值：none、check、additive、authoritative.
+	// 除 none 外，数据库中没有任何策略时总是写入内置策略和策略包.
+	SyncMode string `json:"sync-mode" mapstructure:"sync-mode"`
+}
+
+// NewAuthzOptions create a `zero` value instance.
+func NewAuthzOptions() *AuthzOptions {
+	return &AuthzOptions{
+		Watcher:      AuthzWatcherDB,
+		PollInterval: time.Second,
+		Channel:      "miniblog:casbin",
+		SyncMode:     authz.SyncModeCheck,
+	}
+}
+
+// Validate verifies flags passed to AuthzOptions.
+func (o *AuthzOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Watcher {
+	case AuthzWatcherNone, AuthzWatcherDB:
+	case AuthzWatcherRedis:
+		if o.Channel == "" {
+			errs = append(errs, fmt.Errorf("--authz.channel cannot be empty when authz watcher is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid authz watcher %q: must be one of [%s %s %s]", o.Watcher, AuthzWatcherNone, AuthzWatcherDB, AuthzWatcherRedis))
+	}
+
+	switch o.SyncMode {
+	case AuthzSyncModeNone, authz.SyncModeCheck, authz.SyncModeAdditive, authz.SyncModeAuthoritative:
+	default:
+		errs = append(errs, fmt.Errorf("invalid authz sync mode %q: must be one of [%s %s %s %s]", o.SyncMode,
+			AuthzSyncModeNone, authz.SyncModeCheck, authz.SyncModeAdditive, authz.SyncModeAuthoritative))
+	}
+
+	if o.PollInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--authz.poll-interval must be greater than 0"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to casbin policy synchronization for a specific APIServer to the specified FlagSet.
+func (o *AuthzOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Watcher, fullPrefix+".watcher", o.Watcher, "How policy changes are propagated across instances, available options: [none db redis].")
+	fs.DurationVar(&o.PollInterval, fullPrefix+".poll-interval", o.PollInterval, "Interval for checking the policy version row (db watcher) or reloading all policies (none).")
+	fs.StringVar(&o.Channel, fullPrefix+".channel", o.Channel, "Redis pub/sub channel used to broadcast policy changes (redis watcher).")
+	fs.StringVar(&o.PolicyFile, fullPrefix+".policy-file", o.PolicyFile, "Path to a YAML or CSV policy bundle synced into the database on startup.")
+	fs.StringVar(&o.SyncMode, fullPrefix+".sync-mode", o.SyncMode, "How built-in policies and the policy bundle are synced on startup, available options: [none check additive authoritative]. Unless none, policies are always seeded into an empty database.")
+}
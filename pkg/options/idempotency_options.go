@@ -0,0 +1,63 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// IdempotencyBackendNone 表示忽略幂等键.
+	IdempotencyBackendNone = "none"
+	// IdempotencyBackendMemory 表示在进程内存中保存幂等记录，只适用于单实例部署.
+	IdempotencyBackendMemory = "memory"
+	// IdempotencyBackendRedis 表示在 Redis 中保存幂等记录，适用于多实例部署.
+	IdempotencyBackendRedis = "redis"
+)
+
+var _ IOptions = (*IdempotencyOptions)(nil)
+
+// IdempotencyOptions defines options for idempotency keys of non-idempotent requests.
+type IdempotencyOptions struct {
+	// Backend 定义幂等记录的存储后端，可选值：none、memory、redis.
+	Backend string `json:"backend" mapstructure:"backend"`
+	// TTL 定义第一次请求的响应的保留时间，超过该时间后同一个幂等键会被当作新的请求.
+	TTL time.Duration `json:"ttl" mapstructure:"ttl"`
+}
+
+// NewIdempotencyOptions create a `zero` value instance.
+func NewIdempotencyOptions() *IdempotencyOptions {
+	return &IdempotencyOptions{
+		Backend: IdempotencyBackendMemory,
+		TTL:     24 * time.Hour,
+	}
+}
+
+// Validate verifies flags passed to IdempotencyOptions.
+func (o *IdempotencyOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Backend {
+	case IdempotencyBackendNone, IdempotencyBackendMemory, IdempotencyBackendRedis:
+	default:
+		errs = append(errs, fmt.Errorf("invalid idempotency backend %q: must be one of [%s %s %s]", o.Backend, IdempotencyBackendNone, IdempotencyBackendMemory, IdempotencyBackendRedis))
+	}
+
+	if o.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("--idempotency.ttl must be greater than 0"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to idempotency keys for a specific APIServer to the specified FlagSet.
+func (o *IdempotencyOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Backend, fullPrefix+".backend", o.Backend, "Storage backend for responses of requests carrying an Idempotency-Key, available options: [none memory redis].")
+	fs.DurationVar(&o.TTL, fullPrefix+".ttl", o.TTL, "Duration the first response of an idempotency key is kept and replayed to retries.")
+}
+
+// Enabled 表示是否支持幂等键.
+func (o *IdempotencyOptions) Enabled() bool {
+	return o.Backend != IdempotencyBackendNone
+}
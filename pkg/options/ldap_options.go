@@ -0,0 +1,103 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/authenticator"
+)
+
+var _ IOptions = (*LDAPOptions)(nil)
+
+// LDAPOptions defines options for ldap authentication.
+type LDAPOptions struct {
+	// URL 定义 LDAP 服务地址，为空表示不启用 LDAP 认证.
+	URL                string        `json:"url" mapstructure:"url"`
+	BindDN             string        `json:"bind-dn" mapstructure:"bind-dn"`
+	BindPassword       string        `json:"-" mapstructure:"bind-password"`
+	BaseDN             string        `json:"base-dn" mapstructure:"base-dn"`
+	UserFilter         string        `json:"user-filter" mapstructure:"user-filter"`
+	IDAttribute        string        `json:"id-attribute" mapstructure:"id-attribute"`
+	UsernameAttribute  string        `json:"username-attribute" mapstructure:"username-attribute"`
+	NicknameAttribute  string        `json:"nickname-attribute" mapstructure:"nickname-attribute"`
+	EmailAttribute     string        `json:"email-attribute" mapstructure:"email-attribute"`
+	PhoneAttribute     string        `json:"phone-attribute" mapstructure:"phone-attribute"`
+	StartTLS           bool          `json:"start-tls" mapstructure:"start-tls"`
+	InsecureSkipVerify bool          `json:"insecure-skip-verify" mapstructure:"insecure-skip-verify"`
+	Timeout            time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// NewLDAPOptions create a `zero` value instance.
+func NewLDAPOptions() *LDAPOptions {
+	return &LDAPOptions{
+		UserFilter:        "(uid=%s)",
+		UsernameAttribute: "uid",
+		NicknameAttribute: "cn",
+		EmailAttribute:    "mail",
+		PhoneAttribute:    "mobile",
+		Timeout:           10 * time.Second,
+	}
+}
+
+// Enabled 返回是否启用了 LDAP 认证.
+func (o *LDAPOptions) Enabled() bool {
+	return o.URL != ""
+}
+
+// Validate verifies flags passed to LDAPOptions.
+func (o *LDAPOptions) Validate() []error {
+	errs := []error{}
+
+	if !o.Enabled() {
+		return errs
+	}
+
+	if o.BaseDN == "" {
+		errs = append(errs, fmt.Errorf("--ldap.base-dn is required when ldap authentication is enabled"))
+	}
+
+	if strings.Count(o.UserFilter, "%s") != 1 {
+		errs = append(errs, fmt.Errorf("--ldap.user-filter must contain exactly one %%s placeholder"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to ldap authentication for a specific APIServer to the specified FlagSet.
+func (o *LDAPOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.URL, fullPrefix+".url", o.URL, "LDAP server URL, e.g. ldap://127.0.0.1:389. LDAP authentication is disabled if empty.")
+	fs.StringVar(&o.BindDN, fullPrefix+".bind-dn", o.BindDN, "DN of the service account used to search users. Anonymous search is used if empty.")
+	fs.StringVar(&o.BindPassword, fullPrefix+".bind-password", o.BindPassword, "Password of the service account.")
+	fs.StringVar(&o.BaseDN, fullPrefix+".base-dn", o.BaseDN, "Base DN to search users from.")
+	fs.StringVar(&o.UserFilter, fullPrefix+".user-filter", o.UserFilter, "Filter used to search users, %s is replaced by the escaped username.")
+	fs.StringVar(&o.IDAttribute, fullPrefix+".id-attribute", o.IDAttribute, "Attribute holding a stable unique user ID, e.g. entryUUID. The user DN is used if empty.")
+	fs.StringVar(&o.UsernameAttribute, fullPrefix+".username-attribute", o.UsernameAttribute, "Attribute holding the username.")
+	fs.StringVar(&o.NicknameAttribute, fullPrefix+".nickname-attribute", o.NicknameAttribute, "Attribute holding the display name.")
+	fs.StringVar(&o.EmailAttribute, fullPrefix+".email-attribute", o.EmailAttribute, "Attribute holding the email address.")
+	fs.StringVar(&o.PhoneAttribute, fullPrefix+".phone-attribute", o.PhoneAttribute, "Attribute holding the mobile phone number.")
+	fs.BoolVar(&o.StartTLS, fullPrefix+".start-tls", o.StartTLS, "Use StartTLS on ldap:// connections.")
+	fs.BoolVar(&o.InsecureSkipVerify, fullPrefix+".insecure-skip-verify", o.InsecureSkipVerify, "Skip verification of the LDAP server certificate. Only use it for testing.")
+	fs.DurationVar(&o.Timeout, fullPrefix+".timeout", o.Timeout, "Timeout of LDAP connections and requests.")
+}
+
+// NewAuthenticator create a ldap authenticator with the given config.
+func (o *LDAPOptions) NewAuthenticator() *authenticator.LDAPAuthenticator {
+	return authenticator.NewLDAP(&authenticator.LDAPConfig{
+		URL:                o.URL,
+		BindDN:             o.BindDN,
+		BindPassword:       o.BindPassword,
+		BaseDN:             o.BaseDN,
+		UserFilter:         o.UserFilter,
+		IDAttribute:        o.IDAttribute,
+		UsernameAttribute:  o.UsernameAttribute,
+		NicknameAttribute:  o.NicknameAttribute,
+		EmailAttribute:     o.EmailAttribute,
+		PhoneAttribute:     o.PhoneAttribute,
+		StartTLS:           o.StartTLS,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+		Timeout:            o.Timeout,
+	})
+}
@@ -0,0 +1,82 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/lockout"
+)
+
+const (
+	// LockoutBackendMemory 表示在进程内存中记录失败次数，只适用于单实例部署.
+	LockoutBackendMemory = "memory"
+	// LockoutBackendRedis 表示在 Redis 中记录失败次数，适用于多实例部署.
+	LockoutBackendRedis = "redis"
+)
+
+var _ IOptions = (*LockoutOptions)(nil)
+
+// LockoutOptions defines options for login brute-force protection.
+type LockoutOptions struct {
+	// Backend 定义失败次数的存储后端，可选值：memory、redis.
+	Backend string `json:"backend" mapstructure:"backend"`
+	// Threshold 定义触发临时锁定的连续失败次数，小于等于 0 表示只退避不锁定.
+	Threshold int64 `json:"threshold" mapstructure:"threshold"`
+	// BaseDelay 定义首次失败后的退避时间，之后每次失败翻倍.
+	BaseDelay time.Duration `json:"base-delay" mapstructure:"base-delay"`
+	// MaxDelay 定义退避时间的上限.
+	MaxDelay time.Duration `json:"max-delay" mapstructure:"max-delay"`
+	// LockoutDuration 定义锁定时长.
+	LockoutDuration time.Duration `json:"lockout-duration" mapstructure:"lockout-duration"`
+}
+
+// NewLockoutOptions create a `zero` value instance.
+func NewLockoutOptions() *LockoutOptions {
+	return &LockoutOptions{
+		Backend:         LockoutBackendMemory,
+		Threshold:       5,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		LockoutDuration: 15 * time.Minute,
+	}
+}
+
+// Validate verifies flags passed to LockoutOptions.
+func (o *LockoutOptions) Validate() []error {
+	errs := []error{}
+
+	if o.Backend != LockoutBackendMemory && o.Backend != LockoutBackendRedis {
+		errs = append(errs, fmt.Errorf("invalid lockout backend %q: must be one of [%s %s]", o.Backend, LockoutBackendMemory, LockoutBackendRedis))
+	}
+
+	if o.BaseDelay < 0 || o.MaxDelay < o.BaseDelay {
+		errs = append(errs, fmt.Errorf("--lockout.max-delay must be greater than or equal to --lockout.base-delay"))
+	}
+
+	if o.Threshold > 0 && o.LockoutDuration <= 0 {
+		errs = append(errs, fmt.Errorf("--lockout.lockout-duration must be greater than 0 when lockout threshold is set"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to login lockout for a specific APIServer to the specified FlagSet.
+func (o *LockoutOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Backend, fullPrefix+".backend", o.Backend, "Storage backend for failed login attempts, available options: [memory redis].")
+	fs.Int64Var(&o.Threshold, fullPrefix+".threshold", o.Threshold, "Number of consecutive failed logins before the account or client IP is temporarily locked. 0 disables lockout.")
+	fs.DurationVar(&o.BaseDelay, fullPrefix+".base-delay", o.BaseDelay, "Backoff delay after the first failed login, doubled on each subsequent failure.")
+	fs.DurationVar(&o.MaxDelay, fullPrefix+".max-delay", o.MaxDelay, "Maximum backoff delay between failed logins.")
+	fs.DurationVar(&o.LockoutDuration, fullPrefix+".lockout-duration", o.LockoutDuration, "Duration of the temporary lockout.")
+}
+
+// Policy 返回 LockoutOptions 对应的退避和锁定策略.
+func (o *LockoutOptions) Policy() lockout.Policy {
+	return lockout.Policy{
+		Threshold:       o.Threshold,
+		BaseDelay:       o.BaseDelay,
+		MaxDelay:        o.MaxDelay,
+		LockoutDuration: o.LockoutDuration,
+	}
+}
@@ -0,0 +1,102 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/mail"
+)
+
+const (
+	// MailBackendSMTP 表示通过 SMTP 服务器发送邮件.
+	MailBackendSMTP = "smtp"
+	// MailBackendFile 表示将邮件写入本地目录，适用于本地开发.
+	MailBackendFile = "file"
+	// MailBackendMemory 表示将邮件保存在内存中，适用于测试.
+	MailBackendMemory = "memory"
+)
+
+var _ IOptions = (*MailOptions)(nil)
+
+// MailOptions defines options for sending emails.
+type MailOptions struct {
+	// Backend 定义邮件发送方式，可选值：smtp、file、memory.
+	Backend string `json:"backend" mapstructure:"backend"`
+	// From 定义发件人地址.
+	From string `json:"from" mapstructure:"from"`
+	// Host 定义 SMTP 服务器地址.
+	Host string `json:"host" mapstructure:"host"`
+	// Port 定义 SMTP 服务器端口.
+	Port int `json:"port" mapstructure:"port"`
+	// Username 定义 SMTP 认证用户名，为空表示不认证.
+	Username string `json:"username" mapstructure:"username"`
+	// Password 定义 SMTP 认证密码.
+	Password string `json:"-" mapstructure:"password"`
+	// Dir 定义 file 方式下邮件写入的目录.
+	Dir string `json:"dir" mapstructure:"dir"`
+}
+
+// NewMailOptions create a `zero` value instance.
+func NewMailOptions() *MailOptions {
+	return &MailOptions{
+		Backend: MailBackendFile,
+		From:    "miniblog <no-reply@miniblog.local>",
+		Port:    587,
+		Dir:     "_output/mail",
+	}
+}
+
+// Validate verifies flags passed to MailOptions.
+func (o *MailOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Backend {
+	case MailBackendSMTP:
+		if o.Host == "" || o.Port <= 0 {
+			errs = append(errs, fmt.Errorf("--mail.host and --mail.port must be specified when mail backend is smtp"))
+		}
+	case MailBackendFile:
+		if o.Dir == "" {
+			errs = append(errs, fmt.Errorf("--mail.dir must be specified when mail backend is file"))
+		}
+	case MailBackendMemory:
+	default:
+		errs = append(errs, fmt.Errorf("invalid mail backend %q: must be one of [%s %s %s]", o.Backend, MailBackendSMTP, MailBackendFile, MailBackendMemory))
+	}
+
+	if o.From == "" {
+		errs = append(errs, fmt.Errorf("--mail.from cannot be empty"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to mail for a specific APIServer to the specified FlagSet.
+func (o *MailOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Backend, fullPrefix+".backend", o.Backend, "Mail backend, available options: [smtp file memory].")
+	fs.StringVar(&o.From, fullPrefix+".from", o.From, "Sender address of outgoing emails.")
+	fs.StringVar(&o.Host, fullPrefix+".host", o.Host, "SMTP server host.")
+	fs.IntVar(&o.Port, fullPrefix+".port", o.Port, "SMTP server port.")
+	fs.StringVar(&o.Username, fullPrefix+".username", o.Username, "Username for SMTP authentication. Empty disables authentication.")
+	fs.StringVar(&o.Password, fullPrefix+".password", o.Password, "Password for SMTP authentication.")
+	fs.StringVar(&o.Dir, fullPrefix+".dir", o.Dir, "Directory to write emails to when mail backend is file.")
+}
+
+// NewMailer 根据配置创建一个 mail.Mailer 实例.
+func (o *MailOptions) NewMailer() mail.Mailer {
+	switch o.Backend {
+	case MailBackendSMTP:
+		return mail.NewSMTP(mail.SMTPConfig{
+			Host:     o.Host,
+			Port:     o.Port,
+			Username: o.Username,
+			Password: o.Password,
+			From:     o.From,
+		})
+	case MailBackendMemory:
+		return mail.NewMemory()
+	default:
+		return mail.NewFile(o.Dir, o.From)
+	}
+}
@@ -0,0 +1,69 @@
+package options
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/authenticator"
+)
+
+var _ IOptions = (*OIDCOptions)(nil)
+
+// OIDCOptions defines options for openid connect authentication.
+type OIDCOptions struct {
+	// Issuer 定义 OIDC 提供方地址，为空表示不启用 OIDC 认证.
+	Issuer       string   `json:"issuer" mapstructure:"issuer"`
+	ClientID     string   `json:"client-id" mapstructure:"client-id"`
+	ClientSecret string   `json:"-" mapstructure:"client-secret"`
+	RedirectURL  string   `json:"redirect-url" mapstructure:"redirect-url"`
+	Scopes       []string `json:"scopes" mapstructure:"scopes"`
+}
+
+// NewOIDCOptions create a `zero` value instance.
+func NewOIDCOptions() *OIDCOptions {
+	return &OIDCOptions{
+		Scopes: []string{"profile", "email"},
+	}
+}
+
+// Enabled 返回是否启用了 OIDC 认证.
+func (o *OIDCOptions) Enabled() bool {
+	return o.Issuer != ""
+}
+
+// Validate verifies flags passed to OIDCOptions.
+func (o *OIDCOptions) Validate() []error {
+	errs := []error{}
+
+	if !o.Enabled() {
+		return errs
+	}
+
+	if o.ClientID == "" || o.RedirectURL == "" {
+		errs = append(errs, fmt.Errorf("--oidc.client-id and --oidc.redirect-url are required when oidc authentication is enabled"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to oidc authentication for a specific APIServer to the specified FlagSet.
+func (o *OIDCOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Issuer, fullPrefix+".issuer", o.Issuer, "OIDC issuer URL used for discovery. OIDC authentication is disabled if empty.")
+	fs.StringVar(&o.ClientID, fullPrefix+".client-id", o.ClientID, "OIDC client ID.")
+	fs.StringVar(&o.ClientSecret, fullPrefix+".client-secret", o.ClientSecret, "OIDC client secret.")
+	fs.StringVar(&o.RedirectURL, fullPrefix+".redirect-url", o.RedirectURL, "OIDC redirect URL, e.g. https://miniblog.example.com/login/oidc/callback.")
+	fs.StringSliceVar(&o.Scopes, fullPrefix+".scopes", o.Scopes, "Additional OIDC scopes to request. The openid scope is always requested.")
+}
+
+// NewProvider create an oidc provider with the given config.
+func (o *OIDCOptions) NewProvider(ctx context.Context) (*authenticator.OIDCProvider, error) {
+	return authenticator.NewOIDC(ctx, &authenticator.OIDCConfig{
+		Issuer:       o.Issuer,
+		ClientID:     o.ClientID,
+		ClientSecret: o.ClientSecret,
+		RedirectURL:  o.RedirectURL,
+		Scopes:       o.Scopes,
+	})
+}
@@ -0,0 +1,178 @@
+package options
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/bcrypt"
+
+	"miniblog/pkg/authn"
+)
+
+var _ IOptions = (*PasswordOptions)(nil)
+
+// PasswordOptions defines options for password hashing and password policy.
+type PasswordOptions struct {
+	// Algorithm 定义新密码使用的哈希算法，可选值：argon2id、bcrypt. 已有的哈希无论使用哪种算法都能校验.
+	Algorithm string `json:"algorithm" mapstructure:"algorithm"`
+	// BcryptCost 定义 bcrypt 的计算成本.
+	BcryptCost int `json:"bcrypt-cost" mapstructure:"bcrypt-cost"`
+	// Argon2Memory 定义 argon2id 使用的内存大小，单位 KiB.
+	Argon2Memory uint32 `json:"argon2-memory" mapstructure:"argon2-memory"`
+	// Argon2Iterations 定义 argon2id 的迭代次数.
+	Argon2Iterations uint32 `json:"argon2-iterations" mapstructure:"argon2-iterations"`
+	// Argon2Parallelism 定义 argon2id 的并行度.
+	Argon2Parallelism uint8 `json:"argon2-parallelism" mapstructure:"argon2-parallelism"`
+	// Argon2SaltLength 定义 argon2id 随机盐的长度，单位字节.
+	Argon2SaltLength uint32 `json:"argon2-salt-length" mapstructure:"argon2-salt-length"`
+	// Argon2KeyLength 定义 argon2id 生成的哈希长度，单位字节.
+	Argon2KeyLength uint32 `json:"argon2-key-length" mapstructure:"argon2-key-length"`
+
+	// MinLength 定义密码的最小长度.
+	MinLength int `json:"min-length" mapstructure:"min-length"`
+	// MaxLength 定义密码的最大长度，0 表示不限制.
+	MaxLength int `json:"max-length" mapstructure:"max-length"`
+	// RequireLetter 定义密码是否必须包含字母.
+	RequireLetter bool `json:"require-letter" mapstructure:"require-letter"`
+	// RequireUpper 定义密码是否必须包含大写字母.
+	RequireUpper bool `json:"require-upper" mapstructure:"require-upper"`
+	// RequireLower 定义密码是否必须包含小写字母.
+	RequireLower bool `json:"require-lower" mapstructure:"require-lower"`
+	// RequireDigit 定义密码是否必须包含数字.
+	RequireDigit bool `json:"require-digit" mapstructure:"require-digit"`
+	// RequireSpecial 定义密码是否必须包含特殊字符.
+	RequireSpecial bool `json:"require-special" mapstructure:"require-special"`
+	// DenyList 定义禁止使用的常见密码，比较时忽略大小写.
+	DenyList []string `json:"deny-list" mapstructure:"deny-list"`
+	// DenyListFile 定义禁止使用的常见密码文件，每行一个密码，与 DenyList 合并使用.
+	DenyListFile string `json:"deny-list-file" mapstructure:"deny-list-file"`
+}
+
+// NewPasswordOptions create a `zero` value instance.
+func NewPasswordOptions() *PasswordOptions {
+	policy := authn.DefaultPasswordPolicy()
+	params := authn.DefaultHashConfig.Argon2id
+
+	return &PasswordOptions{
+		Algorithm:         authn.DefaultHashConfig.Algorithm,
+		BcryptCost:        authn.DefaultHashConfig.BcryptCost,
+		Argon2Memory:      params.Memory,
+		Argon2Iterations:  params.Iterations,
+		Argon2Parallelism: params.Parallelism,
+		Argon2SaltLength:  params.SaltLength,
+		Argon2KeyLength:   params.KeyLength,
+		MinLength:         policy.MinLength,
+		MaxLength:         policy.MaxLength,
+		RequireLetter:     policy.RequireLetter,
+		RequireUpper:      policy.RequireUpper,
+		RequireLower:      policy.RequireLower,
+		RequireDigit:      policy.RequireDigit,
+		RequireSpecial:    policy.RequireSpecial,
+		DenyList:          policy.DenyList,
+	}
+}
+
+// Validate verifies flags passed to PasswordOptions.
+func (o *PasswordOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Algorithm {
+	case authn.AlgorithmArgon2id:
+		if o.Argon2Memory < 8*uint32(o.Argon2Parallelism) || o.Argon2Iterations < 1 || o.Argon2Parallelism < 1 {
+			errs = append(errs, fmt.Errorf("invalid argon2id parameters: memory must be at least 8*parallelism KiB, iterations and parallelism must be greater than 0"))
+		}
+		if o.Argon2SaltLength < 8 || o.Argon2KeyLength < 16 {
+			errs = append(errs, fmt.Errorf("--password.argon2-salt-length must be at least 8 and --password.argon2-key-length at least 16"))
+		}
+	case authn.AlgorithmBcrypt:
+		if o.BcryptCost < bcrypt.MinCost || o.BcryptCost > bcrypt.MaxCost {
+			errs = append(errs, fmt.Errorf("--password.bcrypt-cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid password algorithm %q: must be one of [%s %s]", o.Algorithm, authn.AlgorithmArgon2id, authn.AlgorithmBcrypt))
+	}
+
+	if o.MinLength < 1 || (o.MaxLength > 0 && o.MaxLength < o.MinLength) {
+		errs = append(errs, fmt.Errorf("--password.min-length must be greater than 0 and not greater than --password.max-length"))
+	}
+
+	if o.DenyListFile != "" {
+		if _, err := os.Stat(o.DenyListFile); err != nil {
+			errs = append(errs, fmt.Errorf("invalid --password.deny-list-file: %w", err))
+		}
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to password hashing and policy for a specific APIServer to the specified FlagSet.
+func (o *PasswordOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Algorithm, fullPrefix+".algorithm", o.Algorithm, "Hash algorithm for new passwords, available options: [argon2id bcrypt]. Existing hashes are rehashed on the next successful login.")
+	fs.IntVar(&o.BcryptCost, fullPrefix+".bcrypt-cost", o.BcryptCost, "Cost factor of bcrypt.")
+	fs.Uint32Var(&o.Argon2Memory, fullPrefix+".argon2-memory", o.Argon2Memory, "Memory used by argon2id in KiB.")
+	fs.Uint32Var(&o.Argon2Iterations, fullPrefix+".argon2-iterations", o.Argon2Iterations, "Number of argon2id iterations.")
+	fs.Uint8Var(&o.Argon2Parallelism, fullPrefix+".argon2-parallelism", o.Argon2Parallelism, "Degree of parallelism of argon2id.")
+	fs.Uint32Var(&o.Argon2SaltLength, fullPrefix+".argon2-salt-length", o.Argon2SaltLength, "Length of the argon2id salt in bytes.")
+	fs.Uint32Var(&o.Argon2KeyLength, fullPrefix+".argon2-key-length", o.Argon2KeyLength, "Length of the argon2id hash in bytes.")
+	fs.IntVar(&o.MinLength, fullPrefix+".min-length", o.MinLength, "Minimum password length.")
+	fs.IntVar(&o.MaxLength, fullPrefix+".max-length", o.MaxLength, "Maximum password length. 0 means no limit.")
+	fs.BoolVar(&o.RequireLetter, fullPrefix+".require-letter", o.RequireLetter, "Require at least one letter in passwords.")
+	fs.BoolVar(&o.RequireUpper, fullPrefix+".require-upper", o.RequireUpper, "Require at least one upper case letter in passwords.")
+	fs.BoolVar(&o.RequireLower, fullPrefix+".require-lower", o.RequireLower, "Require at least one lower case letter in passwords.")
+	fs.BoolVar(&o.RequireDigit, fullPrefix+".require-digit", o.RequireDigit, "Require at least one digit in passwords.")
+	fs.BoolVar(&o.RequireSpecial, fullPrefix+".require-special", o.RequireSpecial, "Require at least one special character in passwords.")
+	fs.StringSliceVar(&o.DenyList, fullPrefix+".deny-list", o.DenyList, "Common passwords that are not allowed, compared case-insensitively.")
+	fs.StringVar(&o.DenyListFile, fullPrefix+".deny-list-file", o.DenyListFile, "Path to a file of passwords that are not allowed, one per line.")
+}
+
+// HashConfig 返回 PasswordOptions 对应的密码哈希配置.
+func (o *PasswordOptions) HashConfig() authn.HashConfig {
+	return authn.HashConfig{
+		Algorithm:  o.Algorithm,
+		BcryptCost: o.BcryptCost,
+		Argon2id: authn.Argon2idParams{
+			Memory:      o.Argon2Memory,
+			Iterations:  o.Argon2Iterations,
+			Parallelism: o.Argon2Parallelism,
+			SaltLength:  o.Argon2SaltLength,
+			KeyLength:   o.Argon2KeyLength,
+		},
+	}
+}
+
+// Policy 返回 PasswordOptions 对应的密码策略，会读取 DenyListFile 中的密码.
+func (o *PasswordOptions) Policy() (*authn.PasswordPolicy, error) {
+	denyList := append([]string{}, o.DenyList...)
+
+	if o.DenyListFile != "" {
+		f, err := os.Open(o.DenyListFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+				denyList = append(denyList, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &authn.PasswordPolicy{
+		MinLength:      o.MinLength,
+		MaxLength:      o.MaxLength,
+		RequireLetter:  o.RequireLetter,
+		RequireUpper:   o.RequireUpper,
+		RequireLower:   o.RequireLower,
+		RequireDigit:   o.RequireDigit,
+		RequireSpecial: o.RequireSpecial,
+		DenyList:       denyList,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/quota"
+)
+
+var _ IOptions = (*QuotaOptions)(nil)
+
+// QuotaOptions defines options for per-user content creation quotas.
+type QuotaOptions struct {
+	// DailyPosts 定义默认每天最多创建的博客数，小于等于 0 表示不限制.
+	DailyPosts int64 `json:"daily-posts" mapstructure:"daily-posts"`
+	// MaxStorage 定义默认每个用户的博客最多占用的字节数，小于等于 0 表示不限制.
+	MaxStorage int64 `json:"max-storage" mapstructure:"max-storage"`
+	// Roles 定义按角色配置的配额，格式为 "<角色>=<dailyPosts>:<maxStorage>".
+	Roles []string `json:"roles" mapstructure:"roles"`
+}
+
+// NewQuotaOptions create a `zero` value instance.
+func NewQuotaOptions() *QuotaOptions {
+	return &QuotaOptions{
+		DailyPosts: 50,
+		MaxStorage: 10 << 20,
+		Roles:      []string{"role::admin=0:0"},
+	}
+}
+
+// Validate verifies flags passed to QuotaOptions.
+func (o *QuotaOptions) Validate() []error {
+	errs := []error{}
+
+	if _, err := o.Plans(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to content quotas for a specific APIServer to the specified FlagSet.
+func (o *QuotaOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.Int64Var(&o.DailyPosts, fullPrefix+".daily-posts", o.DailyPosts, "Default maximum number of posts a user can create per day (UTC). 0 means unlimited.")
+	fs.Int64Var(&o.MaxStorage, fullPrefix+".max-storage", o.MaxStorage, "Default maximum number of bytes the titles and contents of a user's posts can take. 0 means unlimited.")
+	fs.StringSliceVar(&o.Roles, fullPrefix+".roles", o.Roles, "Per-role quotas in the format <role>=<dailyPosts>:<maxStorage>. "+
+		"A user with several configured roles gets the most generous value of each quota.")
+}
+
+// Plans 返回 QuotaOptions 对应的配额.
+func (o *QuotaOptions) Plans() (quota.Plans, error) {
+	plans := quota.Plans{
+		Default: quota.Limits{DailyPosts: o.DailyPosts, MaxStorage: o.MaxStorage},
+		Roles:   make(map[string]quota.Limits, len(o.Roles)),
+	}
+	for _, r := range o.Roles {
+		role, value, ok := strings.Cut(r, "=")
+		if !ok || strings.TrimSpace(role) == "" {
+			return quota.Plans{}, fmt.Errorf("invalid --quota.roles entry %q: must be in the format <role>=<dailyPosts>:<maxStorage>", r)
+		}
+		limits, err := quota.ParseLimits(strings.TrimSpace(value))
+		if err != nil {
+			return quota.Plans{}, err
+		}
+		plans.Roles[strings.TrimSpace(role)] = limits
+	}
+	return plans, nil
+}
@@ -0,0 +1,106 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/ratelimit"
+)
+
+const (
+	// RateLimitBackendNone 表示不限流.
+	RateLimitBackendNone = "none"
+	// RateLimitBackendMemory 表示在进程内存中保存令牌桶，只适用于单实例部署.
+	RateLimitBackendMemory = "memory"
+	// RateLimitBackendRedis 表示在 Redis 中保存令牌桶，适用于多实例部署.
+	RateLimitBackendRedis = "redis"
+)
+
+var _ IOptions = (*RateLimitOptions)(nil)
+
+// RateLimitOptions defines options for request rate limiting.
+type RateLimitOptions struct {
+	// Backend 定义令牌桶的存储后端，可选值：none、memory、redis.
+	Backend string `json:"backend" mapstructure:"backend"`
+	// Rate 定义默认规则每秒补充的令牌数，小于等于 0 表示默认不限流.
+	Rate float64 `json:"rate" mapstructure:"rate"`
+	// Burst 定义默认规则的令牌桶容量.
+	Burst int64 `json:"burst" mapstructure:"burst"`
+	// Methods 定义单独配置规则的方法，格式为 "<方法>=<rate>:<burst>".
+	// gRPC 方法使用方法全名，例如 "/v1.MiniBlog/Login"；Gin 路由使用 "<METHOD> <路由模板>"，例如 "POST /login".
+	Methods []string `json:"methods" mapstructure:"methods"`
+}
+
+// NewRateLimitOptions create a `zero` value instance.
+func NewRateLimitOptions() *RateLimitOptions {
+	return &RateLimitOptions{
+		Backend: RateLimitBackendMemory,
+		Rate:    20,
+		Burst:   40,
+		Methods: []string{
+			"/v1.MiniBlog/Login=0.2:5",
+			"POST /login=0.2:5",
+			"/v1.MiniBlog/CreatePost=0.5:10",
+			"POST /v1/posts=0.5:10",
+		},
+	}
+}
+
+// Validate verifies flags passed to RateLimitOptions.
+func (o *RateLimitOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Backend {
+	case RateLimitBackendNone, RateLimitBackendMemory, RateLimitBackendRedis:
+	default:
+		errs = append(errs, fmt.Errorf("invalid rate limit backend %q: must be one of [%s %s %s]", o.Backend, RateLimitBackendNone, RateLimitBackendMemory, RateLimitBackendRedis))
+	}
+
+	if o.Rate > 0 && o.Burst <= 0 {
+		errs = append(errs, fmt.Errorf("--ratelimit.burst must be greater than 0 when --ratelimit.rate is set"))
+	}
+
+	if _, err := o.Rules(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to rate limiting for a specific APIServer to the specified FlagSet.
+func (o *RateLimitOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Backend, fullPrefix+".backend", o.Backend, "Storage backend for rate limit token buckets, available options: [none memory redis].")
+	fs.Float64Var(&o.Rate, fullPrefix+".rate", o.Rate, "Default number of requests per second allowed for each user or client IP. 0 disables the default limit.")
+	fs.Int64Var(&o.Burst, fullPrefix+".burst", o.Burst, "Default maximum burst of requests allowed for each user or client IP.")
+	fs.StringSliceVar(&o.Methods, fullPrefix+".methods", o.Methods, "Per-method rate limits in the format <method>=<rate>:<burst>. "+
+		"The method is the full gRPC method name (e.g. /v1.MiniBlog/Login) or '<METHOD> <route>' for the gin server (e.g. POST /login).")
+}
+
+// Enabled 表示是否开启限流.
+func (o *RateLimitOptions) Enabled() bool {
+	return o.Backend != RateLimitBackendNone
+}
+
+// DefaultRule 返回默认的限流规则.
+func (o *RateLimitOptions) DefaultRule() ratelimit.Rule {
+	return ratelimit.Rule{Rate: o.Rate, Burst: o.Burst}
+}
+
+// Rules 解析单独配置了规则的方法.
+func (o *RateLimitOptions) Rules() (map[string]ratelimit.Rule, error) {
+	rules := make(map[string]ratelimit.Rule, len(o.Methods))
+	for _, m := range o.Methods {
+		method, value, ok := strings.Cut(m, "=")
+		if !ok || strings.TrimSpace(method) == "" {
+			return nil, fmt.Errorf("invalid --ratelimit.methods entry %q: must be in the format <method>=<rate>:<burst>", m)
+		}
+		rule, err := ratelimit.ParseRule(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		rules[strings.TrimSpace(method)] = rule
+	}
+	return rules, nil
+}
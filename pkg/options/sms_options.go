@@ -0,0 +1,81 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"miniblog/pkg/sms"
+)
+
+const (
+	// SMSBackendWebhook 表示通过 Webhook 调用短信网关发送短信.
+	SMSBackendWebhook = "webhook"
+	// SMSBackendFile 表示将短信写入本地目录，适用于本地开发.
+	SMSBackendFile = "file"
+	// SMSBackendMemory 表示将短信保存在内存中，适用于测试.
+	SMSBackendMemory = "memory"
+)
+
+var _ IOptions = (*SMSOptions)(nil)
+
+// SMSOptions defines options for sending SMS messages.
+type SMSOptions struct {
+	// Backend 定义短信发送方式，可选值：webhook、file、memory.
+	Backend string `json:"backend" mapstructure:"backend"`
+	// URL 定义 webhook 方式下短信网关的地址.
+	URL string `json:"url" mapstructure:"url"`
+	// Token 定义调用短信网关时使用的 Bearer 令牌.
+	Token string `json:"-" mapstructure:"token"`
+	// Dir 定义 file 方式下短信写入的目录.
+	Dir string `json:"dir" mapstructure:"dir"`
+}
+
+// NewSMSOptions create a `zero` value instance.
+func NewSMSOptions() *SMSOptions {
+	return &SMSOptions{
+		Backend: SMSBackendFile,
+		Dir:     "_output/sms",
+	}
+}
+
+// Validate verifies flags passed to SMSOptions.
+func (o *SMSOptions) Validate() []error {
+	errs := []error{}
+
+	switch o.Backend {
+	case SMSBackendWebhook:
+		if o.URL == "" {
+			errs = append(errs, fmt.Errorf("--sms.url must be specified when sms backend is webhook"))
+		}
+	case SMSBackendFile:
+		if o.Dir == "" {
+			errs = append(errs, fmt.Errorf("--sms.dir must be specified when sms backend is file"))
+		}
+	case SMSBackendMemory:
+	default:
+		errs = append(errs, fmt.Errorf("invalid sms backend %q: must be one of [%s %s %s]", o.Backend, SMSBackendWebhook, SMSBackendFile, SMSBackendMemory))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to SMS for a specific APIServer to the specified FlagSet.
+func (o *SMSOptions) AddFlags(fs *pflag.FlagSet, fullPrefix string) {
+	fs.StringVar(&o.Backend, fullPrefix+".backend", o.Backend, "SMS backend, available options: [webhook file memory].")
+	fs.StringVar(&o.URL, fullPrefix+".url", o.URL, "URL of the SMS gateway when sms backend is webhook.")
+	fs.StringVar(&o.Token, fullPrefix+".token", o.Token, "Bearer token used to call the SMS gateway.")
+	fs.StringVar(&o.Dir, fullPrefix+".dir", o.Dir, "Directory to write SMS messages to when sms backend is file.")
+}
+
+// NewSender 根据配置创建一个 sms.Sender 实例.
+func (o *SMSOptions) NewSender() sms.Sender {
+	switch o.Backend {
+	case SMSBackendWebhook:
+		return sms.NewWebhook(sms.WebhookConfig{URL: o.URL, Token: o.Token})
+	case SMSBackendMemory:
+		return sms.NewMemory()
+	default:
+		return sms.NewFile(o.Dir)
+	}
+}
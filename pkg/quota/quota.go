@@ -0,0 +1,91 @@
+// Package quota 定义按角色或套餐区分的业务配额，例如每天最多创建的博客数和最多占用的存储空间.
+package quota
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayLayout 是每日配额计数所属日期的格式.
+const dayLayout = "2006-01-02"
+
+// Limits 定义一组配额上限，小于等于 0 表示不限制.
+type Limits struct {
+	// DailyPosts 表示每天最多创建的博客数，按 UTC 日期计算，每天零点重置.
+	DailyPosts int64
+	// MaxStorage 表示博客标题和内容最多占用的字节数.
+	MaxStorage int64
+}
+
+// ParseLimits 解析 "<dailyPosts>:<maxStorage>" 格式的配额，例如 "20:10485760".
+func ParseLimits(s string) (Limits, error) {
+	posts, storage, ok := strings.Cut(s, ":")
+	if !ok {
+		return Limits{}, fmt.Errorf("invalid quota %q: must be in the format <dailyPosts>:<maxStorage>", s)
+	}
+
+	p, err := strconv.ParseInt(posts, 10, 64)
+	if err != nil {
+		return Limits{}, fmt.Errorf("invalid daily posts in quota %q: %w", s, err)
+	}
+	m, err := strconv.ParseInt(storage, 10, 64)
+	if err != nil {
+		return Limits{}, fmt.Errorf("invalid max storage in quota %q: %w", s, err)
+	}
+
+	return Limits{DailyPosts: p, MaxStorage: m}, nil
+}
+
+// Plans 定义默认配额以及按角色配置的配额.
+type Plans struct {
+	// Default 表示没有配置角色配额的用户使用的配额.
+	Default Limits
+	// Roles 表示按角色配置的配额，键为角色名，例如 role::admin.
+	Roles map[string]Limits
+}
+
+// Resolve 返回拥有 roles 的用户适用的配额. 用户有多个配置了配额的角色时，每一项配额分别取最宽松的值.
+func (p Plans) Resolve(roles []string) Limits {
+	var (
+		limits Limits
+		found  bool
+	)
+	for _, role := range roles {
+		l, ok := p.Roles[role]
+		if !ok {
+			continue
+		}
+		if !found {
+			limits, found = l, true
+			continue
+		}
+		limits.DailyPosts = looser(limits.DailyPosts, l.DailyPosts)
+		limits.MaxStorage = looser(limits.MaxStorage, l.MaxStorage)
+	}
+
+	if !found {
+		return p.Default
+	}
+	return limits
+}
+
+// looser 返回两个上限中更宽松的一个.
+func looser(a, b int64) int64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	return max(a, b)
+}
+
+// Day 返回 t 所属的每日配额周期，即 UTC 日期.
+func Day(t time.Time) string {
+	return t.UTC().Format(dayLayout)
+}
+
+// NextReset 返回 t 之后每日配额下一次重置的时间，即下一个 UTC 零点.
+func NextReset(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,43 @@
+package quota
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlansResolve(t *testing.T) {
+	plans := Plans{
+		Default: Limits{DailyPosts: 10, MaxStorage: 1024},
+		Roles: map[string]Limits{
+			"role::admin": {},
+			"role::vip":   {DailyPosts: 100, MaxStorage: 512},
+			"role::pro":   {DailyPosts: 50, MaxStorage: 4096},
+		},
+	}
+
+	tests := []struct {
+		roles []string
+		want  Limits
+	}{
+		{roles: nil, want: Limits{DailyPosts: 10, MaxStorage: 1024}},
+		{roles: []string{"role::user"}, want: Limits{DailyPosts: 10, MaxStorage: 1024}},
+		{roles: []string{"role::user", "role::vip"}, want: Limits{DailyPosts: 100, MaxStorage: 512}},
+		{roles: []string{"role::vip", "role::pro"}, want: Limits{DailyPosts: 100, MaxStorage: 4096}},
+		{roles: []string{"role::vip", "role::admin"}, want: Limits{}},
+	}
+	for _, tt := range tests {
+		if got := plans.Resolve(tt.roles); got != tt.want {
+			t.Errorf("Resolve(%v) = %+v, want %+v", tt.roles, got, tt.want)
+		}
+	}
+}
+
+func TestNextReset(t *testing.T) {
+	now := time.Date(2024, 12, 31, 23, 30, 0, 0, time.FixedZone("UTC+8", 8*3600))
+	if got, want := Day(now), "2024-12-31"; got != want {
+		t.Errorf("Day() = %s, want %s", got, want)
+	}
+	if got, want := NextReset(now), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("NextReset() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval 表示每取多少次令牌清理一次过期的令牌桶.
+const sweepInterval = 1024
+
+// bucket 是内存存储中的一个令牌桶.
+type bucket struct {
+	tokens   float64
+	last     time.Time
+	expireAt time.Time
+}
+
+// MemoryStore 是基于内存的 Store 实现，只适用于单实例部署.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+}
+
+// 确保 *MemoryStore 实现了 Store 接口.
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore 创建一个 *MemoryStore 实例.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take 从 keys 对应的每个令牌桶中各取出一个令牌，检查和取出在同一把锁内完成.
+func (s *MemoryStore) Take(ctx context.Context, keys []string, rule Rule, now time.Time) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.takes++
+	if s.takes%sweepInterval == 0 {
+		s.sweep(now)
+	}
+
+	buckets := make([]*bucket, 0, len(keys))
+	var wait time.Duration
+	for _, key := range keys {
+		b, ok := s.buckets[key]
+		if !ok {
+			b = &bucket{tokens: float64(rule.Burst), last: now}
+			s.buckets[key] = b
+		}
+
+		// 按经过的时间补充令牌，不超过令牌桶容量
+		if elapsed := now.Sub(b.last); elapsed > 0 {
+			b.tokens = min(float64(rule.Burst), b.tokens+elapsed.Seconds()*rule.Rate)
+			b.last = now
+		}
+		b.expireAt = now.Add(rule.ttl())
+
+		if b.tokens < 1 {
+			wait = max(wait, time.Duration((1-b.tokens)/rule.Rate*float64(time.Second)))
+		}
+		buckets = append(buckets, b)
+	}
+	if wait > 0 {
+		return wait, nil
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return 0, nil
+}
+
+// sweep 清理已经补满的令牌桶，避免大量不同的 key 导致内存持续增长.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.After(b.expireAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
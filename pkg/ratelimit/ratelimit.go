@@ -0,0 +1,95 @@
+// Package ratelimit 提供基于令牌桶的限流能力，可按用户、客户端 IP 等维度限制请求频率.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule 定义令牌桶的限流规则.
+type Rule struct {
+	// Rate 表示每秒补充的令牌数，小于等于 0 表示不限流.
+	Rate float64
+	// Burst 表示令牌桶的容量，即允许的突发请求数.
+	Burst int64
+}
+
+// ParseRule 解析 "<rate>:<burst>" 格式的限流规则，例如 "0.5:5" 表示每 2 秒补充 1 个令牌，最多允许 5 个突发请求.
+func ParseRule(s string) (Rule, error) {
+	rate, burst, ok := strings.Cut(s, ":")
+	if !ok {
+		return Rule{}, fmt.Errorf("invalid rate limit rule %q: must be in the format <rate>:<burst>", s)
+	}
+
+	r, err := strconv.ParseFloat(rate, 64)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rate in rate limit rule %q: %w", s, err)
+	}
+	b, err := strconv.ParseInt(burst, 10, 64)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid burst in rate limit rule %q: %w", s, err)
+	}
+	if r > 0 && b <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit rule %q: burst must be greater than 0", s)
+	}
+
+	return Rule{Rate: r, Burst: b}, nil
+}
+
+// unlimited 表示规则是否不限流.
+func (r Rule) unlimited() bool {
+	return r.Rate <= 0
+}
+
+// ttl 返回令牌桶从空到满所需的时间，超过该时间没有请求的令牌桶可以被清除.
+func (r Rule) ttl() time.Duration {
+	return time.Duration(float64(r.Burst) / r.Rate * float64(time.Second))
+}
+
+// Store 定义令牌桶的存储接口. 单实例部署可以使用内存存储，多实例部署需要使用 Redis 等共享存储.
+type Store interface {
+	// Take 原子地从 keys 对应的每个令牌桶中各取出一个令牌. 任一令牌桶的令牌不足时不取出任何令牌，
+	// 并返回需要等待的最长时间，避免被限流的请求消耗其他令牌桶中的令牌.
+	Take(ctx context.Context, keys []string, rule Rule, now time.Time) (time.Duration, error)
+}
+
+// Limiter 按方法和调用方维度限制请求频率.
+//
+// 在 rules 中单独配置了规则的方法，每个调用方单独使用一个令牌桶；
+// 其他方法使用默认规则，同一个调用方的所有请求共享一个令牌桶.
+type Limiter struct {
+	store       Store
+	defaultRule Rule
+	rules       map[string]Rule
+	now         func() time.Time
+}
+
+// New 创建一个 *Limiter 实例.
+func New(store Store, defaultRule Rule, rules map[string]Rule) *Limiter {
+	return &Limiter{store: store, defaultRule: defaultRule, rules: rules, now: time.Now}
+}
+
+// Allow 检查 keys 调用 method 是否超过限流规则，keys 通常为用户 ID、客户端 IP 等调用方标识.
+// 只有所有 keys 都没有超过限流规则时才会从各自的令牌桶中取出令牌.
+// 返回值大于 0 表示请求被限流，值为需要等待的时间.
+func (l *Limiter) Allow(ctx context.Context, method string, keys ...string) (time.Duration, error) {
+	rule, ok := l.rules[method]
+	if !ok {
+		rule = l.defaultRule
+	}
+	if rule.unlimited() {
+		return 0, nil
+	}
+
+	if ok {
+		scoped := make([]string, 0, len(keys))
+		for _, key := range keys {
+			scoped = append(scoped, method+"|"+key)
+		}
+		keys = scoped
+	}
+	return l.store.Take(ctx, keys, rule, l.now())
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	l := New(NewMemoryStore(), Rule{Rate: 10, Burst: 2}, map[string]Rule{"Login": {Rate: 1, Burst: 1}})
+	l.now = func() time.Time { return now }
+
+	// 默认规则允许 Burst 个突发请求
+	for i := 0; i < 2; i++ {
+		if wait, err := l.Allow(ctx, "GetPost", "user:foo"); err != nil || wait != 0 {
+			t.Fatalf("Allow() #%d = %v, %v, want 0, nil", i+1, wait, err)
+		}
+	}
+	if wait, _ := l.Allow(ctx, "ListPost", "user:foo"); wait != 100*time.Millisecond {
+		t.Errorf("Allow() after burst = %v, want %v", wait, 100*time.Millisecond)
+	}
+
+	// 单独配置了规则的方法使用独立的令牌桶
+	if wait, _ := l.Allow(ctx, "Login", "user:foo"); wait != 0 {
+		t.Errorf("Allow(Login) = %v, want 0", wait)
+	}
+	if wait, _ := l.Allow(ctx, "Login", "user:foo", "ip:127.0.0.1"); wait != time.Second {
+		t.Errorf("Allow(Login) after burst = %v, want %v", wait, time.Second)
+	}
+	// 被限流的请求不会消耗其他令牌桶中的令牌
+	if wait, _ := l.Allow(ctx, "Login", "ip:127.0.0.1"); wait != 0 {
+		t.Errorf("Allow(Login, ip) = %v, want 0", wait)
+	}
+
+	// 其他调用方不受影响
+	if wait, _ := l.Allow(ctx, "GetPost", "user:bar"); wait != 0 {
+		t.Errorf("Allow(user:bar) = %v, want 0", wait)
+	}
+
+	// 令牌按时间补充
+	now = now.Add(100 * time.Millisecond)
+	if wait, _ := l.Allow(ctx, "GetPost", "user:foo"); wait != 0 {
+		t.Errorf("Allow() after refill = %v, want 0", wait)
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	if rule, err := ParseRule("0.5:5"); err != nil || rule != (Rule{Rate: 0.5, Burst: 5}) {
+		t.Errorf("ParseRule() = %v, %v", rule, err)
+	}
+	for _, s := range []string{"", "1", "a:1", "1:b", "1:0"} {
+		if _, err := ParseRule(s); err == nil {
+			t.Errorf("ParseRule(%q) expected error", s)
+		}
+	}
+}
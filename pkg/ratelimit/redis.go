@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript 原子地补充 KEYS 中每个令牌桶的令牌，所有令牌桶都有令牌时各取出一个令牌，返回需要等待的毫秒数.
+// ARGV 依次为每秒补充的令牌数、令牌桶容量和当前时间（Unix 毫秒）.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local buckets = {}
+local wait = 0
+for i, key in ipairs(KEYS) do
+	local values = redis.call('HMGET', key, 'tokens', 'last')
+	local tokens = tonumber(values[1])
+	local last = tonumber(values[2])
+	if tokens == nil or last == nil then
+		tokens = burst
+		last = now
+	end
+	if now > last then
+		tokens = math.min(burst, tokens + (now - last) * rate / 1000)
+		last = now
+	end
+	if tokens < 1 then
+		wait = math.max(wait, math.ceil((1 - tokens) * 1000 / rate))
+	end
+	buckets[i] = {tokens, last}
+end
+
+for i, key in ipairs(KEYS) do
+	local tokens = buckets[i][1]
+	if wait == 0 then
+		tokens = tokens - 1
+	end
+	redis.call('HSET', key, 'tokens', tostring(tokens), 'last', buckets[i][2])
+	redis.call('PEXPIRE', key, math.ceil(burst * 1000 / rate) + 1000)
+end
+return wait
+`)
+
+// RedisStore 是基于 Redis 的 Store 实现，多实例部署时共享令牌桶.
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// 确保 *RedisStore 实现了 Store 接口.
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore 创建一个 *RedisStore 实例，prefix 会添加到所有 key 之前.
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Take 通过一个 Lua 脚本原子地从 keys 对应的每个令牌桶中各取出一个令牌.
+// Redis 集群要求一个脚本访问的 key 位于同一个哈希槽，可以在 prefix 中使用哈希标签，例如 "{miniblog:ratelimit}:".
+func (s *RedisStore) Take(ctx context.Context, keys []string, rule Rule, now time.Time) (time.Duration, error) {
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, s.prefix+key)
+	}
+
+	wait, err := takeScript.Run(ctx, s.client, prefixed, rule.Rate, rule.Burst, now.UnixMilli()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
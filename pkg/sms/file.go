@@ -0,0 +1,40 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// FileSender 将短信写入目录中的 .txt 文件，适用于本地开发，不会真正发送短信.
+type FileSender struct {
+	dir string
+	seq atomic.Uint64
+}
+
+// 确保 *FileSender 实现了 Sender 接口.
+var _ Sender = (*FileSender)(nil)
+
+// NewFile 创建一个 *FileSender 实例，短信写入 dir 目录.
+func NewFile(dir string) *FileSender {
+	return &FileSender{dir: dir}
+}
+
+// Send 将短信写入文件.
+func (s *FileSender) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%d.txt", now.Format("20060102T150405.000000000"), s.seq.Add(1))
+	content := fmt.Sprintf("To: %s\nDate: %s\n\n%s\n", msg.To, now.Format(time.RFC1123Z), msg.Body)
+	return os.WriteFile(filepath.Join(s.dir, name), []byte(content), 0o600)
+}
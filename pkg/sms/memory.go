@@ -0,0 +1,39 @@
+package sms
+
+import (
+	"context"
+	"sync"
+)
+
+// MemorySender 将短信保存在内存中，适用于测试.
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// 确保 *MemorySender 实现了 Sender 接口.
+var _ Sender = (*MemorySender)(nil)
+
+// NewMemory 创建一个 *MemorySender 实例.
+func NewMemory() *MemorySender {
+	return &MemorySender{}
+}
+
+// Send 将短信保存在内存中.
+func (s *MemorySender) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, *msg)
+	return nil
+}
+
+// Messages 返回已发送短信的副本.
+func (s *MemorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
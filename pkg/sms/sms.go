@@ -0,0 +1,36 @@
+// Package sms 提供发送短信的抽象，以及 Webhook、文件和内存三种实现.
+package sms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Message 表示一条短信.
+type Message struct {
+	// To 表示接收短信的手机号.
+	To string `json:"to"`
+	// Body 表示短信内容.
+	Body string `json:"body"`
+}
+
+// Sender 定义发送短信的接口.
+type Sender interface {
+	// Send 发送一条短信.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// validate 校验短信的接收方和内容.
+func validate(msg *Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("sms: no recipient")
+	}
+	if strings.ContainsAny(msg.To, "\r\n") {
+		return fmt.Errorf("sms: recipient contains line break")
+	}
+	if msg.Body == "" {
+		return fmt.Errorf("sms: empty body")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWebhookSender(t *testing.T) {
+	var got Message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	s := NewWebhook(WebhookConfig{URL: srv.URL, Token: "secret"})
+	if err := s.Send(context.Background(), &Message{To: "18110000000", Body: "123456"}); err != nil {
+		t.Fatal(err)
+	}
+	if got.To != "18110000000" || got.Body != "123456" {
+		t.Fatalf("gateway received %+v", got)
+	}
+
+	s = NewWebhook(WebhookConfig{URL: srv.URL})
+	if err := s.Send(context.Background(), &Message{To: "18110000000", Body: "123456"}); err == nil {
+		t.Fatal("expected non-2xx status to be reported as error")
+	}
+}
+
+func TestFileSender(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFile(dir)
+
+	if err := s.Send(context.Background(), &Message{To: "18110000000", Body: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.HasPrefix(string(data), "To: 18110000000\n") || !strings.HasSuffix(string(data), "hello\n") {
+		t.Fatalf("unexpected sms content: %q", data)
+	}
+}
+
+func TestMemorySender(t *testing.T) {
+	s := NewMemory()
+
+	if err := s.Send(context.Background(), &Message{To: "", Body: "x"}); err == nil {
+		t.Fatal("expected empty recipient to be rejected")
+	}
+	if err := s.Send(context.Background(), &Message{To: "18110000000", Body: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Messages(); len(got) != 1 || got[0].To != "18110000000" {
+		t.Fatalf("Messages() = %v", got)
+	}
+}
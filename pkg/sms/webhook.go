@@ -0,0 +1,67 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookConfig 定义 Webhook 短信网关的配置.
+type WebhookConfig struct {
+	// URL 是短信网关的地址，短信以 JSON 格式 POST 到该地址.
+	URL string
+	// Token 是调用短信网关时使用的 Bearer 令牌，为空表示不认证.
+	Token string
+	// Client 是发送请求使用的 HTTP 客户端，为空时使用 http.DefaultClient.
+	Client *http.Client
+}
+
+// WebhookSender 将短信以 JSON 格式 POST 到短信网关，由网关对接具体的短信服务商.
+type WebhookSender struct {
+	cfg WebhookConfig
+}
+
+// 确保 *WebhookSender 实现了 Sender 接口.
+var _ Sender = (*WebhookSender)(nil)
+
+// NewWebhook 创建一个 *WebhookSender 实例.
+func NewWebhook(cfg WebhookConfig) *WebhookSender {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	return &WebhookSender{cfg: cfg}
+}
+
+// Send 将短信发送到短信网关，网关返回非 2xx 状态码时返回错误.
+func (s *WebhookSender) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+	}
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sms: gateway returned status %d", resp.StatusCode)
+	}
+	return nil
+}
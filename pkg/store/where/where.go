@@ -54,8 +54,9 @@ type Options struct {
 	Queries []Query
 }
 
-// tenant holds the registered tenant instance.
-var registeredTenant Tenant
+// registeredTenants holds the registered tenant dimensions in registration order.
+// The first registered tenant is the default tenant used by T when no keys are given.
+var registeredTenants []Tenant
 
 // WithOffset initializes the Offset field in Options with the given offset value.
 func WithOffset(offset int64) Option {
@@ -176,10 +177,11 @@ func (whr *Options) Q(query interface{}, args ...interface{}) *Options {
 	return whr
 }
 
-// T retrieves the value associated with the registered tenant using the provided context.
-func (whr *Options) T(ctx context.Context) *Options {
-	if registeredTenant.Key != "" && registeredTenant.ValueFunc != nil {
-		whr.F(registeredTenant.Key, registeredTenant.ValueFunc(ctx))
+// T adds filters for the given tenant dimensions, using the values retrieved from the provided context.
+// Without keys, only the default tenant (the first registered one) is applied.
+func (whr *Options) T(ctx context.Context, keys ...string) *Options {
+	for _, tenant := range tenantsFor(keys) {
+		whr.F(tenant.Key, tenant.ValueFunc(ctx))
 	}
 	return whr
 }
@@ -230,8 +232,8 @@ func C(conds ...clause.Expression) *Options {
 }
 
 // T is a convenience function to create a new Options with tenant.
-func T(ctx context.Context) *Options {
-	return NewWhere().F(registeredTenant.Key, registeredTenant.ValueFunc(ctx))
+func T(ctx context.Context, keys ...string) *Options {
+	return NewWhere().T(ctx, keys...)
 }
 
 // F is a convenience function to create a new Options with filters.
@@ -239,10 +241,58 @@ func F(kvs ...any) *Options {
 	return NewWhere().F(kvs...)
 }
 
-// RegisterTenant registers a new tenant with the specified key and value function.
+// RegisterTenant registers a tenant dimension with the specified key and value function.
+// Registering the same key again replaces the previous value function.
 func RegisterTenant(key string, valueFunc func(context.Context) string) {
-	registeredTenant = Tenant{
-		Key:       key,
-		ValueFunc: valueFunc,
+	for i, tenant := range registeredTenants {
+		if tenant.Key == key {
+			registeredTenants[i].ValueFunc = valueFunc
+			return
+		}
+	}
+	registeredTenants = append(registeredTenants, Tenant{Key: key, ValueFunc: valueFunc})
+}
+
+// TenantScope returns a Where that only adds filters for the given tenant dimensions.
+// Unlike T, it does not touch pagination, so the store layer can apply it on top of the caller's
+// options to enforce tenant isolation automatically. Keys that are not registered are ignored.
+func TenantScope(ctx context.Context, keys ...string) Where {
+	return tenantScope{ctx: ctx, keys: keys}
+}
+
+// tenantScope implements the Where interface returned by TenantScope.
+type tenantScope struct {
+	ctx  context.Context
+	keys []string
+}
+
+// Where applies the tenant filters to the given gorm.DB instance.
+func (s tenantScope) Where(db *gorm.DB) *gorm.DB {
+	if len(s.keys) == 0 {
+		return db
+	}
+	for _, tenant := range tenantsFor(s.keys) {
+		db = db.Where(map[string]any{tenant.Key: tenant.ValueFunc(s.ctx)})
+	}
+	return db
+}
+
+// tenantsFor returns the registered tenants with the given keys, or the default tenant when keys is empty.
+func tenantsFor(keys []string) []Tenant {
+	if len(keys) == 0 {
+		if len(registeredTenants) == 0 || registeredTenants[0].ValueFunc == nil {
+			return nil
+		}
+		return registeredTenants[:1]
+	}
+
+	tenants := make([]Tenant, 0, len(keys))
+	for _, key := range keys {
+		for _, tenant := range registeredTenants {
+			if tenant.Key == key && tenant.ValueFunc != nil {
+				tenants = append(tenants, tenant)
+			}
+		}
 	}
+	return tenants
 }
@@ -0,0 +1,59 @@
+package where
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type tenantKey string
+
+type post struct {
+	ID     int64
+	UserID string `gorm:"column:userID"`
+	OrgID  string `gorm:"column:orgID"`
+}
+
+func TestTenants(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	t.Cleanup(func() { registeredTenants = nil })
+	for _, key := range []string{"userID", "orgID"} {
+		RegisterTenant(key, func(ctx context.Context) string {
+			value, _ := ctx.Value(tenantKey(key)).(string)
+			return value
+		})
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey("userID"), "user-1")
+	ctx = context.WithValue(ctx, tenantKey("orgID"), "org-1")
+
+	tests := []struct {
+		name  string
+		where []Where
+		want  string
+	}{
+		{"default tenant", []Where{T(ctx)}, "SELECT * FROM `posts` WHERE `userID` = ?"},
+		{"tenant by key", []Where{T(ctx, "orgID")}, "SELECT * FROM `posts` WHERE `orgID` = ?"},
+		{"scope keeps pagination", []Where{P(2, 10), TenantScope(ctx, "orgID")}, "SELECT * FROM `posts` WHERE `posts`.`orgID` = ? LIMIT 10 OFFSET 10"},
+		{"unknown key is ignored", []Where{TenantScope(ctx, "teamID")}, "SELECT * FROM `posts`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := db.Model(&post{})
+			for _, whr := range tt.where {
+				tx = whr.Where(tx)
+			}
+			stmt := tx.Find(&[]post{}).Statement
+			if got := strings.TrimSpace(stmt.SQL.String()); got != tt.want {
+				t.Errorf("SQL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 许铭杰 (1044011439@qq.com). All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix 是 API Key 的固定前缀，用于在 Authorization 头中区分 API Key 和 JWT.
+const APIKeyPrefix = "mbk_"
+
+// apiKeyEntropy 是 API Key 随机部分的字节数.
+const apiKeyEntropy = 32
+
+// Credential 表示从请求中解析出的身份凭证.
+type Credential struct {
+	// Identity 是凭证对应的身份，例如用户 ID.
+	Identity string
+	// KeyID 是 API Key 的 ID，使用 JWT 认证时为空.
+	KeyID string
+	// Scopes 是 API Key 的权限范围，为空表示不限制.
+	Scopes []string
+	// IssuedAt 是 JWT 的签发时间，使用 API Key 认证时为零值.
+	IssuedAt time.Time
+	// Actor 是代理身份 token 的真实操作者，Identity 为被代理的用户. 不是代理身份时为空.
+	Actor string
+}
+
+// APIKeyResolver 用于将 API Key 解析为身份凭证.
+type APIKeyResolver func(ctx context.Context, apiKey string) (*Credential, error)
+
+// apiKeyResolver 保存注册的 API Key 解析器.
+var apiKeyResolver APIKeyResolver
+
+// RegisterAPIKeyResolver 注册 API Key 解析器. 未注册时，ParseRequest 只接受 JWT.
+func RegisterAPIKeyResolver(resolver APIKeyResolver) {
+	apiKeyResolver = resolver
+}
+
+// IsAPIKey 判断令牌是否为 API Key.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// NewAPIKey 生成一个新的随机 API Key，返回 API Key 明文及其哈希值.
+// 明文只应返回给用户一次，数据库中只保存哈希值.
+func NewAPIKey() (string, string, error) {
+	buf := make([]byte, apiKeyEntropy)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	apiKey := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return apiKey, HashAPIKey(apiKey), nil
+}
+
+// HashAPIKey 计算 API Key 的哈希值.
+// API Key 本身具有足够的随机性，因此使用 SHA-256 即可，同时便于按哈希值索引查询.
+func HashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
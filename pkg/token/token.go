@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -30,6 +31,12 @@ type Config struct {
 	expiration time.Duration
 }
 
+// purposeClaim 是用途声明在 token 中的键.
+const purposeClaim = "purpose"
+
+// actorClaim 是代理身份 token 中真实操作者的键，参见 RFC 8693 中的 act 声明.
+const actorClaim = "act"
+
 var (
 	config = Config{"Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5", "identityKey", 2 * time.Hour}
 	once   sync.Once // 确保配置只被初始化一次
@@ -52,6 +59,15 @@ func Init(key string, identityKey string, expiration time.Duration) {
 
 // Parse 使用指定的密钥 key 解析 token，解析成功返回 token 上下文，否则报错.
 func Parse(tokenString string, key string) (string, error) {
+	credential, err := parse(tokenString, key)
+	if err != nil {
+		return "", err
+	}
+	return credential.Identity, nil
+}
+
+// parse 解析 token，返回 token 中的身份、真实操作者和签发时间.
+func parse(tokenString string, key string) (*Credential, error) {
 	// 解析 token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// 确保 token 加密算法是预期的加密算法
@@ -63,27 +79,49 @@ func Parse(tokenString string, key string) (string, error) {
 	})
 	// 解析失败
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	var identityKey string
+	credential := &Credential{}
 	// 如果解析成功，从 token 中取出 token 的主题
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		// 带有用途声明的 token 只能用于特定流程，不能作为访问令牌使用
+		if _, exists := claims[purposeClaim]; exists {
+			return nil, jwt.ErrSignatureInvalid
+		}
 		if key, exists := claims[config.identityKey]; exists {
 			if identity, valid := key.(string); valid {
-				identityKey = identity // 获取身份键
+				credential.Identity = identity // 获取身份键
 			}
 		}
+		if actor, valid := claims[actorClaim].(string); valid {
+			credential.Actor = actor
+		}
+		if iat, valid := claims["iat"].(float64); valid {
+			credential.IssuedAt = time.UnixMilli(int64(math.Round(iat * 1000)))
+		}
 	}
-	if identityKey == "" {
-		return "", jwt.ErrSignatureInvalid
+	if credential.Identity == "" {
+		return nil, jwt.ErrSignatureInvalid
 	}
 
-	return identityKey, nil
+	return credential, nil
 }
 
 // ParseRequest 从请求头中获取令牌，并将其传递给 Parse 函数以解析令牌.
+// 如果请求携带的是 API Key，则交给注册的 APIKeyResolver 解析.
 func ParseRequest(ctx context.Context) (string, error) {
+	credential, err := ParseRequestCredential(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return credential.Identity, nil
+}
+
+// ParseRequestCredential 从请求头中获取令牌并解析为身份凭证.
+// 与 ParseRequest 不同的是，它会返回 API Key 的 ID 和权限范围.
+func ParseRequestCredential(ctx context.Context) (*Credential, error) {
 	var (
 		token string
 		err   error
@@ -95,7 +133,7 @@ func ParseRequest(ctx context.Context) (string, error) {
 		header := typed.Request.Header.Get("Authorization")
 		if len(header) == 0 {
 			//nolint: err113
-			return "", errors.New("the length of the `Authorization` header is zero") // 返回错误
+			return nil, errors.New("the length of the `Authorization` header is zero") // 返回错误
 		}
 
 		// 从请求头中取出 token
@@ -104,11 +142,20 @@ func ParseRequest(ctx context.Context) (string, error) {
 	default:
 		token, err = auth.AuthFromMD(typed, "Bearer")
 		if err != nil {
-			return "", status.Errorf(codes.Unauthenticated, "invalid auth token")
+			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token")
+		}
+	}
+
+	// API Key 和 JWT 共用 Authorization 头，通过固定前缀区分
+	if IsAPIKey(token) {
+		if apiKeyResolver == nil {
+			//nolint: err113
+			return nil, errors.New("api key authentication is not enabled")
 		}
+		return apiKeyResolver(ctx, token)
 	}
 
-	return Parse(token, config.key) // 解析 token
+	return parse(token, config.key) // 解析 token
 }
 
 // Sign 使用 jwtSecret 签发 token，token 的 claims 中会存放传入的 subject.
@@ -118,10 +165,10 @@ func Sign(identityKey string) (string, time.Time, error) {
 
 	// Token 的内容
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		config.identityKey: identityKey,       // 存放用户身份
-		"nbf":              time.Now().Unix(), // token 生效时间
-		"iat":              time.Now().Unix(), // token 签发时间
-		"exp":              expireAt.Unix(),   // token 过期时间
+		config.identityKey: identityKey,          // 存放用户身份
+		"nbf":              time.Now().Unix(),    // token 生效时间
+		"iat":              issuedAt(time.Now()), // token 签发时间，精确到毫秒
+		"exp":              expireAt.Unix(),      // token 过期时间
 	})
 
 	// 签发 token
@@ -131,4 +178,78 @@ func Sign(identityKey string) (string, time.Time, error) {
 	}
 
 	return tokenString, expireAt, nil // 返回 token 字符串、过期时间和错误
-}
\ No newline at end of file
+}
+
+// SignImpersonation 签发一个代理身份的短期 token. token 的身份为被代理的用户 identityKey，
+// 同时在 act 声明中记录真实操作者 actor，解析后可以通过 Credential.Actor 获取.
+func SignImpersonation(identityKey string, actor string, expiration time.Duration) (string, time.Time, error) {
+	expireAt := time.Now().Add(expiration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		config.identityKey: identityKey,          // 存放被代理用户的身份
+		actorClaim:         actor,                // 存放真实操作者的身份
+		"nbf":              time.Now().Unix(),    // token 生效时间
+		"iat":              issuedAt(time.Now()), // token 签发时间，精确到毫秒
+		"exp":              expireAt.Unix(),      // token 过期时间
+	})
+
+	tokenString, err := token.SignedString([]byte(config.key))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expireAt, nil
+}
+
+// SignWithPurpose 签发一个仅用于特定用途的短期 token，例如两步验证的登录挑战.
+// 这类 token 不包含身份键，因此无法通过 Parse 当作访问令牌使用.
+func SignWithPurpose(purpose string, subject string, expiration time.Duration) (string, time.Time, error) {
+	expireAt := time.Now().Add(expiration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		purposeClaim: purpose,           // token 用途
+		"sub":        subject,           // token 主题
+		"nbf":        time.Now().Unix(), // token 生效时间
+		"iat":        time.Now().Unix(), // token 签发时间
+		"exp":        expireAt.Unix(),   // token 过期时间
+	})
+
+	tokenString, err := token.SignedString([]byte(config.key))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expireAt, nil
+}
+
+// ParseWithPurpose 解析 SignWithPurpose 签发的 token，并校验其用途，成功时返回 token 的主题.
+func ParseWithPurpose(tokenString string, purpose string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+
+		return []byte(config.key), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims[purposeClaim] != purpose {
+		return "", jwt.ErrSignatureInvalid
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return "", jwt.ErrSignatureInvalid
+	}
+
+	return subject, nil
+}
+
+// issuedAt 返回精确到毫秒的 iat 声明. 签发时间需要与会话吊销时间比较，
+// 只精确到秒时，与吊销在同一秒内签发的新 token 无法与吊销前签发的 token 区分.
+func issuedAt(now time.Time) float64 {
+	return float64(now.UnixMilli()) / 1000
+}